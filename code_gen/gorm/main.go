@@ -57,6 +57,17 @@ func main() {
 		&dbModel.ManualGradingRubric{},
 		&dbModel.ManualGradingResult{},
 		&dbModel.AssignmentJunitTest{},
+		&dbModel.AssignmentTemplateVariant{},
+		&dbModel.AssignmentReminder{},
+		&dbModel.NotificationPreference{},
+		&dbModel.PendingNotification{},
+		&dbModel.OutgoingMail{},
+		&dbModel.GradingComment{},
+		&dbModel.APIToken{},
+		&dbModel.Webhook{},
+		&dbModel.WebhookDelivery{},
+		&dbModel.GradingRun{},
+		&dbModel.GradingRunResult{},
 	)
 
 	g.ApplyInterface(func(TeamQuerier) {}, dbModel.Team{})
@@ -174,6 +174,12 @@ func MigrateDatabase(db *gorm.DB) error {
 		&database.ManualGradingRubric{},
 		&database.ManualGradingResult{},
 		&database.AssignmentJunitTest{},
+		&database.AssignmentTemplateVariant{},
+		&database.AssignmentReminder{},
+		&database.NotificationPreference{},
+		&database.PendingNotification{},
+		&database.OutgoingMail{},
+		&database.GradingComment{},
 	)
 }
 
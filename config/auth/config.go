@@ -8,4 +8,5 @@ import (
 type Config interface {
 	GetOAuthConfig() *oauth2.Config
 	GetRedirectUrl() *url.URL
+	GetTokenKey() string
 }
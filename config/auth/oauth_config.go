@@ -13,6 +13,15 @@ type OAuthConfig struct {
 	AuthURL      *url.URL `env:"AUTH_URL,expand" envDefault:"$GITLAB_URL/oauth/authorize"`
 	TokenURL     *url.URL `env:"TOKEN_URL,expand" envDefault:"$GITLAB_URL/oauth/token"`
 	Scopes       []string `env:"SCOPES" envSeparator:"," envDefault:"api"`
+	// TokenKey encrypts the GitLab tokens stored for API tokens, the client secret is used if it is empty
+	TokenKey string `env:"TOKEN_KEY"`
+}
+
+func (c *OAuthConfig) GetTokenKey() string {
+	if c.TokenKey != "" {
+		return c.TokenKey
+	}
+	return c.ClientSecret
 }
 
 func (c *OAuthConfig) GetRedirectUrl() *url.URL {
@@ -11,17 +11,20 @@ import (
 	"gitlab.hs-flensburg.de/gitlab-classroom/config/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/config/gitlab"
 	"gitlab.hs-flensburg.de/gitlab-classroom/config/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/config/reminder"
 )
 
 type ApplicationConfig struct {
-	PublicURL      *url.URL             `env:"PUBLIC_URL" envDefault:"https://staging.hs-flensburg.dev"`
-	Port           int                  `env:"PORT" envDefault:"3000"`
-	FrontendPath   string               `env:"FRONTEND_PATH" envDefault:"./public"`
-	TrustedProxies []string             `env:"TRUSTED_PROXIES" envSeparator:"," envDefault:""`
-	GitLab         *gitlab.GitlabConfig `envPrefix:"GITLAB_"`
-	Database       *database.PsqlConfig `envPrefix:"POSTGRES_"`
-	Auth           *auth.OAuthConfig    `envPrefix:"AUTH_"`
-	Mail           *mail.MailConfig     `envPrefix:"SMTP_"`
+	PublicURL      *url.URL                 `env:"PUBLIC_URL" envDefault:"https://staging.hs-flensburg.dev"`
+	Port           int                      `env:"PORT" envDefault:"3000"`
+	FrontendPath   string                   `env:"FRONTEND_PATH" envDefault:"./public"`
+	TrustedProxies []string                 `env:"TRUSTED_PROXIES" envSeparator:"," envDefault:""`
+	DevMode        bool                     `env:"DEV_MODE" envDefault:"false"`
+	GitLab         *gitlab.GitlabConfig     `envPrefix:"GITLAB_"`
+	Database       *database.PsqlConfig     `envPrefix:"POSTGRES_"`
+	Auth           *auth.OAuthConfig        `envPrefix:"AUTH_"`
+	Mail           *mail.MailConfig         `envPrefix:"SMTP_"`
+	Reminder       *reminder.ReminderConfig `envPrefix:"REMINDER_"`
 }
 
 func LoadApplicationConfig() (*ApplicationConfig, error) {
@@ -34,6 +37,7 @@ func LoadApplicationConfig() (*ApplicationConfig, error) {
 		Database: &database.PsqlConfig{},
 		Auth:     &auth.OAuthConfig{},
 		Mail:     &mail.MailConfig{},
+		Reminder: &reminder.ReminderConfig{},
 	}
 	if err := env.Parse(config); err != nil {
 		return nil, err
@@ -5,4 +5,14 @@ type Config interface {
 	GetPort() int
 	GetUser() string
 	GetPassword() string
+	GetFromName() string
+	GetFromAddress() string
+	GetReplyTo() string
+	GetLogoURL() string
+	GetTemplateDir() string
+	GetDefaultLanguage() string
+	GetTransport() Transport
+	GetTLSMode() TLSMode
+	GetTLSSkipVerify() bool
+	GetMailDir() string
 }
@@ -1,10 +1,46 @@
 package mail
 
+// Transport selects how rendered mails are delivered.
+type Transport string
+
+const (
+	// SMTPTransport sends the mails to the configured SMTP server.
+	SMTPTransport Transport = "smtp"
+	// FileTransport writes every mail as .eml file to the mail directory.
+	FileTransport Transport = "file"
+	// MemoryTransport keeps the mails in memory, where they can be inspected via the dev endpoint.
+	MemoryTransport Transport = "memory"
+)
+
+// TLSMode selects how the connection to the SMTP server is secured.
+type TLSMode string
+
+const (
+	// NoTLS sends the mails unencrypted, e.g. to a local mail catcher.
+	NoTLS TLSMode = "none"
+	// StartTLS upgrades the connection with STARTTLS and fails if the server does not support it.
+	StartTLS TLSMode = "starttls"
+	// ImplicitTLS connects with TLS from the start, usually on port 465.
+	ImplicitTLS TLSMode = "tls"
+)
+
 type MailConfig struct {
 	Host     string `env:"HOST"`
 	Port     int    `env:"PORT"`
 	User     string `env:"USER"`
 	Password string `env:"PASSWORD"`
+
+	FromName        string `env:"FROM_NAME" envDefault:"GitClassrooms"`
+	FromAddress     string `env:"FROM_ADDRESS"`
+	ReplyTo         string `env:"REPLY_TO"`
+	LogoURL         string `env:"LOGO_URL"`
+	TemplateDir     string `env:"TEMPLATE_DIR"`
+	DefaultLanguage string `env:"DEFAULT_LANGUAGE" envDefault:"en"`
+
+	Transport     Transport `env:"TRANSPORT" envDefault:"smtp"`
+	TLSMode       TLSMode   `env:"TLS_MODE" envDefault:"starttls"`
+	TLSSkipVerify bool      `env:"TLS_SKIP_VERIFY" envDefault:"false"`
+	MailDir       string    `env:"MAIL_DIR" envDefault:"./mails"`
 }
 
 func (c *MailConfig) GetHost() string {
@@ -22,3 +58,55 @@ func (c *MailConfig) GetUser() string {
 func (c *MailConfig) GetPassword() string {
 	return c.Password
 }
+
+// GetFromAddress returns the sender address, which defaults to the SMTP user.
+func (c *MailConfig) GetFromAddress() string {
+	if c.FromAddress == "" {
+		return c.User
+	}
+	return c.FromAddress
+}
+
+func (c *MailConfig) GetFromName() string {
+	return c.FromName
+}
+
+func (c *MailConfig) GetReplyTo() string {
+	return c.ReplyTo
+}
+
+func (c *MailConfig) GetLogoURL() string {
+	return c.LogoURL
+}
+
+func (c *MailConfig) GetTemplateDir() string {
+	return c.TemplateDir
+}
+
+func (c *MailConfig) GetDefaultLanguage() string {
+	return c.DefaultLanguage
+}
+
+// GetTransport returns the configured transport, which defaults to SMTPTransport.
+func (c *MailConfig) GetTransport() Transport {
+	if c.Transport == "" {
+		return SMTPTransport
+	}
+	return c.Transport
+}
+
+// GetTLSMode returns the configured TLS mode, which defaults to StartTLS.
+func (c *MailConfig) GetTLSMode() TLSMode {
+	if c.TLSMode == "" {
+		return StartTLS
+	}
+	return c.TLSMode
+}
+
+func (c *MailConfig) GetTLSSkipVerify() bool {
+	return c.TLSSkipVerify
+}
+
+func (c *MailConfig) GetMailDir() string {
+	return c.MailDir
+}
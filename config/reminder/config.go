@@ -0,0 +1,8 @@
+package reminder
+
+import "time"
+
+type Config interface {
+	GetDeadlineOffsets() []time.Duration
+	GetAcceptanceOffsets() []time.Duration
+}
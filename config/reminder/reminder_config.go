@@ -0,0 +1,17 @@
+package reminder
+
+import "time"
+
+type ReminderConfig struct {
+	Interval          time.Duration   `env:"INTERVAL" envDefault:"15m"`
+	DeadlineOffsets   []time.Duration `env:"DEADLINE_OFFSETS" envSeparator:"," envDefault:"72h,24h"`
+	AcceptanceOffsets []time.Duration `env:"ACCEPTANCE_OFFSETS" envSeparator:"," envDefault:"72h"`
+}
+
+func (c *ReminderConfig) GetDeadlineOffsets() []time.Duration {
+	return c.DeadlineOffsets
+}
+
+func (c *ReminderConfig) GetAcceptanceOffsets() []time.Duration {
+	return c.AcceptanceOffsets
+}
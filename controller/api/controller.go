@@ -26,17 +26,34 @@ type Controller interface {
 	GetMultipleProjectCloneUrls(*fiber.Ctx) error
 
 	GetMe(*fiber.Ctx) error
+	UpdateMe(*fiber.Ctx) error
 	GetMeGitlab(*fiber.Ctx) error
+	GetMeNotifications(*fiber.Ctx) error
+	UpdateMeNotifications(*fiber.Ctx) error
+	GetMeTokens(*fiber.Ctx) error
+	CreateMeToken(*fiber.Ctx) error
+	DeleteMeToken(*fiber.Ctx) error
+	GetMeEvents(*fiber.Ctx) error
 	GetActiveAssignments(*fiber.Ctx) error
 
+	GetDevMails(*fiber.Ctx) error
+	DeleteDevMails(*fiber.Ctx) error
+
 	GetClassrooms(*fiber.Ctx) error
 	CreateClassroom(*fiber.Ctx) error
 	ClassroomMiddleware(*fiber.Ctx) error
 	GetClassroom(*fiber.Ctx) error
 	UpdateClassroom(*fiber.Ctx) error
 	ArchiveClassroom(*fiber.Ctx) error
+	UnarchiveClassroom(*fiber.Ctx) error
+	DuplicateClassroom(*fiber.Ctx) error
+	ExportClassroom(*fiber.Ctx) error
+	ImportClassroom(*fiber.Ctx) error
 
 	GetClassroomTemplates(*fiber.Ctx) error
+	CreateClassroomTemplate(*fiber.Ctx) error
+	GetTemplateStarterKits(*fiber.Ctx) error
+	ValidateTemplateProject(*fiber.Ctx) error
 
 	GetClassroomAssignments(*fiber.Ctx) error
 	CreateAssignment(*fiber.Ctx) error
@@ -56,16 +73,28 @@ type Controller interface {
 	InviteToAssignment(*fiber.Ctx) error
 	ClassroomAssignmentProjectMiddleware(*fiber.Ctx) error
 	GetClassroomAssignmentProject(*fiber.Ctx) error
+	GetProjectRepositoryTree(*fiber.Ctx) error
+	GetProjectRepositoryFile(*fiber.Ctx) error
+	GetProjectRepositoryDiff(*fiber.Ctx) error
 
 	GetGradingResults(c *fiber.Ctx) (err error)
 	UpdateGradingResults(c *fiber.Ctx) (err error)
+	GetGradingComments(*fiber.Ctx) error
+	CreateGradingComment(*fiber.Ctx) error
 
 	StartAutoGrading(c *fiber.Ctx) (err error)
+	GetAssignmentGradingRuns(*fiber.Ctx) error
+	GetAssignmentGradingRun(*fiber.Ctx) error
+	CancelAssignmentGradingRun(*fiber.Ctx) error
 	StartAutoGradingForProject(c *fiber.Ctx) (err error)
 
+	GetAssignmentPipelines(*fiber.Ctx) error
+	RunAssignmentPipelines(*fiber.Ctx) error
+
 	GetClassroomReport(c *fiber.Ctx) (err error)
 	GetClassroomAssignmentReport(c *fiber.Ctx) (err error)
 	GetClassroomTeamReport(c *fiber.Ctx) (err error)
+	GetStudentReport(*fiber.Ctx) error
 
 	GetClassroomProjects(*fiber.Ctx) error
 	AcceptAssignment(*fiber.Ctx) error
@@ -78,14 +107,32 @@ type Controller interface {
 	InviteToClassroom(*fiber.Ctx) error
 	RevokeClassroomInvitation(*fiber.Ctx) error
 
+	GetClassroomMails(*fiber.Ctx) error
+	ResendClassroomMail(*fiber.Ctx) error
+
+	GetClassroomWebhooks(*fiber.Ctx) error
+	CreateClassroomWebhook(*fiber.Ctx) error
+	UpdateClassroomWebhook(*fiber.Ctx) error
+	DeleteClassroomWebhook(*fiber.Ctx) error
+	GetClassroomWebhookDeliveries(*fiber.Ctx) error
+	RedeliverClassroomWebhookDelivery(*fiber.Ctx) error
+
+	GetClassroomEvents(*fiber.Ctx) error
+
 	GetClassroomMembers(*fiber.Ctx) error
 	ClassroomMemberMiddleware(*fiber.Ctx) error
 	GetClassroomMember(*fiber.Ctx) error
 	UpdateMemberTeam(*fiber.Ctx) error
 	UpdateMemberRole(*fiber.Ctx) error
+	RemoveClassroomMember(*fiber.Ctx) error
 
 	GetClassroomRunners(c *fiber.Ctx) error
 	GetClassroomRunnersAreAvailable(c *fiber.Ctx) error
+	GetClassroomRunnerOverview(*fiber.Ctx) error
+	GetClassroomGroupRunners(c *fiber.Ctx) error
+	CreateClassroomGroupRunner(c *fiber.Ctx) error
+	UpdateClassroomGroupRunner(c *fiber.Ctx) error
+	DeleteClassroomGroupRunner(c *fiber.Ctx) error
 
 	GetClassroomTeams(*fiber.Ctx) error
 	CreateTeam(*fiber.Ctx) error
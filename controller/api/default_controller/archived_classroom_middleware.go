@@ -1,14 +1,28 @@
 package api
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
 
+// archivedClassroomActions are the requests allowed on an archived classroom, as they do not modify it.
+var archivedClassroomActions = map[string]string{
+	"/unarchive": fiber.MethodPatch,
+	"/duplicate": fiber.MethodPost,
+}
+
 func (ctrl *DefaultController) ArchivedMiddleware(c *fiber.Ctx) error {
 	ctx := context.Get(c)
 	classroom := ctx.GetUserClassroom()
 
+	for suffix, method := range archivedClassroomActions {
+		if c.Method() == method && strings.HasSuffix(c.Path(), suffix) {
+			return c.Next()
+		}
+	}
+
 	switch c.Method() {
 	case fiber.MethodPost:
 		fallthrough
@@ -10,7 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
-	mailRepoMock "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail/_mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
 
@@ -29,8 +29,7 @@ func TestArchivedClassroomMiddleware(t *testing.T) {
 		return c.Next()
 	})
 
-	mailRepo := mailRepoMock.NewMockRepository(t)
-	handler := NewApiV1Controller(mailRepo, config.ApplicationConfig{})
+	handler := NewApiV1Controller(config.ApplicationConfig{}, nil, events.NewBroker())
 	app.Use("/api/v1/classrooms/:classroomId", handler.ArchivedMiddleware)
 
 	targetRoute := fmt.Sprintf("/api/v1/classrooms/%s", userClassroom.Classroom.ID.String())
@@ -51,6 +50,20 @@ func TestArchivedClassroomMiddleware(t *testing.T) {
 		testForbiddenMethod(t, app, targetRoute, fiber.MethodDelete)
 	})
 
+	t.Run("Allows unarchive for archived classroom", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodPatch, targetRoute+"/unarchive", nil)
+		resp, err := app.Test(req)
+		assert.NotEqual(t, fiber.StatusForbidden, resp.StatusCode)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Allows duplicate for archived classroom", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodPost, targetRoute+"/duplicate", nil)
+		resp, err := app.Test(req)
+		assert.NotEqual(t, fiber.StatusForbidden, resp.StatusCode)
+		assert.NoError(t, err)
+	})
+
 	t.Run("Allows get for archived classroom", func(t *testing.T) {
 		req := httptest.NewRequest(fiber.MethodGet, targetRoute, nil)
 		resp, err := app.Test(req)
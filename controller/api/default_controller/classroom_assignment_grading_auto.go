@@ -1,14 +1,26 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	fiberContext "gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm/clause"
 )
 
 type startAutoGradingRequest struct {
@@ -19,24 +31,35 @@ func (r startAutoGradingRequest) isValid() bool {
 	return r.JUnitAutoGrading != nil
 }
 
+// gradingRunTimeout limits the duration of a grading run, the remaining projects are canceled afterwards
+const gradingRunTimeout = 30 * time.Minute
+
+var errGradingRunRunning = errors.New("A grading run of the assignment is already running")
+
 // @Summary		StartAutoGrading
-// @Description	StartAutoGrading
+// @Description	Start a grading run, which grades the accepted projects of the assignment in the background. The progress is recorded per project in the grading run.
+// @Description	Only one grading run of an assignment can run at a time.
 // @Id				StartAutoGrading
 // @Tags			grading
 // @Accept			json
-// @Param			classroomId		path	string						true	"Classroom ID"	Format(uuid)
-// @Param			assignmentId	path	string						true	"Assignment ID"	Format(uuid)
-// @Param			assignmentInfo	body	api.startAutoGradingRequest	true	"Grading Update Info"
-// @Param			X-Csrf-Token	header	string						true	"Csrf-Token"
-// @Success		202
-// @Failure		400	{object}	HTTPError
-// @Failure		401	{object}	HTTPError
-// @Failure		403	{object}	HTTPError
-// @Failure		404	{object}	HTTPError
-// @Failure		500	{object}	HTTPError
+// @Produce		json
+// @Param			classroomId		path		string						true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path		string						true	"Assignment ID"	Format(uuid)
+// @Param			assignmentInfo	body		api.startAutoGradingRequest	true	"Grading Update Info"
+// @Param			X-Csrf-Token	header		string						true	"Csrf-Token"
+// @Success		202				{object}	database.GradingRun
+// @Header			202				{string}	Location	"/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/grading/runs/{runId}"
+// @Success		204
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		409				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
 // @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/grading/auto [post]
 func (ctrl *DefaultController) StartAutoGrading(c *fiber.Ctx) (err error) {
 	ctx := fiberContext.Get(c)
+	classroom := ctx.GetUserClassroom()
 	repo := ctx.GetGitlabRepository()
 	assignment := ctx.GetAssignment()
 
@@ -49,6 +72,19 @@ func (ctrl *DefaultController) StartAutoGrading(c *fiber.Ctx) (err error) {
 		return fiber.NewError(fiber.StatusBadRequest, "Request Body is not valid")
 	}
 
+	if !*requestBody.JUnitAutoGrading {
+		return c.SendStatus(fiber.StatusNoContent) // Nothing to grade
+	}
+
+	if !assignment.GradingJUnitAutoGradingActive {
+		return fiber.NewError(fiber.StatusBadRequest, "JUnit Auto Grading is not active")
+	}
+
+	// The run outlasts the OAuth token of the request, so it grades with the group access token of the classroom
+	if err = repo.GroupAccessLogin(classroom.Classroom.GroupAccessToken); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
 	queryAssignmentProjects := query.AssignmentProjects
 	projects, err := queryAssignmentProjects.
 		WithContext(c.Context()).
@@ -59,30 +95,168 @@ func (ctrl *DefaultController) StartAutoGrading(c *fiber.Ctx) (err error) {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	if *requestBody.JUnitAutoGrading {
-		if !assignment.GradingJUnitAutoGradingActive {
-			return fiber.NewError(fiber.StatusBadRequest, "JUnit Auto Grading is not active")
+	run := &database.GradingRun{
+		AssignmentID: assignment.ID,
+		StartedByID:  ctx.GetUserID(),
+		Status:       database.GradingRunRunning,
+		Results: utils.Map(projects, func(project *database.AssignmentProjects) *database.GradingRunResult {
+			return &database.GradingRunResult{ProjectID: project.ID, Status: database.GradingResultPending}
+		}),
+	}
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		// Lock the assignment, so concurrent requests can not start two runs
+		queryAssignment := tx.Assignment
+		if _, err := queryAssignment.
+			WithContext(c.Context()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(queryAssignment.ID.Eq(assignment.ID)).
+			First(); err != nil {
+			return err
+		}
+
+		queryRun := tx.GradingRun
+		running, err := queryRun.
+			WithContext(c.Context()).
+			Where(queryRun.AssignmentID.Eq(assignment.ID)).
+			Where(queryRun.Status.Eq(string(database.GradingRunRunning))).
+			Count()
+		if err != nil {
+			return err
 		}
+		if running > 0 {
+			return errGradingRunRunning
+		}
+
+		return queryRun.WithContext(c.Context()).Create(run)
+	})
+	if err != nil {
+		if errors.Is(err, errGradingRunRunning) {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	runCtx, cancel := context.WithTimeout(context.Background(), gradingRunTimeout)
+	ctrl.gradingRuns.Store(run.ID, cancel)
+
+	// Grade the projects async, the run is only changed in the database from now on
+	go ctrl.runAutoGrading(runCtx, cancel, repo, assignment, run, projects)
+
+	c.Set("Location", fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/grading/runs/%s", assignment.ClassroomID.String(), assignment.ID.String(), run.ID.String()))
+	return c.Status(fiber.StatusAccepted).JSON(run)
+}
+
+// runAutoGrading grades the projects of the run concurrently and records the outcome of every project.
+// The projects, which are not graded before the context is done, are marked as canceled.
+func (ctrl *DefaultController) runAutoGrading(ctx context.Context, cancel context.CancelFunc, repo gitlab.Repository, assignment *database.Assignment, run *database.GradingRun, projects []*database.AssignmentProjects) {
+	defer cancel()
+	defer ctrl.gradingRuns.Delete(run.ID)
+
+	// The outcome of a project is stored even if the run is canceled meanwhile
+	storeCtx := context.WithoutCancel(ctx)
+	queryResult := query.GradingRunResult
 
-		for _, project := range projects {
-			report, err := repo.GetProjectLatestPipelineTestReportSummary(project.ProjectID, nil)
+	var completed atomic.Int64
+	var eg errgroup.Group
+	eg.SetLimit(pipelineConcurrency)
+	for i, result := range run.Results {
+		project := projects[i]
+		eg.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			status, err := gradeProject(storeCtx, repo, assignment.ClassroomID, project)
+
+			update := &database.GradingRunResult{Status: status}
+			if err != nil {
+				update.Error = utils.Ptr(err.Error())
+			}
+			if _, err := queryResult.WithContext(storeCtx).Where(queryResult.ID.Eq(result.ID)).Updates(update); err != nil {
+				log.Println("Error while saving the grading run result", err)
+			}
+
+			data := events.AutoGradingData{
+				RunID:        run.ID,
+				AssignmentID: assignment.ID,
+				ProjectID:    project.ID,
+				Status:       status,
+				Completed:    int(completed.Add(1)),
+				Total:        len(projects),
+			}
 			if err != nil {
-				var gitlabError *model.GitLabError
-				if errors.As(err, &gitlabError) {
-					if gitlabError.Response.StatusCode == http.StatusForbidden {
-						return fiber.NewError(fiber.StatusNotFound, "No executed pipeline yet available on the main branch")
-					}
-				}
-				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+				data.Error = err.Error()
 			}
+			ctrl.broker.Publish(events.Event{Type: events.AutoGradingEvent, ClassroomID: assignment.ClassroomID, Data: data})
+			return nil
+		})
+	}
+	_ = eg.Wait()
 
-			project.GradingJUnitTestResult = &database.JUnitTestResult{TestReport: *report}
+	status := database.GradingRunCompleted
+	if ctx.Err() != nil {
+		info, err := queryResult.
+			WithContext(storeCtx).
+			Where(queryResult.GradingRunID.Eq(run.ID)).
+			Where(queryResult.Status.Eq(string(database.GradingResultPending))).
+			Update(queryResult.Status, database.GradingResultCanceled)
+		if err != nil {
+			log.Println("Error while canceling the grading run results", err)
+		} else if info.RowsAffected > 0 {
+			status = database.GradingRunCanceled
+		}
+	}
 
-			if err := query.AssignmentProjects.WithContext(c.Context()).Save(project); err != nil {
-				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	queryRun := query.GradingRun
+	if _, err := queryRun.
+		WithContext(storeCtx).
+		Where(queryRun.ID.Eq(run.ID)).
+		Updates(&database.GradingRun{Status: status, FinishedAt: utils.Ptr(time.Now())}); err != nil {
+		log.Println("Error while finishing the grading run", err)
+	}
+}
+
+// gradeProject saves the test report of the latest pipeline of the project as its JUnit grading.
+// Projects without an executed pipeline are skipped.
+func gradeProject(ctx context.Context, repo gitlab.Repository, classroomID uuid.UUID, project *database.AssignmentProjects) (database.GradingRunResultStatus, error) {
+	report, err := repo.GetProjectLatestPipelineTestReportSummary(project.ProjectID, nil)
+	if err != nil {
+		var gitlabError *model.GitLabError
+		if errors.As(err, &gitlabError) {
+			// The project has no pipeline or no executed pipeline with a test report yet
+			if gitlabError.Response.StatusCode == http.StatusNotFound || gitlabError.Response.StatusCode == http.StatusForbidden {
+				return database.GradingResultSkipped, errors.New("No executed pipeline yet available on the main branch")
 			}
 		}
+		return database.GradingResultFailed, err
+	}
+
+	project.GradingJUnitTestResult = &database.JUnitTestResult{TestReport: *report}
+
+	if err = saveAutoGradingResult(ctx, classroomID, project); err != nil {
+		return database.GradingResultFailed, err
 	}
+	return database.GradingResultGraded, nil
+}
+
+// saveAutoGradingResult saves the test result of the project, emits the updated grades to the webhooks of the classroom
+// and notifies the team of the project.
+// Only the test result is written, so concurrent changes of the project, e.g. its manual grading, are kept.
+func saveAutoGradingResult(ctx context.Context, classroomID uuid.UUID, project *database.AssignmentProjects) error {
+	return query.Q.Transaction(func(tx *query.Query) error {
+		queryAssignmentProjects := tx.AssignmentProjects
+		if _, err := queryAssignmentProjects.
+			WithContext(ctx).
+			Where(queryAssignmentProjects.ID.Eq(project.ID)).
+			Select(queryAssignmentProjects.GradingJUnitTestResult).
+			Updates(project); err != nil {
+			return err
+		}
+
+		if err := webhook.NewOutbox(ctx, tx, classroomID).Emit(database.GradesUpdatedEvent, webhook.NewProjectData(project)); err != nil {
+			return err
+		}
 
-	return c.SendStatus(fiber.StatusAccepted)
+		return notifyGradesReleased(ctx, tx, project)
+	})
 }
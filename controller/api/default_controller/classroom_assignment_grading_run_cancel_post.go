@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+)
+
+// @Summary		CancelAssignmentGradingRun
+// @Description	Cancel a running grading run of the assignment. The projects, which are already graded, keep their results and the others are marked as canceled.
+// @Id				CancelAssignmentGradingRun
+// @Tags			grading
+// @Param			classroomId		path	string	true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path	string	true	"Assignment ID"	Format(uuid)
+// @Param			runId			path	string	true	"Grading Run ID"	Format(uuid)
+// @Param			X-Csrf-Token	header	string	true	"Csrf-Token"
+// @Success		202
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		409	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/grading/runs/{runId}/cancel [post]
+func (ctrl *DefaultController) CancelAssignmentGradingRun(c *fiber.Ctx) (err error) {
+	run, err := assignmentGradingRun(c)
+	if err != nil {
+		return err
+	}
+
+	if run.Status != database.GradingRunRunning {
+		return fiber.NewError(fiber.StatusBadRequest, "The grading run is not running")
+	}
+
+	cancel, ok := ctrl.gradingRuns.Load(run.ID)
+	if !ok {
+		return fiber.NewError(fiber.StatusConflict, "The grading run is finishing")
+	}
+
+	cancel.(context.CancelFunc)()
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		GetAssignmentGradingRun
+// @Description	Get a grading run of the assignment with the outcome of every project. Poll it until the run is not running anymore.
+// @Id				GetAssignmentGradingRun
+// @Tags			grading
+// @Produce		json
+// @Param			classroomId		path		string	true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path		string	true	"Assignment ID"	Format(uuid)
+// @Param			runId			path		string	true	"Grading Run ID"	Format(uuid)
+// @Success		200				{object}	database.GradingRun
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/grading/runs/{runId} [get]
+func (ctrl *DefaultController) GetAssignmentGradingRun(c *fiber.Ctx) (err error) {
+	run, err := assignmentGradingRun(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(run)
+}
+
+// assignmentGradingRun returns the grading run of the route params with its results, if it belongs to the assignment.
+func assignmentGradingRun(c *fiber.Ctx) (*database.GradingRun, error) {
+	var params Params
+	if err := c.ParamsParser(&params); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if params.RunID == nil {
+		return nil, fiber.ErrBadRequest
+	}
+
+	queryRun := query.GradingRun
+	run, err := queryRun.
+		WithContext(c.Context()).
+		Preload(queryRun.Results).
+		Where(queryRun.AssignmentID.Eq(context.Get(c).GetAssignment().ID)).
+		Where(queryRun.ID.Eq(*params.RunID)).
+		First()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+
+	return run, nil
+}
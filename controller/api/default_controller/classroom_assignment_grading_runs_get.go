@@ -0,0 +1,50 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		GetAssignmentGradingRuns
+// @Description	Get the grading runs of the assignment without their results, newest first.
+// @Id				GetAssignmentGradingRuns
+// @Tags			grading
+// @Produce		json
+// @Param			classroomId		path		string	true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path		string	true	"Assignment ID"	Format(uuid)
+// @Param			page			query		int		false	"Page, all runs are returned if neither page nor perPage is set"
+// @Param			perPage			query		int		false	"Page size (default: 20, max: 100)"
+// @Success		200				{array}		database.GradingRun
+// @Header			200				{string}	Link	"Links to the first, previous, next and last page"
+// @Header			200				{int}		X-Total	"Total number of runs"
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/grading/runs [get]
+func (ctrl *DefaultController) GetAssignmentGradingRuns(c *fiber.Ctx) (err error) {
+	assignment := context.Get(c).GetAssignment()
+
+	var pagination paginationQuery
+	if err = c.QueryParser(&pagination); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var runs []*database.GradingRun
+
+	queryRun := query.GradingRun
+	dbQuery := queryRun.
+		WithContext(c.Context()).
+		Where(queryRun.AssignmentID.Eq(assignment.ID)).
+		Order(queryRun.CreatedAt.Desc())
+
+	runs, err = findPage(c, dbQuery, pagination)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(runs)
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestAssignmentGradingRuns(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, true)
+
+	newProject := func(projectID int) *database.AssignmentProjects {
+		student := factory.User()
+		team := factory.Team(classroom.ID, []*database.UserClassrooms{
+			factory.UserClassroom(student.ID, classroom.ID, database.Student),
+		})
+		project := factory.AssignmentProject(assignment.ID, team.ID)
+		project.ProjectID = projectID
+		if err := query.AssignmentProjects.WithContext(context.Background()).Save(project); err != nil {
+			t.Fatal(err)
+		}
+		return project
+	}
+	pushed := newProject(10)
+	neverPushed := newProject(20)
+	broken := newProject(30)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	gradingRoute := fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/grading", classroom.ID.String(), assignment.ID.String())
+
+	gitlabRepo.EXPECT().
+		GroupAccessLogin(classroom.GroupAccessToken).
+		Return(nil)
+
+	notFound := &model.GitLabError{
+		Response: &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{Method: "GET", URL: &url.URL{}}},
+		Message:  "404 Not found",
+	}
+
+	var run database.GradingRun
+
+	t.Run("grades the projects in the background", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetProjectLatestPipelineTestReportSummary(10, (*string)(nil)).
+			Return(&model.TestReport{TotalCount: 3, SuccessCount: 2, FailedCount: 1}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectLatestPipelineTestReportSummary(20, (*string)(nil)).
+			Return(nil, notFound).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectLatestPipelineTestReportSummary(30, (*string)(nil)).
+			Return(nil, fmt.Errorf("connection reset")).
+			Once()
+
+		req := newPostJsonRequest(gradingRoute+"/auto", startAutoGradingRequest{JUnitAutoGrading: utils.Ptr(true)})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&run))
+		assert.Equal(t, database.GradingRunRunning, run.Status)
+		assert.Len(t, run.Results, 3)
+		location := resp.Header.Get("Location")
+		assert.Equal(t, fmt.Sprintf("%s/runs/%s", gradingRoute, run.ID.String()), location)
+
+		assert.Eventually(t, func() bool {
+			resp, err := app.Test(httptest.NewRequest("GET", location, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&run))
+			return run.Status != database.GradingRunRunning
+		}, 5*time.Second, 50*time.Millisecond)
+
+		assert.Equal(t, database.GradingRunCompleted, run.Status)
+		assert.NotNil(t, run.FinishedAt)
+
+		byProject := map[uuid.UUID]*database.GradingRunResult{}
+		for _, result := range run.Results {
+			byProject[result.ProjectID] = result
+		}
+		assert.Equal(t, database.GradingResultGraded, byProject[pushed.ID].Status)
+		assert.Equal(t, database.GradingResultSkipped, byProject[neverPushed.ID].Status)
+		assert.Equal(t, database.GradingResultFailed, byProject[broken.ID].Status)
+		assert.NotNil(t, byProject[broken.ID].Error)
+
+		graded, err := query.AssignmentProjects.WithContext(context.Background()).Where(query.AssignmentProjects.ID.Eq(pushed.ID)).First()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, graded.GradingJUnitTestResult.SuccessCount)
+	})
+
+	t.Run("lists the runs", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", gradingRoute+"/runs", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var runs []*database.GradingRun
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&runs))
+		assert.Len(t, runs, 1)
+		assert.Equal(t, run.ID, runs[0].ID)
+	})
+
+	t.Run("can not cancel a finished run", func(t *testing.T) {
+		req := newPostJsonRequest(fmt.Sprintf("%s/runs/%s/cancel", gradingRoute, run.ID.String()), nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects a second run and cancels the running run", func(t *testing.T) {
+		// More projects than graded concurrently, so some projects are still pending when the run is canceled
+		for i := range pipelineConcurrency {
+			newProject(100 + i)
+		}
+
+		started := make(chan struct{}, pipelineConcurrency)
+		release := make(chan struct{})
+		gitlabRepo.EXPECT().
+			GetProjectLatestPipelineTestReportSummary(mock.Anything, (*string)(nil)).
+			RunAndReturn(func(int, *string) (*model.TestReport, error) {
+				started <- struct{}{}
+				<-release
+				return &model.TestReport{TotalCount: 1, SuccessCount: 1}, nil
+			}).
+			Times(pipelineConcurrency)
+
+		req := newPostJsonRequest(gradingRoute+"/auto", startAutoGradingRequest{JUnitAutoGrading: utils.Ptr(true)})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		var running database.GradingRun
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&running))
+		for range pipelineConcurrency {
+			<-started
+		}
+
+		req = newPostJsonRequest(gradingRoute+"/auto", startAutoGradingRequest{JUnitAutoGrading: utils.Ptr(true)})
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+		location := fmt.Sprintf("%s/runs/%s", gradingRoute, running.ID.String())
+		resp, err = app.Test(newPostJsonRequest(location+"/cancel", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+		close(release)
+
+		assert.Eventually(t, func() bool {
+			resp, err := app.Test(httptest.NewRequest("GET", location, nil))
+			assert.NoError(t, err)
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&running))
+			return running.Status != database.GradingRunRunning
+		}, 5*time.Second, 50*time.Millisecond)
+
+		assert.Equal(t, database.GradingRunCanceled, running.Status)
+		statuses := map[database.GradingRunResultStatus]int{}
+		for _, result := range running.Results {
+			statuses[result.Status]++
+		}
+		assert.Equal(t, map[database.GradingRunResultStatus]int{
+			database.GradingResultGraded:   pipelineConcurrency,
+			database.GradingResultCanceled: len(running.Results) - pipelineConcurrency,
+		}, statuses)
+	})
+
+	t.Run("returns not found for unknown runs", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", fmt.Sprintf("%s/runs/%s", gradingRoute, uuid.NewString()), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -14,6 +14,7 @@ func classroomAssignmentQuery(c *fiber.Ctx, classroomID uuid.UUID) query.IAssign
 		WithContext(c.Context()).
 		Preload(queryAssignment.GradingManualRubrics).
 		Preload(queryAssignment.JUnitTests).
+		Preload(queryAssignment.TemplateVariants.Order(query.AssignmentTemplateVariant.Position)).
 		Where(queryAssignment.ClassroomID.Eq(classroomID))
 }
 
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// pipelineConcurrency limits the parallel requests to GitLab for the projects of an assignment
+	pipelineConcurrency = 8
+	pipelineCacheTTL    = time.Minute
+)
+
+type PipelineTestCounts struct {
+	Total   int `json:"total"`
+	Success int `json:"success"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Error   int `json:"error"`
+} //@Name PipelineTestCounts
+
+// projectPipeline is the latest pipeline of a GitLab project, Pipeline is nil if the project has none
+type projectPipeline struct {
+	Pipeline *model.Pipeline
+	Tests    *PipelineTestCounts
+}
+
+type ProjectPipelineResponse struct {
+	AssignmentProjectID uuid.UUID           `json:"assignmentProjectId"`
+	Team                database.Team       `json:"team"`
+	Pipeline            *model.Pipeline     `json:"pipeline" validate:"optional"`
+	Tests               *PipelineTestCounts `json:"tests" validate:"optional"`
+	// Error is set if the pipeline could not be fetched from GitLab
+	Error *string `json:"error" validate:"optional"`
+} //@Name ProjectPipelineResponse
+
+type getAssignmentPipelinesQuery struct {
+	Refresh bool `query:"refresh"`
+}
+
+// @Summary		GetAssignmentPipelines
+// @Description	Get the latest pipeline of every accepted project of the assignment. Missing pipelines are returned without pipeline.
+// @Id				GetAssignmentPipelines
+// @Tags			assignment
+// @Produce		json
+// @Param			classroomId		path		string	true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path		string	true	"Assignment ID"	Format(uuid)
+// @Param			refresh			query		bool	false	"Bypass the cache of the pipelines"
+// @Success		200				{array}		api.ProjectPipelineResponse
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/pipelines [get]
+func (ctrl *DefaultController) GetAssignmentPipelines(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	assignment := ctx.GetAssignment()
+	repo := ctx.GetGitlabRepository()
+
+	var urlQuery getAssignmentPipelinesQuery
+	if err = c.QueryParser(&urlQuery); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	queryAssignmentProjects := query.AssignmentProjects
+	projects, err := queryAssignmentProjects.
+		WithContext(c.Context()).
+		Preload(queryAssignmentProjects.Team).
+		Where(queryAssignmentProjects.AssignmentID.Eq(assignment.ID)).
+		Where(queryAssignmentProjects.ProjectStatus.Eq(string(database.Accepted))).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	response := make([]*ProjectPipelineResponse, len(projects))
+
+	var eg errgroup.Group
+	eg.SetLimit(pipelineConcurrency)
+	for i, project := range projects {
+		eg.Go(func() error {
+			response[i] = &ProjectPipelineResponse{AssignmentProjectID: project.ID, Team: project.Team}
+
+			pipeline, ok := ctrl.pipelines.Get(project.ProjectID)
+			if !ok || urlQuery.Refresh {
+				var err error
+				pipeline, err = fetchProjectPipeline(repo, project.ProjectID)
+				if err != nil {
+					response[i].Error = utils.Ptr(err.Error())
+					return nil
+				}
+				ctrl.pipelines.Set(project.ProjectID, pipeline)
+			}
+
+			response[i].Pipeline = pipeline.Pipeline
+			response[i].Tests = pipeline.Tests
+			return nil
+		})
+	}
+	eg.Wait()
+
+	return c.JSON(response)
+}
+
+// latestPipeline fetches the latest pipeline of the default branch, it is nil if the project has none.
+func latestPipeline(repo gitlab.Repository, projectID int) (*model.Pipeline, error) {
+	pipeline, err := repo.GetProjectLatestPipeline(projectID, nil)
+	if err != nil {
+		// GitLab answers with 403 or 404 if the project has no pipeline yet
+		var gitlabError *model.GitLabError
+		if errors.As(err, &gitlabError) &&
+			(gitlabError.Response.StatusCode == http.StatusForbidden || gitlabError.Response.StatusCode == http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// fetchProjectPipeline fetches the latest pipeline of the default branch with the counts of its test report.
+func fetchProjectPipeline(repo gitlab.Repository, projectID int) (*projectPipeline, error) {
+	pipeline, err := latestPipeline(repo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if pipeline == nil {
+		return &projectPipeline{}, nil
+	}
+
+	// Not every pipeline produces a test report
+	var tests *PipelineTestCounts
+	if report, err := repo.GetProjectPipelineTestReportSummary(projectID, pipeline.ID); err == nil {
+		tests = &PipelineTestCounts{
+			Total:   report.TotalCount,
+			Success: report.SuccessCount,
+			Failed:  report.FailedCount,
+			Skipped: report.SkippedCount,
+			Error:   report.ErrorCount,
+		}
+	}
+
+	return &projectPipeline{Pipeline: pipeline, Tests: tests}, nil
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"database/sql/driver"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"golang.org/x/sync/errgroup"
+)
+
+type pipelineRunMode string //@Name PipelineRunMode
+
+const (
+	// retryPipeline retries the failed jobs of the latest pipeline
+	retryPipeline pipelineRunMode = "retry"
+	// runPipeline runs a new pipeline on the default branch
+	runPipeline pipelineRunMode = "run"
+)
+
+type runPipelinesRequest struct {
+	AssignmentProjectIDs []uuid.UUID     `json:"assignmentProjectIds"`
+	Mode                 pipelineRunMode `json:"mode"`
+} //@Name RunPipelinesRequest
+
+func (r runPipelinesRequest) isValid() bool {
+	return len(r.AssignmentProjectIDs) > 0 && (r.Mode == retryPipeline || r.Mode == runPipeline)
+}
+
+// @Summary		RunAssignmentPipelines
+// @Description	Retry the latest pipeline or run a new pipeline for the selected projects of the assignment. Projects without pipeline get a new one when retrying.
+// @Id				RunAssignmentPipelines
+// @Tags			assignment
+// @Accept			json
+// @Param			classroomId		path	string					true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path	string					true	"Assignment ID"	Format(uuid)
+// @Param			pipelines		body	api.runPipelinesRequest	true	"Projects to run the pipelines for"
+// @Param			X-Csrf-Token	header	string					true	"Csrf-Token"
+// @Success		202
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/pipelines [post]
+func (ctrl *DefaultController) RunAssignmentPipelines(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	assignment := ctx.GetAssignment()
+	repo := ctx.GetGitlabRepository()
+
+	var requestBody runPipelinesRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Request Body is not valid")
+	}
+
+	queryAssignmentProjects := query.AssignmentProjects
+	projects, err := queryAssignmentProjects.
+		WithContext(c.Context()).
+		Where(queryAssignmentProjects.AssignmentID.Eq(assignment.ID)).
+		Where(queryAssignmentProjects.ProjectStatus.Eq(string(database.Accepted))).
+		Where(queryAssignmentProjects.ID.In(utils.Map(requestBody.AssignmentProjectIDs, func(id uuid.UUID) driver.Valuer { return id })...)).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if len(projects) != len(requestBody.AssignmentProjectIDs) {
+		return fiber.NewError(fiber.StatusBadRequest, "Body includes invalid or not accepted projects")
+	}
+
+	var eg errgroup.Group
+	eg.SetLimit(pipelineConcurrency)
+	for _, project := range projects {
+		eg.Go(func() error {
+			// The cached pipeline is outdated in any case
+			defer ctrl.pipelines.Delete(project.ProjectID)
+
+			if requestBody.Mode == retryPipeline {
+				pipeline, err := latestPipeline(repo, project.ProjectID)
+				if err != nil {
+					return err
+				}
+				if pipeline != nil {
+					_, err = repo.RetryPipeline(project.ProjectID, pipeline.ID)
+					return err
+				}
+			}
+
+			_, err := repo.CreatePipeline(project.ProjectID, nil)
+			return err
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestAssignmentPipelines(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+
+	newProject := func(projectID int) *database.AssignmentProjects {
+		student := factory.User()
+		team := factory.Team(classroom.ID, []*database.UserClassrooms{
+			factory.UserClassroom(student.ID, classroom.ID, database.Student),
+		})
+		project := factory.AssignmentProject(assignment.ID, team.ID)
+		project.ProjectID = projectID
+		if err := query.AssignmentProjects.WithContext(context.Background()).Save(project); err != nil {
+			t.Fatal(err)
+		}
+		return project
+	}
+	withPipeline := newProject(10)
+	withoutPipeline := newProject(20)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	targetRoute := fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/pipelines", classroom.ID.String(), assignment.ID.String())
+
+	notFound := &model.GitLabError{
+		Response: &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{Method: "GET", URL: &url.URL{}}},
+		Message:  "404 Not found",
+	}
+
+	t.Run("lists latest pipelines and caches them", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetProjectLatestPipeline(10, (*string)(nil)).
+			Return(&model.Pipeline{ID: 1, Status: "failed", WebURL: "https://gitlab.example.com/pipelines/1"}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectPipelineTestReportSummary(10, 1).
+			Return(&model.TestReport{TotalCount: 3, SuccessCount: 2, FailedCount: 1}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectLatestPipeline(20, (*string)(nil)).
+			Return(nil, notFound).
+			Once()
+
+		for range 2 {
+			resp, err := app.Test(httptest.NewRequest("GET", targetRoute, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+			var pipelines []*ProjectPipelineResponse
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&pipelines))
+			assert.Len(t, pipelines, 2)
+
+			byProject := map[uuid.UUID]*ProjectPipelineResponse{}
+			for _, pipeline := range pipelines {
+				byProject[pipeline.AssignmentProjectID] = pipeline
+			}
+
+			assert.Equal(t, "failed", byProject[withPipeline.ID].Pipeline.Status)
+			assert.Equal(t, 1, byProject[withPipeline.ID].Tests.Failed)
+			assert.Nil(t, byProject[withoutPipeline.ID].Pipeline)
+			assert.Nil(t, byProject[withoutPipeline.ID].Error)
+		}
+	})
+
+	t.Run("retries pipelines and runs missing ones", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetProjectLatestPipeline(10, (*string)(nil)).
+			Return(&model.Pipeline{ID: 1}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			RetryPipeline(10, 1).
+			Return(&model.Pipeline{ID: 1}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectLatestPipeline(20, (*string)(nil)).
+			Return(nil, notFound).
+			Once()
+		gitlabRepo.EXPECT().
+			CreatePipeline(20, (*string)(nil)).
+			Return(&model.Pipeline{ID: 2}, nil).
+			Once()
+
+		req := newPostJsonRequest(targetRoute, runPipelinesRequest{
+			AssignmentProjectIDs: []uuid.UUID{withPipeline.ID, withoutPipeline.ID},
+			Mode:                 retryPipeline,
+		})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	})
+
+	t.Run("rejects unknown projects", func(t *testing.T) {
+		req := newPostJsonRequest(targetRoute, runPipelinesRequest{
+			AssignmentProjectIDs: []uuid.UUID{uuid.New()},
+			Mode:                 runPipeline,
+		})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
-	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	fiberContext "gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
@@ -66,7 +65,7 @@ func (ctrl *DefaultController) StartAutoGradingForProject(c *fiber.Ctx) (err err
 
 		project.GradingJUnitTestResult = &database.JUnitTestResult{TestReport: *report}
 
-		if err := query.AssignmentProjects.WithContext(c.Context()).Save(project); err != nil {
+		if err := saveAutoGradingResult(c.Context(), project.Assignment.ClassroomID, project); err != nil {
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 	}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type GradingCommentResponse struct {
+	*database.GradingComment
+	Resolved bool `json:"resolved"`
+	// Deleted is true if the discussion no longer exists on the feedback merge request
+	Deleted bool `json:"deleted"`
+	// Replies is the number of notes in the discussion besides the comment itself
+	Replies int    `json:"replies"`
+	WebURL  string `json:"webUrl"`
+} //@Name GradingCommentResponse
+
+// @Summary		GetGradingComments
+// @Description	Get the comments on the feedback merge request of the project, with the state of their discussions in GitLab.
+// @Id				GetGradingComments
+// @Tags			grading
+// @Produce		json
+// @Param			classroomId		path		string	true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path		string	true	"Assignment ID"	Format(uuid)
+// @Param			projectId		path		string	true	"Project ID"	Format(uuid)
+// @Success		200				{array}		api.GradingCommentResponse
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/projects/{projectId}/grading/comments [get]
+func (ctrl *DefaultController) GetGradingComments(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	project := ctx.GetAssignmentProject()
+	repo := ctx.GetGitlabRepository()
+
+	queryGradingComment := query.GradingComment
+	comments, err := queryGradingComment.
+		WithContext(c.Context()).
+		Preload(queryGradingComment.Rubric).
+		Preload(queryGradingComment.Author).
+		Where(queryGradingComment.AssignmentProjectID.Eq(project.ID)).
+		Order(queryGradingComment.CreatedAt).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	response := make([]*GradingCommentResponse, len(comments))
+	if len(comments) == 0 {
+		return c.JSON(response)
+	}
+
+	mergeRequest, err := repo.GetMergeRequestByTargetBranch(project.ProjectID, feedbackBranch)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	discussions, err := repo.GetMergeRequestDiscussions(project.ProjectID, mergeRequest.IID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	discussionsByID := make(map[string]*model.Discussion, len(discussions))
+	for _, discussion := range discussions {
+		discussionsByID[discussion.ID] = discussion
+	}
+
+	for i, comment := range comments {
+		response[i] = &GradingCommentResponse{GradingComment: comment, WebURL: mergeRequest.WebURL}
+
+		discussion, ok := discussionsByID[comment.DiscussionID]
+		if !ok || len(discussion.Notes) == 0 {
+			response[i].Deleted = true
+			continue
+		}
+
+		response[i].Resolved = discussion.Resolved
+		response[i].Replies = len(discussion.Notes) - 1
+		response[i].WebURL = fmt.Sprintf("%s#note_%d", mergeRequest.WebURL, discussion.Notes[0].ID)
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type createGradingCommentRequest struct {
+	FilePath string     `json:"filePath"`
+	Line     int        `json:"line"`
+	Body     string     `json:"body"`
+	RubricID *uuid.UUID `json:"rubricId" validate:"optional"`
+} //@Name CreateGradingCommentRequest
+
+func (r createGradingCommentRequest) isValid() bool {
+	return r.FilePath != "" && r.Line > 0 && strings.TrimSpace(r.Body) != ""
+}
+
+// @Summary		CreateGradingComment
+// @Description	Comment a line of a file on the feedback merge request of the project, optionally linked to a rubric of the assignment.
+// @Id				CreateGradingComment
+// @Tags			grading
+// @Accept			json
+// @Param			classroomId		path	string							true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path	string							true	"Assignment ID"	Format(uuid)
+// @Param			projectId		path	string							true	"Project ID"	Format(uuid)
+// @Param			comment			body	api.createGradingCommentRequest	true	"Comment"
+// @Param			X-Csrf-Token	header	string							true	"Csrf-Token"
+// @Success		201
+// @Header			201	{string}	Location	"/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/projects/{projectId}/grading/comments"
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/projects/{projectId}/grading/comments [post]
+func (ctrl *DefaultController) CreateGradingComment(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	assignment := ctx.GetAssignment()
+	project := ctx.GetAssignmentProject()
+	repo := ctx.GetGitlabRepository()
+
+	var requestBody createGradingCommentRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Request Body is not valid")
+	}
+
+	if project.ProjectStatus != database.Accepted {
+		return fiber.NewError(fiber.StatusBadRequest, "Comments can only be created for accepted projects")
+	}
+
+	body := requestBody.Body
+	if requestBody.RubricID != nil {
+		index := slices.IndexFunc(assignment.GradingManualRubrics, func(rubric *database.ManualGradingRubric) bool { return rubric.ID == *requestBody.RubricID })
+		if index == -1 {
+			return fiber.NewError(fiber.StatusBadRequest, "Rubric is not part of the assignment")
+		}
+		body = fmt.Sprintf("**%s:** %s", assignment.GradingManualRubrics[index].Name, body)
+	}
+
+	mergeRequest, err := repo.GetMergeRequestByTargetBranch(project.ProjectID, feedbackBranch)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	discussion, err := repo.CreateMergeRequestDiffDiscussion(project.ProjectID, mergeRequest.IID, requestBody.FilePath, requestBody.Line, body)
+	if err != nil {
+		// GitLab rejects positions outside of the diff, e.g. a line the file does not have
+		var gitlabError *model.GitLabError
+		if errors.As(err, &gitlabError) && gitlabError.Response.StatusCode == http.StatusBadRequest {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	comment := &database.GradingComment{
+		AssignmentProjectID: project.ID,
+		RubricID:            requestBody.RubricID,
+		AuthorID:            ctx.GetUserID(),
+		DiscussionID:        discussion.ID,
+		FilePath:            requestBody.FilePath,
+		Line:                requestBody.Line,
+		Body:                requestBody.Body,
+	}
+	if err = query.GradingComment.WithContext(c.Context()).Create(comment); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	c.Set("Location", fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/projects/%s/grading/comments", assignment.ClassroomID.String(), assignment.ID.String(), project.ID.String()))
+	return c.SendStatus(fiber.StatusCreated)
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestGradingComments(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	student := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	team := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(student.ID, classroom.ID, database.Student),
+	})
+	project := factory.AssignmentProject(assignment.ID, team.ID)
+
+	rubric := &database.ManualGradingRubric{Name: "Code Quality", Description: "Clean code", ClassroomID: classroom.ID, MaxScore: 10}
+	if err := query.ManualGradingRubric.WithContext(context.Background()).Create(rubric); err != nil {
+		t.Fatal(err)
+	}
+	assignment.GradingManualRubrics = []*database.ManualGradingRubric{rubric}
+	if err := query.Assignment.WithContext(context.Background()).Save(assignment); err != nil {
+		t.Fatal(err)
+	}
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	targetRoute := fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/projects/%s/grading/comments", classroom.ID.String(), assignment.ID.String(), project.ID.String())
+
+	mergeRequest := &model.MergeRequest{IID: 1, WebURL: "https://gitlab.example.com/project/-/merge_requests/1"}
+	gitlabRepo.EXPECT().
+		GetMergeRequestByTargetBranch(project.ProjectID, "feedback").
+		Return(mergeRequest, nil).
+		Maybe()
+
+	t.Run("rejects invalid comments", func(t *testing.T) {
+		resp, err := app.Test(newPostJsonRequest(targetRoute, createGradingCommentRequest{FilePath: "main.go", Body: "Missing line"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects rubrics of other assignments", func(t *testing.T) {
+		otherRubric := uuid.New()
+		resp, err := app.Test(newPostJsonRequest(targetRoute, createGradingCommentRequest{FilePath: "main.go", Line: 3, Body: "Unknown", RubricID: &otherRubric}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("creates comment linked to rubric", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			CreateMergeRequestDiffDiscussion(project.ProjectID, 1, "main.go", 3, "**Code Quality:** Use a constant").
+			Return(&model.Discussion{ID: "abc", Notes: []*model.Note{{ID: 7}}}, nil).
+			Once()
+
+		resp, err := app.Test(newPostJsonRequest(targetRoute, createGradingCommentRequest{FilePath: "main.go", Line: 3, Body: "Use a constant", RubricID: &rubric.ID}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		comment, err := query.GradingComment.
+			WithContext(context.Background()).
+			Where(query.GradingComment.AssignmentProjectID.Eq(project.ID)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", comment.DiscussionID)
+		assert.Equal(t, rubric.ID, *comment.RubricID)
+		assert.Equal(t, owner.ID, comment.AuthorID)
+		assert.Equal(t, "Use a constant", comment.Body)
+	})
+
+	t.Run("rejects lines GitLab can not comment", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			CreateMergeRequestDiffDiscussion(project.ProjectID, 1, "main.go", 999, "Out of range").
+			Return(nil, &model.GitLabError{
+				Response: &http.Response{StatusCode: http.StatusBadRequest, Request: &http.Request{Method: "POST", URL: &url.URL{}}},
+				Message:  "400 Bad request - Note {:line_code=>[\"can't be blank\", \"must be a valid line code\"]}",
+			}).
+			Once()
+
+		resp, err := app.Test(newPostJsonRequest(targetRoute, createGradingCommentRequest{FilePath: "main.go", Line: 999, Body: "Out of range"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		count, err := query.GradingComment.
+			WithContext(context.Background()).
+			Where(query.GradingComment.AssignmentProjectID.Eq(project.ID)).
+			Count()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("lists comments with discussion state", func(t *testing.T) {
+		comment := &database.GradingComment{AssignmentProjectID: project.ID, AuthorID: owner.ID, DiscussionID: "deleted", FilePath: "main.go", Line: 5, Body: "Gone"}
+		if err := query.GradingComment.WithContext(context.Background()).Create(comment); err != nil {
+			t.Fatal(err)
+		}
+
+		gitlabRepo.EXPECT().
+			GetMergeRequestDiscussions(project.ProjectID, 1).
+			Return([]*model.Discussion{{ID: "abc", Resolved: true, Notes: []*model.Note{{ID: 7}, {ID: 8}}}}, nil).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", targetRoute, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var comments []*GradingCommentResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&comments))
+		assert.Len(t, comments, 2)
+
+		assert.Equal(t, "abc", comments[0].DiscussionID)
+		assert.Equal(t, rubric.Name, comments[0].Rubric.Name)
+		assert.True(t, comments[0].Resolved)
+		assert.False(t, comments[0].Deleted)
+		assert.Equal(t, 1, comments[0].Replies)
+		assert.Equal(t, mergeRequest.WebURL+"#note_7", comments[0].WebURL)
+
+		assert.True(t, comments[1].Deleted)
+	})
+}
@@ -1,15 +1,24 @@
 package api
 
 import (
+	"context"
 	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	mailRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
-	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	fiberContext "gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
 
 type gradingManualResultRequest struct {
@@ -28,31 +37,39 @@ func resultRequestIsValid(r gradingManualResultRequest) bool {
 
 type updateProjectGradingRequest struct {
 	GradingManualResults []gradingManualResultRequest `json:"gradingManualRubrics"`
+	// PublishFeedback posts the grading summary as comment on the feedback merge request of the project
+	PublishFeedback bool `json:"publishFeedback" validate:"optional"`
 } //@Name UpdateAssignmentGradingRequest
 
 func (r updateProjectGradingRequest) isValid() bool {
 	return utils.All(r.GradingManualResults, resultRequestIsValid)
 }
 
+type UpdateGradingResultsResponse struct {
+	// FeedbackError is set if the results are saved, but the feedback could not be published on GitLab
+	FeedbackError *string `json:"feedbackError" validate:"optional"`
+} //@Name UpdateGradingResultsResponse
+
 // @Summary		UpdateGradingResults
-// @Description	UpdateGradingResults
+// @Description	Save the manual grading results of the project. With publishFeedback the summary is posted on the feedback merge request after the results are saved, a failure of the publishing is reported in the response.
 // @Id				UpdateGradingResults
 // @Tags			grading
 // @Accept			json
+// @Produce		json
 // @Param			classroomId		path	string							true	"Classroom ID"	Format(uuid)
 // @Param			assignmentId	path	string							true	"Assignment ID"	Format(uuid)
 // @Param			projectId		path	string							true	"Project ID"	Format(uuid)
 // @Param			assignmentInfo	body	api.updateProjectGradingRequest	true	"Grading Update Info"
 // @Param			X-Csrf-Token	header	string							true	"Csrf-Token"
-// @Success		202
-// @Failure		400	{object}	HTTPError
-// @Failure		401	{object}	HTTPError
-// @Failure		403	{object}	HTTPError
-// @Failure		404	{object}	HTTPError
-// @Failure		500	{object}	HTTPError
+// @Success		202				{object}	api.UpdateGradingResultsResponse
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
 // @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/projects/{projectId}/grading [put]
 func (ctrl *DefaultController) UpdateGradingResults(c *fiber.Ctx) (err error) {
-	ctx := context.Get(c)
+	ctx := fiberContext.Get(c)
 	assignment := ctx.GetAssignment()
 	project := ctx.GetAssignmentProject()
 
@@ -77,6 +94,11 @@ func (ctrl *DefaultController) UpdateGradingResults(c *fiber.Ctx) (err error) {
 		return fiber.NewError(fiber.StatusBadRequest, "Body includes invalid IDs")
 	}
 
+	if requestBody.PublishFeedback && project.ProjectStatus != database.Accepted {
+		return fiber.NewError(fiber.StatusBadRequest, "Feedback can only be published for accepted projects")
+	}
+
+	var results []*database.ManualGradingResult
 	err = query.Q.Transaction(func(tx *query.Query) error {
 		queryManualGradingResult := tx.ManualGradingResult
 		if _, err := queryManualGradingResult.
@@ -86,7 +108,7 @@ func (ctrl *DefaultController) UpdateGradingResults(c *fiber.Ctx) (err error) {
 			return err
 		}
 
-		results := utils.Map(requestBody.GradingManualResults, func(e gradingManualResultRequest) *database.ManualGradingResult {
+		results = utils.Map(requestBody.GradingManualResults, func(e gradingManualResultRequest) *database.ManualGradingResult {
 			return &database.ManualGradingResult{
 				AssignmentProjectID: project.ID,
 				RubricID:            *e.RubricID,
@@ -101,11 +123,131 @@ func (ctrl *DefaultController) UpdateGradingResults(c *fiber.Ctx) (err error) {
 			return err
 		}
 
-		return nil
+		if err = webhook.NewOutbox(c.Context(), tx, assignment.ClassroomID).
+			Emit(database.GradesUpdatedEvent, webhook.NewProjectData(project)); err != nil {
+			return err
+		}
+
+		return notifyGradesReleased(c.Context(), tx, project)
 	})
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return c.SendStatus(fiber.StatusAccepted)
+	// The results are saved independently of GitLab, so a failed publishing can be retried without losing them
+	var response UpdateGradingResultsResponse
+	if requestBody.PublishFeedback {
+		if err = publishFeedbackNote(c, ctx.GetGitlabRepository(), assignment, project, results); err != nil {
+			response.FeedbackError = utils.Ptr(err.Error())
+		}
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
+
+// notifyGradesReleased writes a grades released notification for every member of the team of the project to the outbox.
+// The notifications are sent according to the grades released preference of the members.
+func notifyGradesReleased(ctx context.Context, tx *query.Query, project *database.AssignmentProjects) error {
+	queryAssignment := tx.Assignment
+	assignment, err := queryAssignment.
+		WithContext(ctx).
+		Preload(queryAssignment.Classroom).
+		Where(queryAssignment.ID.Eq(project.AssignmentID)).
+		First()
+	if err != nil {
+		return err
+	}
+
+	queryUserClassrooms := tx.UserClassrooms
+	members, err := queryUserClassrooms.
+		WithContext(ctx).
+		Preload(queryUserClassrooms.User).
+		Where(queryUserClassrooms.TeamID.Eq(project.TeamID)).
+		Find()
+	if err != nil {
+		return err
+	}
+
+	outbox := mailRepo.NewOutboxRepository(ctx, tx, &assignment.ClassroomID)
+	for _, member := range members {
+		err = outbox.SendGradesReleasedNotification(member.User.GitlabEmail,
+			fmt.Sprintf(`Your project of the Assignment "%s" has been graded`, assignment.Name),
+			mailRepo.GradesReleasedData{
+				ClassroomName:  assignment.Classroom.Name,
+				RecipientName:  member.User.Name,
+				AssignmentName: assignment.Name,
+				Path:           fmt.Sprintf("/classrooms/%s", assignment.ClassroomID.String()),
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishFeedbackNote posts the grading summary on the feedback merge request.
+// An already published summary is updated, unless it has been deleted in GitLab.
+func publishFeedbackNote(c *fiber.Ctx, repo gitlab.Repository, assignment *database.Assignment, project *database.AssignmentProjects, results []*database.ManualGradingResult) error {
+	mergeRequest, err := repo.GetMergeRequestByTargetBranch(project.ProjectID, feedbackBranch)
+	if err != nil {
+		return err
+	}
+
+	body := feedbackNoteBody(assignment, results)
+
+	var note *model.Note
+	if project.FeedbackNoteID != nil {
+		note, err = repo.UpdateMergeRequestNote(project.ProjectID, mergeRequest.IID, *project.FeedbackNoteID, body)
+		var gitlabError *model.GitLabError
+		if errors.As(err, &gitlabError) && gitlabError.Response.StatusCode == http.StatusNotFound {
+			note, err = nil, nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if note == nil {
+		note, err = repo.CreateMergeRequestNote(project.ProjectID, mergeRequest.IID, body)
+		if err != nil {
+			return err
+		}
+	}
+
+	queryAssignmentProjects := query.AssignmentProjects
+	_, err = queryAssignmentProjects.
+		WithContext(c.Context()).
+		Where(queryAssignmentProjects.ID.Eq(project.ID)).
+		Update(queryAssignmentProjects.FeedbackNoteID, note.ID)
+	return err
+}
+
+// feedbackNoteBody formats the results as markdown table in the order of the rubrics of the assignment.
+func feedbackNoteBody(assignment *database.Assignment, results []*database.ManualGradingResult) string {
+	escape := strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Grading: %s\n\n", assignment.Name)
+	b.WriteString("| Rubric | Score | Feedback |\n| --- | --- | --- |\n")
+
+	score, maxScore := 0, 0
+	for _, rubric := range assignment.GradingManualRubrics {
+		index := slices.IndexFunc(results, func(result *database.ManualGradingResult) bool { return result.RubricID == rubric.ID })
+		if index == -1 {
+			continue
+		}
+		result := results[index]
+
+		feedback := ""
+		if result.Feedback != nil {
+			feedback = *result.Feedback
+		}
+
+		fmt.Fprintf(&b, "| %s | %d / %d | %s |\n", escape.Replace(rubric.Name), result.Score, rubric.MaxScore, escape.Replace(feedback))
+		score += result.Score
+		maxScore += rubric.MaxScore
+	}
+
+	fmt.Fprintf(&b, "\n**Total: %d / %d**\n", score, maxScore)
+	return b.String()
 }
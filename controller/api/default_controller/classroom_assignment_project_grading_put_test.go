@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+	db_tests "gitlab.hs-flensburg.de/gitlab-classroom/utils/tests"
+)
+
+func TestUpdateGradingResults(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	student := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	team := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(student.ID, classroom.ID, database.Student),
+	})
+	project := factory.AssignmentProject(assignment.ID, team.ID)
+
+	rubric := &database.ManualGradingRubric{Name: "Code Quality", Description: "Clean code", ClassroomID: classroom.ID, MaxScore: 10}
+	if err := query.ManualGradingRubric.WithContext(context.Background()).Create(rubric); err != nil {
+		t.Fatal(err)
+	}
+	assignment.GradingManualRubrics = []*database.ManualGradingRubric{rubric}
+	if err := query.Assignment.WithContext(context.Background()).Save(assignment); err != nil {
+		t.Fatal(err)
+	}
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	targetRoute := fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/projects/%s/grading", classroom.ID.String(), assignment.ID.String(), project.ID.String())
+
+	newRequest := func(score int, feedback string, publish bool) updateProjectGradingRequest {
+		return updateProjectGradingRequest{
+			GradingManualResults: []gradingManualResultRequest{{RubricID: &rubric.ID, Score: &score, Feedback: &feedback}},
+			PublishFeedback:      publish,
+		}
+	}
+
+	reload := func(t *testing.T) *database.AssignmentProjects {
+		reloaded, err := query.AssignmentProjects.
+			WithContext(context.Background()).
+			Preload(query.AssignmentProjects.GradingManualResults).
+			Where(query.AssignmentProjects.ID.Eq(project.ID)).
+			First()
+		assert.NoError(t, err)
+		return reloaded
+	}
+
+	gitlabRepo.EXPECT().
+		GetMergeRequestByTargetBranch(project.ProjectID, "feedback").
+		Return(&model.MergeRequest{IID: 1}, nil).
+		Maybe()
+
+	t.Run("saves results without publishing", func(t *testing.T) {
+		resp, err := app.Test(db_tests.NewPutJsonRequest(targetRoute, newRequest(5, "Ok", false)))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		reloaded := reload(t)
+		assert.Len(t, reloaded.GradingManualResults, 1)
+		assert.Equal(t, 5, reloaded.GradingManualResults[0].Score)
+		assert.Nil(t, reloaded.FeedbackNoteID)
+
+		outgoingMail, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.Recipient.Eq(student.GitlabEmail)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.GradesReleasedMail, outgoingMail.Kind)
+		assert.Equal(t, fmt.Sprintf(`Your project of the Assignment "%s" has been graded`, assignment.Name), outgoingMail.Subject)
+	})
+
+	t.Run("creates feedback note", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			CreateMergeRequestNote(project.ProjectID, 1, mock.MatchedBy(func(body string) bool {
+				return assert.Contains(t, body, "| Code Quality | 7 / 10 | Good \\| readable<br>naming |") &&
+					assert.Contains(t, body, "**Total: 7 / 10**")
+			})).
+			Return(&model.Note{ID: 42}, nil).
+			Once()
+
+		resp, err := app.Test(db_tests.NewPutJsonRequest(targetRoute, newRequest(7, "Good | readable\nnaming", true)))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		reloaded := reload(t)
+		assert.Equal(t, 42, *reloaded.FeedbackNoteID)
+	})
+
+	t.Run("updates published feedback note", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			UpdateMergeRequestNote(project.ProjectID, 1, 42, mock.Anything).
+			Return(&model.Note{ID: 42}, nil).
+			Once()
+
+		resp, err := app.Test(db_tests.NewPutJsonRequest(targetRoute, newRequest(9, "Better", true)))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		reloaded := reload(t)
+		assert.Equal(t, 9, reloaded.GradingManualResults[0].Score)
+		assert.Equal(t, 42, *reloaded.FeedbackNoteID)
+	})
+
+	t.Run("recreates deleted feedback note", func(t *testing.T) {
+		notFound := &model.GitLabError{
+			Response: &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{Method: "PUT", URL: &url.URL{}}},
+			Message:  "404 Not found",
+		}
+		gitlabRepo.EXPECT().
+			UpdateMergeRequestNote(project.ProjectID, 1, 42, mock.Anything).
+			Return(nil, notFound).
+			Once()
+		gitlabRepo.EXPECT().
+			CreateMergeRequestNote(project.ProjectID, 1, mock.Anything).
+			Return(&model.Note{ID: 43}, nil).
+			Once()
+
+		resp, err := app.Test(db_tests.NewPutJsonRequest(targetRoute, newRequest(8, "Again", true)))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		var response UpdateGradingResultsResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Nil(t, response.FeedbackError)
+
+		reloaded := reload(t)
+		assert.Equal(t, 43, *reloaded.FeedbackNoteID)
+	})
+
+	t.Run("saves results if publishing fails", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			UpdateMergeRequestNote(project.ProjectID, 1, 43, mock.Anything).
+			Return(nil, errors.New("connection reset")).
+			Once()
+
+		resp, err := app.Test(db_tests.NewPutJsonRequest(targetRoute, newRequest(6, "Saved", true)))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		var response UpdateGradingResultsResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, "connection reset", *response.FeedbackError)
+
+		reloaded := reload(t)
+		assert.Equal(t, 6, reloaded.GradingManualResults[0].Score)
+		assert.Equal(t, 43, *reloaded.FeedbackNoteID)
+	})
+
+	t.Run("rejects publishing for projects which are not accepted", func(t *testing.T) {
+		project.ProjectStatus = database.Pending
+		query.AssignmentProjects.WithContext(context.Background()).Save(project)
+		t.Cleanup(func() {
+			project.ProjectStatus = database.Accepted
+			query.AssignmentProjects.WithContext(context.Background()).Save(project)
+		})
+
+		resp, err := app.Test(db_tests.NewPutJsonRequest(targetRoute, newRequest(1, "", true)))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
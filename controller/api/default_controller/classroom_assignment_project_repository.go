@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+)
+
+// submissionRef selects the last commit on the default branch before the due date of the assignment
+const submissionRef = "submission"
+
+// feedbackBranch is created from the template state when the assignment is accepted,
+// so diffs against it show the changes of the students.
+const feedbackBranch = "feedback"
+
+type projectRepositoryQuery struct {
+	Ref       string `query:"ref"`
+	Path      string `query:"path"`
+	Recursive bool   `query:"recursive"`
+}
+
+// resolveProjectRef resolves the requested ref in the repository of an accepted project.
+// Without a ref the default branch is used.
+func resolveProjectRef(repo gitlab.Repository, assignment *database.Assignment, project *database.AssignmentProjects, ref string) (string, error) {
+	if project.ProjectStatus != database.Accepted {
+		return "", fiber.NewError(fiber.StatusBadRequest, "Project has not been accepted yet")
+	}
+
+	switch ref {
+	case "":
+		return "HEAD", nil
+	case submissionRef:
+		commit, err := repo.GetLatestCommit(project.ProjectID, "", assignment.DueDate)
+		if err != nil {
+			return "", repositoryError(err)
+		}
+		return commit.ID, nil
+	default:
+		return ref, nil
+	}
+}
+
+// repositoryError maps missing refs, paths and files in GitLab to 404.
+func repositoryError(err error) error {
+	var gitlabError *model.GitLabError
+	if errors.As(err, &gitlabError) && gitlabError.Response.StatusCode == http.StatusNotFound {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		GetProjectRepositoryDiff
+// @Description	Get the changes of the project compared to the template it was created from.
+// @Id				GetProjectRepositoryDiff
+// @Tags			project
+// @Produce		json
+// @Param			classroomId		path		string	true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path		string	true	"Assignment ID"	Format(uuid)
+// @Param			projectId		path		string	true	"Project ID"	Format(uuid)
+// @Param			ref				query		string	false	"Branch, tag or commit, 'submission' for the last commit before the due date, defaults to the default branch"
+// @Success		200				{object}	Compare
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/projects/{projectId}/repository/diff [get]
+func (ctrl *DefaultController) GetProjectRepositoryDiff(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	assignment := ctx.GetAssignment()
+	project := ctx.GetAssignmentProject()
+	repo := ctx.GetGitlabRepository()
+
+	var urlQuery projectRepositoryQuery
+	if err = c.QueryParser(&urlQuery); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	ref, err := resolveProjectRef(repo, assignment, project, urlQuery.Ref)
+	if err != nil {
+		return err
+	}
+
+	compare, err := repo.CompareRefs(project.ProjectID, feedbackBranch, ref)
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	return c.JSON(compare)
+}
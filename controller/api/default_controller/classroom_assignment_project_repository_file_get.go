@@ -0,0 +1,51 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		GetProjectRepositoryFile
+// @Description	Get a file with its content from the repository of the project.
+// @Id				GetProjectRepositoryFile
+// @Tags			project
+// @Produce		json
+// @Param			classroomId		path		string	true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path		string	true	"Assignment ID"	Format(uuid)
+// @Param			projectId		path		string	true	"Project ID"	Format(uuid)
+// @Param			path			query		string	true	"Path of the file"
+// @Param			ref				query		string	false	"Branch, tag or commit, 'submission' for the last commit before the due date, defaults to the default branch"
+// @Success		200				{object}	RepositoryFile
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/projects/{projectId}/repository/file [get]
+func (ctrl *DefaultController) GetProjectRepositoryFile(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	assignment := ctx.GetAssignment()
+	project := ctx.GetAssignmentProject()
+	repo := ctx.GetGitlabRepository()
+
+	var urlQuery projectRepositoryQuery
+	if err = c.QueryParser(&urlQuery); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if urlQuery.Path == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Path is required")
+	}
+
+	ref, err := resolveProjectRef(repo, assignment, project, urlQuery.Ref)
+	if err != nil {
+		return err
+	}
+
+	file, err := repo.GetRepositoryFile(project.ProjectID, urlQuery.Path, ref)
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	return c.JSON(file)
+}
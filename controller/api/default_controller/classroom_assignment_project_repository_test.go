@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestProjectRepository(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	student := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	dueDate := time.Now().Add(-1 * time.Hour).Truncate(time.Second)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	team := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(student.ID, classroom.ID, database.Student),
+	})
+	project := factory.AssignmentProject(assignment.ID, team.ID)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	baseRoute := fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/projects/%s/repository", classroom.ID.String(), assignment.ID.String(), project.ID.String())
+
+	t.Run("lists tree of the default branch", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetRepositoryTree(project.ProjectID, "HEAD", "src", true).
+			Return([]*model.TreeNode{{Name: "main.go", Path: "src/main.go", Type: model.TreeNodeBlob}}, nil).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", baseRoute+"/tree?path=src&recursive=true", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var nodes []*model.TreeNode
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&nodes))
+		assert.Len(t, nodes, 1)
+		assert.Equal(t, "src/main.go", nodes[0].Path)
+	})
+
+	t.Run("gets file of the submission", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetLatestCommit(project.ProjectID, "", mock.MatchedBy(func(until *time.Time) bool { return until.Equal(dueDate) })).
+			Return(&model.Commit{ID: "abc123"}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetRepositoryFile(project.ProjectID, "src/main.go", "abc123").
+			Return(&model.File{Path: "src/main.go", Ref: "abc123", Content: "package main"}, nil).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", baseRoute+"/file?path=src%2Fmain.go&ref=submission", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var file model.File
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&file))
+		assert.Equal(t, "package main", file.Content)
+	})
+
+	t.Run("requires file path", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", baseRoute+"/file", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("returns 404 for missing files", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetRepositoryFile(project.ProjectID, "missing.go", "HEAD").
+			Return(nil, &model.GitLabError{
+				Response: &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{Method: "GET", URL: &url.URL{}}},
+				Message:  "404 File Not Found",
+			}).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", baseRoute+"/file?path=missing.go", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("compares with the template state", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			CompareRefs(project.ProjectID, "feedback", "HEAD").
+			Return(&model.Compare{Diffs: []*model.Diff{{NewPath: "src/main.go", Diff: "+package main"}}}, nil).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", baseRoute+"/diff", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var compare model.Compare
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&compare))
+		assert.Len(t, compare.Diffs, 1)
+		assert.Equal(t, "src/main.go", compare.Diffs[0].NewPath)
+	})
+
+	t.Run("rejects projects which are not accepted", func(t *testing.T) {
+		project.ProjectStatus = database.Pending
+		query.AssignmentProjects.WithContext(context.Background()).Save(project)
+
+		resp, err := app.Test(httptest.NewRequest("GET", baseRoute+"/tree", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
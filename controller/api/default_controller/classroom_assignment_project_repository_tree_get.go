@@ -0,0 +1,48 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		GetProjectRepositoryTree
+// @Description	List the files and directories in the repository of the project.
+// @Id				GetProjectRepositoryTree
+// @Tags			project
+// @Produce		json
+// @Param			classroomId		path		string	true	"Classroom ID"	Format(uuid)
+// @Param			assignmentId	path		string	true	"Assignment ID"	Format(uuid)
+// @Param			projectId		path		string	true	"Project ID"	Format(uuid)
+// @Param			ref				query		string	false	"Branch, tag or commit, 'submission' for the last commit before the due date, defaults to the default branch"
+// @Param			path			query		string	false	"Directory to list, defaults to the root"
+// @Param			recursive		query		bool	false	"List the subdirectories recursively"
+// @Success		200				{array}		TreeNode
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/assignments/{assignmentId}/projects/{projectId}/repository/tree [get]
+func (ctrl *DefaultController) GetProjectRepositoryTree(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	assignment := ctx.GetAssignment()
+	project := ctx.GetAssignmentProject()
+	repo := ctx.GetGitlabRepository()
+
+	var urlQuery projectRepositoryQuery
+	if err = c.QueryParser(&urlQuery); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	ref, err := resolveProjectRef(repo, assignment, project, urlQuery.Ref)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := repo.GetRepositoryTree(project.ProjectID, ref, urlQuery.Path, urlQuery.Recursive)
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	return c.JSON(nodes)
+}
@@ -2,13 +2,25 @@ package api
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/gofiber/fiber/v2"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gorm.io/gen/field"
 )
 
+type getAssignmentProjectsQuery struct {
+	listQuery
+	Status string `query:"status"`
+}
+
+func (q getAssignmentProjectsQuery) isValid() bool {
+	return q.Status == "" || slices.Contains([]string{string(database.Pending), string(database.Creating), string(database.Accepted), string(database.Failed)}, q.Status)
+}
+
 // @Summary		GetClassroomAssignmentProjects
 // @Description	GetClassroomAssignmentProjects
 // @Id				GetClassroomAssignmentProjects
@@ -16,7 +28,14 @@ import (
 // @Produce		json
 // @Param			classroomId		path		string	true	"Classroom ID"	Format(uuid)
 // @Param			assignmentId	path		string	true	"Assignment ID"	Format(uuid)
+// @Param			status			query		string	false	"Filter by project status"	Enums(pending, creating, accepted, failed)
+// @Param			search			query		string	false	"Search by team name"
+// @Param			sort			query		string	false	"Sort by team, status or createdAt, prefixed with - for descending order"	Enums(team, -team, status, -status, createdAt, -createdAt)
+// @Param			page			query		int		false	"Page, all projects are returned if neither page nor perPage is set"
+// @Param			perPage			query		int		false	"Page size (default: 20, max: 100)"
 // @Success		200				{array}		api.ProjectResponse
+// @Header			200				{string}	Link	"Links to the first, previous, next and last page"
+// @Header			200				{int}		X-Total	"Total number of projects"
 // @Failure		400				{object}	HTTPError
 // @Failure		401				{object}	HTTPError
 // @Failure		403				{object}	HTTPError
@@ -28,8 +47,38 @@ func (strl *DefaultController) GetClassroomAssignmentProjects(c *fiber.Ctx) (err
 	classroom := ctx.GetUserClassroom()
 	assignment := ctx.GetAssignment()
 
-	projects, err := assignmentProjectQuery(c, assignment.ID).
-		Find()
+	var params getAssignmentProjectsQuery
+	if err = c.QueryParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !params.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Query is not valid")
+	}
+
+	queryAssignmentProjects := query.AssignmentProjects
+	queryTeam := query.Team
+	dbQuery := assignmentProjectQuery(c, assignment.ID).
+		Join(queryTeam, queryAssignmentProjects.TeamID.EqCol(queryTeam.ID))
+
+	if params.Status != "" {
+		dbQuery = dbQuery.Where(queryAssignmentProjects.ProjectStatus.Eq(params.Status))
+	}
+
+	if params.Search != "" {
+		dbQuery = dbQuery.Where(containsIgnoreCase(params.Search, queryTeam.Name))
+	}
+
+	order, ok := params.orderBy(map[string]field.OrderExpr{
+		"team":      queryTeam.Name,
+		"status":    queryAssignmentProjects.ProjectStatus,
+		"createdAt": queryAssignmentProjects.CreatedAt,
+	}, queryTeam.Name)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Sort field is not valid")
+	}
+
+	projects, err := findPage(c, dbQuery.Order(order...), params.paginationQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -60,4 +61,25 @@ func TestGetClassroomAssignmentProjects(t *testing.T) {
 		assert.Equal(t, project.ID, projectResponse.ID)
 		assert.Equal(t, project.ProjectID, projectResponse.ProjectID)
 	})
+
+	t.Run("GetClassroomAssignmentProjects filtered by status and team", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/projects?status=accepted&search=%s", classroom.ID.String(), assignment.ID.String(), url.QueryEscape(team.Name))
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var projectsResponse []*ProjectResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&projectsResponse))
+		assert.Len(t, projectsResponse, 1)
+		assert.Equal(t, project.ID, projectsResponse[0].ID)
+	})
+
+	t.Run("GetClassroomAssignmentProjects rejects unknown status", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/projects?status=unknown", classroom.ID.String(), assignment.ID.String())
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
 }
@@ -3,7 +3,6 @@ package api
 import (
 	"database/sql/driver"
 	"fmt"
-	"log"
 
 	"gorm.io/gen/field"
 
@@ -63,7 +62,17 @@ func (ctrl *DefaultController) InviteToAssignment(c *fiber.Ctx) (err error) {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	queryUser := query.User
+	me, err := queryUser.
+		WithContext(c.Context()).
+		Where(queryUser.ID.Eq(userID)).
+		First()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
 	err = query.Q.Transaction(func(tx *query.Query) (err error) {
+		outbox := mailRepo.NewOutboxRepository(c.Context(), tx, &classroom.ClassroomID)
 		for _, team := range invitableTeams {
 			assignmentProject := &database.AssignmentProjects{
 				AssignmentID:  assignment.ID,
@@ -73,7 +82,23 @@ func (ctrl *DefaultController) InviteToAssignment(c *fiber.Ctx) (err error) {
 			if err = tx.AssignmentProjects.WithContext(c.Context()).Create(assignmentProject); err != nil {
 				return err
 			}
-			team.AssignmentProjects = []*database.AssignmentProjects{assignmentProject}
+
+			joinPath := fmt.Sprintf("/classrooms/%s/projects/%s/accept", classroom.ClassroomID.String(), assignmentProject.ID.String())
+			for _, member := range team.Member {
+				err = outbox.SendAssignmentNotification(member.User.GitlabEmail,
+					fmt.Sprintf(`You were invited to a new Assigment "%s"`,
+						classroom.Classroom.Name),
+					mailRepo.AssignmentNotificationData{
+						ClassroomName:      classroom.Classroom.Name,
+						ClassroomOwnerName: me.Name,
+						RecipientName:      member.User.Name,
+						AssignmentName:     assignment.Name,
+						JoinPath:           joinPath,
+					})
+				if err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
@@ -81,35 +106,5 @@ func (ctrl *DefaultController) InviteToAssignment(c *fiber.Ctx) (err error) {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	queryUser := query.User
-	me, err := queryUser.
-		WithContext(c.Context()).
-		Where(queryUser.ID.Eq(userID)).
-		First()
-	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
-	}
-
-	for _, team := range invitableTeams {
-		for _, member := range team.Member {
-			log.Println("Sending invitation to", member.User.GitlabEmail)
-
-			joinPath := fmt.Sprintf("/classrooms/%s/projects/%s/accept", classroom.ClassroomID.String(), team.AssignmentProjects[0].ID.String())
-			err = ctrl.mailRepo.SendAssignmentNotification(member.User.GitlabEmail,
-				fmt.Sprintf(`You were invited to a new Assigment "%s"`,
-					classroom.Classroom.Name),
-				mailRepo.AssignmentNotificationData{
-					ClassroomName:      classroom.Classroom.Name,
-					ClassroomOwnerName: me.Name,
-					RecipientName:      member.User.Name,
-					AssignmentName:     assignment.Name,
-					JoinPath:           joinPath,
-				})
-			if err != nil {
-				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
-			}
-		}
-	}
-
 	return c.SendStatus(fiber.StatusCreated)
 }
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http/httptest"
 	"testing"
@@ -15,7 +17,6 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 )
 
 func TestPostClassroomAssignmentProjects(t *testing.T) {
@@ -40,32 +41,31 @@ func TestPostClassroomAssignmentProjects(t *testing.T) {
 	factory.Team(classroom.ID, []*database.UserClassrooms{userClassroom})
 
 	// setup app
-	app, _, mockMailRepo := setupApp(t, owner)
+	app, _, _ := setupApp(t, owner)
 
 	t.Run("PostOwnedClassroomAssignmentProjects", func(t *testing.T) {
 		route := fmt.Sprintf("/api/v1/classrooms/%s/assignments/%s/projects", classroom.ID.String(), assignment.ID.String())
 
-		mockMailRepo.
-			EXPECT().
-			SendAssignmentNotification(
-				user.GitlabEmail,
-				fmt.Sprintf(`You were invited to a new Assigment "%s"`, classroom.Name),
-				mock.Anything,
-			).
-			RunAndReturn(func(gitlabEmail, mailTitle string, notificationData mailRepo.AssignmentNotificationData) error {
-				assert.Equal(t, notificationData.ClassroomName, classroom.Name)
-				assert.Equal(t, notificationData.ClassroomOwnerName, owner.Name)
-				assert.Equal(t, notificationData.RecipientName, user.Name)
-				assert.Equal(t, notificationData.AssignmentName, assignment.Name)
-
-				return nil
-			})
-
 		req := httptest.NewRequest("POST", route, nil)
 		resp, err := app.Test(req)
 
 		assert.NoError(t, err)
 		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
 
+		outgoingMail, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.Recipient.Eq(user.GitlabEmail)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.AssignmentNotificationMail, outgoingMail.Kind)
+		assert.Equal(t, database.MailPending, outgoingMail.Status)
+		assert.Equal(t, fmt.Sprintf(`You were invited to a new Assigment "%s"`, classroom.Name), outgoingMail.Subject)
+
+		var notificationData mailRepo.AssignmentNotificationData
+		assert.NoError(t, json.Unmarshal(outgoingMail.Data, &notificationData))
+		assert.Equal(t, classroom.Name, notificationData.ClassroomName)
+		assert.Equal(t, owner.Name, notificationData.ClassroomOwnerName)
+		assert.Equal(t, user.Name, notificationData.RecipientName)
+		assert.Equal(t, assignment.Name, notificationData.AssignmentName)
 	})
 }
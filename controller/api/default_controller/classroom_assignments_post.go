@@ -2,11 +2,13 @@ package api
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
 
@@ -15,14 +17,34 @@ type createAssignmentRequest struct {
 	Description       string     `json:"description"`
 	TemplateProjectId int        `json:"templateProjectId"`
 	DueDate           *time.Time `json:"dueDate" validate:"optional"`
+
+	// Additional template projects, which are distributed to the teams together with the template project
+	TemplateVariantIds   []int                          `json:"templateVariantIds" validate:"optional"`
+	TemplateDistribution *database.TemplateDistribution `json:"templateDistribution" validate:"optional"`
 } //@Name CreateAssignmentRequest
 
 func (r createAssignmentRequest) isValid() bool {
-	return r.Name != "" && r.TemplateProjectId != 0
+	if r.TemplateDistribution != nil &&
+		*r.TemplateDistribution != database.RandomDistribution &&
+		*r.TemplateDistribution != database.RoundRobinDistribution {
+		return false
+	}
+	return r.Name != "" && r.TemplateProjectId != 0 && !slices.Contains(r.TemplateVariantIds, 0)
+}
+
+// templateProjectIds returns the template project followed by the distinct template variants.
+func (r createAssignmentRequest) templateProjectIds() []int {
+	ids := []int{r.TemplateProjectId}
+	for _, id := range r.TemplateVariantIds {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
 }
 
 // @Summary		CreateAssignment
-// @Description	CreateAssignment
+// @Description	Create an assignment. The template projects must be accessible and forkable for the classroom, see ValidateTemplateProject.
 // @Id				CreateAssignment
 // @Tags			assignment
 // @Accept			json
@@ -51,13 +73,23 @@ func (ctrl *DefaultController) CreateAssignment(c *fiber.Ctx) (err error) {
 		return fiber.ErrBadRequest
 	}
 
-	// Check if template repository exists
-	if _, err = repo.GetProjectById(requestBody.TemplateProjectId); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	// Check if the classroom can fork the template repositories
+	if err = repo.GroupAccessLogin(classroom.Classroom.GroupAccessToken); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	templateProjectIds := requestBody.templateProjectIds()
+	for _, templateProjectId := range templateProjectIds {
+		validation, _, err := validateTemplateProject(repo, templateProjectId)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if !validation.Valid {
+			return fiber.NewError(fiber.StatusBadRequest, templateErrors(validation))
+		}
 	}
 
 	// Create assigment
-	assignmentQuery := query.Assignment
 	assignment := &database.Assignment{
 		ClassroomID:       classroom.ClassroomID,
 		TemplateProjectID: requestBody.TemplateProjectId,
@@ -66,8 +98,32 @@ func (ctrl *DefaultController) CreateAssignment(c *fiber.Ctx) (err error) {
 		DueDate:           requestBody.DueDate,
 	}
 
+	if requestBody.TemplateDistribution != nil {
+		assignment.TemplateDistribution = *requestBody.TemplateDistribution
+	}
+
+	if len(templateProjectIds) > 1 {
+		for i, templateProjectId := range templateProjectIds {
+			assignment.TemplateVariants = append(assignment.TemplateVariants, &database.AssignmentTemplateVariant{
+				TemplateProjectID: templateProjectId,
+				Position:          i,
+			})
+		}
+	}
+
 	// Persist assigment
-	if err = assignmentQuery.WithContext(c.Context()).Create(assignment); err != nil {
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.Assignment.WithContext(c.Context()).Create(assignment); err != nil {
+			return err
+		}
+
+		return webhook.NewOutbox(c.Context(), tx, classroom.ClassroomID).Emit(database.AssignmentCreatedEvent, webhook.AssignmentData{
+			AssignmentID: assignment.ID,
+			Name:         assignment.Name,
+			DueDate:      assignment.DueDate,
+		})
+	})
+	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
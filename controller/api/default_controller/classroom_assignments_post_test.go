@@ -8,6 +8,7 @@ import (
 
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -38,6 +39,12 @@ func TestPostClassroomAssignment(t *testing.T) {
 	app, gitlabRepo, _ := setupApp(t, owner)
 	route := fmt.Sprintf("/api/v1/classrooms/%s/assignments", classroom.ID.String())
 
+	gitlabRepo.
+		EXPECT().GroupAccessLogin(classroom.GroupAccessToken).Return(nil).Maybe()
+	templateProject := func(id int) *model.Project {
+		return &model.Project{ID: id, DefaultBranch: "main", ForkingEnabled: true}
+	}
+
 	t.Run("PostClassroomAssignment", func(t *testing.T) {
 		requestBody := createAssignmentRequest{
 			Name:              gofakeit.Name(),
@@ -47,7 +54,7 @@ func TestPostClassroomAssignment(t *testing.T) {
 		}
 
 		gitlabRepo.
-			EXPECT().GetProjectById(requestBody.TemplateProjectId).Return(nil, nil)
+			EXPECT().GetProjectById(requestBody.TemplateProjectId).Return(templateProject(requestBody.TemplateProjectId), nil)
 
 		req := newPostJsonRequest(route, requestBody)
 		resp, err := app.Test(req)
@@ -64,4 +71,67 @@ func TestPostClassroomAssignment(t *testing.T) {
 		assert.Equal(t, assignment.TemplateProjectID, requestBody.TemplateProjectId)
 		assert.WithinDuration(t, *assignment.DueDate, *requestBody.DueDate, 1 * time.Minute)
 	})
+
+	t.Run("PostClassroomAssignment with template variants", func(t *testing.T) {
+		distribution := database.RoundRobinDistribution
+		requestBody := createAssignmentRequest{
+			Name:                 gofakeit.Name(),
+			TemplateProjectId:    1001,
+			TemplateVariantIds:   []int{1002, 1001},
+			TemplateDistribution: &distribution,
+		}
+
+		gitlabRepo.
+			EXPECT().GetProjectById(1001).Return(templateProject(1001), nil)
+		gitlabRepo.
+			EXPECT().GetProjectById(1002).Return(templateProject(1002), nil)
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		assignment, err := query.Assignment.WithContext(context.Background()).
+			Preload(query.Assignment.TemplateVariants.Order(query.AssignmentTemplateVariant.Position)).
+			Where(query.Assignment.Name.Eq(requestBody.Name)).
+			First()
+
+		assert.NoError(t, err)
+		assert.Equal(t, database.RoundRobinDistribution, assignment.TemplateDistribution)
+		assert.Len(t, assignment.TemplateVariants, 2)
+		assert.Equal(t, 1001, assignment.TemplateVariants[0].TemplateProjectID)
+		assert.Equal(t, 1002, assignment.TemplateVariants[1].TemplateProjectID)
+	})
+
+	t.Run("rejects templates which cannot be forked", func(t *testing.T) {
+		requestBody := createAssignmentRequest{
+			Name:              gofakeit.Name(),
+			TemplateProjectId: 1003,
+		}
+
+		gitlabRepo.
+			EXPECT().GetProjectById(1003).Return(&model.Project{ID: 1003, DefaultBranch: "main"}, nil)
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects invalid template distribution", func(t *testing.T) {
+		distribution := database.TemplateDistribution("invalid")
+		requestBody := createAssignmentRequest{
+			Name:                 gofakeit.Name(),
+			TemplateProjectId:    1001,
+			TemplateDistribution: &distribution,
+		}
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
 }
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type duplicateClassroomRequest struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description" validate:"optional"`
+	DueDateOffsetDays int     `json:"dueDateOffsetDays"`
+} //@Name DuplicateClassroomRequest
+
+func (r duplicateClassroomRequest) isValid() bool {
+	return r.Name != "" && (r.Description == nil || *r.Description != "")
+}
+
+// @Summary		Duplicate a classroom
+// @Description	Creates a new classroom with the settings, grading rubrics and assignments of the given classroom. Members, teams and projects are not copied. The due dates of the assignments are shifted by the given amount of days.
+// @Id				DuplicateClassroom
+// @Tags			classroom
+// @Accept			json
+// @Param			classroomId		path	string							true	"Classroom ID"	Format(uuid)
+// @Param			classroom		body	api.duplicateClassroomRequest	true	"Duplicate Classroom Info"
+// @Param			X-Csrf-Token	header	string							true	"Csrf-Token"
+// @Success		201
+// @Header			201	{string}	Location	"/api/v1/classroom/{classroomId}"
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/duplicate [post]
+func (ctrl *DefaultController) DuplicateClassroom(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+	userClassroom := ctx.GetUserClassroom()
+	source := userClassroom.Classroom
+
+	userID := ctx.GetUserID()
+
+	var requestBody duplicateClassroomRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	description := source.Description
+	if requestBody.Description != nil {
+		description = *requestBody.Description
+	}
+
+	queryUser := query.User
+	user, err := queryUser.WithContext(c.Context()).Where(queryUser.ID.Eq(userID)).First()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	queryAssignment := query.Assignment
+	sourceAssignments, err := queryAssignment.
+		WithContext(c.Context()).
+		Preload(queryAssignment.JUnitTests).
+		Preload(queryAssignment.GradingManualRubrics).
+		Preload(queryAssignment.TemplateVariants).
+		Where(queryAssignment.ClassroomID.Eq(source.ID)).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	queryRubric := query.ManualGradingRubric
+	sourceRubrics, err := queryRubric.
+		WithContext(c.Context()).
+		Where(queryRubric.ClassroomID.Eq(source.ID)).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	group, err := repo.CreateGroup(
+		requestBody.Name,
+		model.Private,
+		description,
+	)
+	if err != nil {
+		var gitlabError *model.GitLabError
+		if errors.As(err, &gitlabError) {
+			if strings.Contains(gitlabError.Message, "{:path=>[\"has already been taken\"]}}") {
+				return fiber.NewError(fiber.StatusBadRequest, "A classroom with this name already exists")
+			}
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	defer func() {
+		if recover() != nil || err != nil {
+			if err := repo.DeleteGroup(group.ID); err != nil {
+				log.Println(err.Error())
+			}
+		}
+	}()
+
+	expiresAt := time.Now().AddDate(0, 0, 364)
+
+	accessToken, err := repo.CreateGroupAccessToken(group.ID, "GitClassrooms", model.OwnerPermissions, expiresAt, "api")
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	// We don't need to delete the accessToken because it will be deleted when the group is deleted
+
+	var classroom *database.Classroom
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		classroom = &database.Classroom{
+			Name:                    requestBody.Name,
+			Description:             description,
+			OwnerID:                 userID,
+			CreateTeams:             source.CreateTeams,
+			MaxTeamSize:             source.MaxTeamSize,
+			MaxTeams:                source.MaxTeams,
+			GroupID:                 group.ID,
+			GroupAccessTokenID:      accessToken.ID,
+			GroupAccessToken:        accessToken.Token,
+			StudentsViewAllProjects: source.StudentsViewAllProjects,
+			Member:                  []*database.UserClassrooms{{UserID: userID, Role: database.Owner}},
+		}
+
+		if err := tx.Classroom.WithContext(c.Context()).Create(classroom); err != nil {
+			return err
+		}
+
+		rubrics := make(map[uuid.UUID]*database.ManualGradingRubric, len(sourceRubrics))
+		for _, sourceRubric := range sourceRubrics {
+			rubric := &database.ManualGradingRubric{
+				Name:        sourceRubric.Name,
+				Description: sourceRubric.Description,
+				ClassroomID: classroom.ID,
+				MaxScore:    sourceRubric.MaxScore,
+			}
+			if err := tx.ManualGradingRubric.WithContext(c.Context()).Create(rubric); err != nil {
+				return err
+			}
+			rubrics[sourceRubric.ID] = rubric
+		}
+
+		for _, sourceAssignment := range sourceAssignments {
+			assignment := &database.Assignment{
+				ClassroomID:                   classroom.ID,
+				TemplateProjectID:             sourceAssignment.TemplateProjectID,
+				Name:                          sourceAssignment.Name,
+				Description:                   sourceAssignment.Description,
+				DueDate:                       shiftDueDate(sourceAssignment.DueDate, requestBody.DueDateOffsetDays),
+				GradingJUnitAutoGradingActive: sourceAssignment.GradingJUnitAutoGradingActive,
+				JUnitTests: utils.Map(sourceAssignment.JUnitTests, func(test *database.AssignmentJunitTest) *database.AssignmentJunitTest {
+					return &database.AssignmentJunitTest{Name: test.Name, Score: test.Score}
+				}),
+				GradingManualRubrics: utils.Map(sourceAssignment.GradingManualRubrics, func(rubric *database.ManualGradingRubric) *database.ManualGradingRubric {
+					return rubrics[rubric.ID]
+				}),
+				TemplateVariants: utils.Map(sourceAssignment.TemplateVariants, func(variant *database.AssignmentTemplateVariant) *database.AssignmentTemplateVariant {
+					return &database.AssignmentTemplateVariant{TemplateProjectID: variant.TemplateProjectID, Position: variant.Position}
+				}),
+				TemplateDistribution: sourceAssignment.TemplateDistribution,
+			}
+			if err := tx.Assignment.WithContext(c.Context()).Create(assignment); err != nil {
+				return err
+			}
+		}
+
+		if _, err := repo.ChangeGroupDescription(group.ID, utils.CreateClassroomGitlabDescription(classroom, ctrl.config.PublicURL)); err != nil {
+			return err
+		}
+
+		invitation := &database.ClassroomInvitation{
+			Status:      database.ClassroomInvitationAccepted,
+			ClassroomID: classroom.ID,
+			Email:       user.GitlabEmail,
+			ExpiryDate:  time.Now().AddDate(0, 0, 14),
+		}
+		return tx.ClassroomInvitation.WithContext(c.Context()).Create(invitation)
+	})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	c.Set("Location", fmt.Sprintf("/api/v1/classrooms/%s", classroom.ID.String()))
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+func shiftDueDate(dueDate *time.Time, days int) *time.Time {
+	if dueDate == nil {
+		return nil
+	}
+	return utils.NewPtr(dueDate.AddDate(0, 0, days))
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestDuplicateClassroom(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	student := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+	members := []*database.UserClassrooms{
+		factory.UserClassroom(student.ID, classroom.ID, database.Student),
+	}
+
+	dueDate := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	assignment := factory.Assignment(classroom.ID, &dueDate, true)
+	team := factory.Team(classroom.ID, members)
+	factory.AssignmentProject(assignment.ID, team.ID)
+
+	rubric := &database.ManualGradingRubric{Name: "Code Quality", Description: "Clean code", ClassroomID: classroom.ID, MaxScore: 10}
+	if err := query.ManualGradingRubric.WithContext(context.Background()).Create(rubric); err != nil {
+		t.Fatal(err)
+	}
+	assignment.GradingManualRubrics = []*database.ManualGradingRubric{rubric}
+	assignment.JUnitTests = []*database.AssignmentJunitTest{{Name: "TestAdd", Score: 5}}
+	if err := query.Assignment.WithContext(context.Background()).Save(assignment); err != nil {
+		t.Fatal(err)
+	}
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	targetRoute := fmt.Sprintf("/api/v1/classrooms/%s/duplicate", classroom.ID.String())
+
+	t.Run("invalid request", func(t *testing.T) {
+		req := newPostJsonRequest(targetRoute, duplicateClassroomRequest{})
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("duplicates classroom", func(t *testing.T) {
+		requestBody := duplicateClassroomRequest{
+			Name:              "Next Semester",
+			DueDateOffsetDays: 182,
+		}
+
+		gitlabRepo.
+			EXPECT().
+			CreateGroup(requestBody.Name, model.Private, classroom.Description).
+			Return(&model.Group{ID: 2}, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			CreateGroupAccessToken(2, "GitClassrooms", model.OwnerPermissions, mock.AnythingOfType("time.Time"), "api").
+			Return(&model.GroupAccessToken{ID: 21, Token: "new-token"}, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			ChangeGroupDescription(2, mock.Anything).
+			Return(nil, nil).
+			Times(1)
+
+		req := newPostJsonRequest(targetRoute, requestBody)
+		resp, err := app.Test(req)
+
+		gitlabRepo.AssertExpectations(t)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		newClassroom, err := query.Classroom.
+			WithContext(context.Background()).
+			Preload(query.Classroom.Member).
+			Preload(query.Classroom.Teams).
+			Preload(query.Classroom.ManualGradingRubrics).
+			Where(query.Classroom.GroupID.Eq(2)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, requestBody.Name, newClassroom.Name)
+		assert.Equal(t, classroom.MaxTeamSize, newClassroom.MaxTeamSize)
+		assert.Equal(t, "new-token", newClassroom.GroupAccessToken)
+		assert.Len(t, newClassroom.Member, 1)
+		assert.Empty(t, newClassroom.Teams)
+		assert.Len(t, newClassroom.ManualGradingRubrics, 1)
+		assert.NotEqual(t, rubric.ID, newClassroom.ManualGradingRubrics[0].ID)
+		assert.Equal(t, fmt.Sprintf("/api/v1/classrooms/%s", newClassroom.ID.String()), resp.Header.Get("Location"))
+
+		newAssignment, err := query.Assignment.
+			WithContext(context.Background()).
+			Preload(query.Assignment.JUnitTests).
+			Preload(query.Assignment.GradingManualRubrics).
+			Preload(query.Assignment.Projects).
+			Where(query.Assignment.ClassroomID.Eq(newClassroom.ID)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, assignment.Name, newAssignment.Name)
+		assert.True(t, dueDate.AddDate(0, 0, 182).Equal(*newAssignment.DueDate))
+		assert.Len(t, newAssignment.JUnitTests, 1)
+		assert.Equal(t, 5, newAssignment.JUnitTests[0].Score)
+		assert.Len(t, newAssignment.GradingManualRubrics, 1)
+		assert.Equal(t, newClassroom.ManualGradingRubrics[0].ID, newAssignment.GradingManualRubrics[0].ID)
+		assert.Empty(t, newAssignment.Projects)
+	})
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		GetClassroomEvents
+// @Description	Stream the events of the classroom as server-sent events, e.g. the project creations, the autograding progress and changes of the sync with GitLab.
+// @Id				GetClassroomEvents
+// @Tags			classroom
+// @Produce		text/event-stream
+// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Success		200			{object}	events.Event
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/events [get]
+func (ctrl *DefaultController) GetClassroomEvents(c *fiber.Ctx) error {
+	return ctrl.streamEvents(c, events.ForClassroom(context.Get(c).GetUserClassroom().ClassroomID))
+}
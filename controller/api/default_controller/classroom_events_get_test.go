@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/config"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+func TestGetClassroomEvents(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	student := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+	factory.UserClassroom(student.ID, classroom.ID, database.Student)
+
+	route := fmt.Sprintf("/api/v1/classrooms/%s/events", classroom.ID.String())
+
+	t.Run("students can not stream the classroom", func(t *testing.T) {
+		app, _, _ := setupApp(t, student)
+
+		req := httptest.NewRequest("GET", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("streams the published events of the classroom", func(t *testing.T) {
+		broker := events.NewBroker()
+		handler := NewApiV1Controller(config.ApplicationConfig{}, nil, broker)
+
+		// The stream does not end, so it is read from a running server instead of app.Test
+		app := fiber.New()
+		app.Get("/api/v1/classrooms/:classroomId/events", func(c *fiber.Ctx) error {
+			context.Get(c).SetUserClassroom(&database.UserClassrooms{ClassroomID: classroom.ID, Role: database.Owner})
+			return c.Next()
+		}, handler.GetClassroomEvents)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		go app.Listener(listener)
+		defer app.Shutdown()
+
+		client := &http.Client{Timeout: 5 * time.Second}
+		resp, err := client.Get("http://" + listener.Addr().String() + route)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get(fiber.HeaderContentType))
+
+		reader := bufio.NewReader(resp.Body)
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, ": connected\n", line)
+
+		teamID := uuid.New()
+		broker.Publish(events.Event{Type: events.SyncEvent, ClassroomID: uuid.New(), Data: events.SyncData{Change: events.ClassroomDeletedChange}})
+		broker.Publish(events.Event{Type: events.SyncEvent, ClassroomID: classroom.ID, Data: events.SyncData{Change: events.TeamDeletedChange, TeamID: &teamID}})
+
+		var eventType, data string
+		for data == "" {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value, ok := strings.CutPrefix(line, "event: "); ok {
+				eventType = strings.TrimSpace(value)
+			}
+			if value, ok := strings.CutPrefix(line, "data: "); ok {
+				data = strings.TrimSpace(value)
+			}
+		}
+		assert.Equal(t, string(events.SyncEvent), eventType)
+
+		var event struct {
+			ClassroomID uuid.UUID       `json:"classroomId"`
+			Data        events.SyncData `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(data), &event))
+		assert.Equal(t, classroom.ID, event.ClassroomID)
+		assert.Equal(t, events.TeamDeletedChange, event.Data.Change)
+		assert.Equal(t, teamID, *event.Data.TeamID)
+
+		// Closing the broker ends the stream
+		broker.Close()
+		_, err = reader.ReadString('\n')
+		for err == nil {
+			_, err = reader.ReadString('\n')
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		ExportClassroom
+// @Description	Exports the settings, rubrics, assignments, members, teams, projects and grading results of the classroom as a versioned bundle.
+// @Id				ExportClassroom
+// @Tags			classroom
+// @Produce		application/zip
+// @Produce		json
+// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Success		200			{file}		application/zip
+// @Success		200			{object}	utils.ClassroomBundle
+// @Failure		400			{object}	HTTPError
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/export [get]
+func (ctrl *DefaultController) ExportClassroom(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	classroom := ctx.GetUserClassroom()
+
+	queryRubric := query.ManualGradingRubric
+	rubrics, err := queryRubric.
+		WithContext(c.Context()).
+		Where(queryRubric.ClassroomID.Eq(classroom.ClassroomID)).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	assignments, err := assignmentGradingQuery(c, classroom.ClassroomID).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	queryTeam := query.Team
+	teams, err := queryTeam.
+		WithContext(c.Context()).
+		Where(queryTeam.ClassroomID.Eq(classroom.ClassroomID)).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	members, err := classroomMemberQuery(c, classroom.ClassroomID).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	bundle := utils.CreateClassroomBundle(&classroom.Classroom, rubrics, assignments, teams, members)
+
+	acceptHeader := c.Get("Accept")
+	if strings.Contains(acceptHeader, "application/json") {
+		return c.JSON(bundle)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=export_%s_%s.zip", time.Now().Format(time.DateOnly), classroom.Classroom.Name))
+
+	return utils.WriteClassroomBundleZip(c.Response().BodyWriter(), bundle)
+}
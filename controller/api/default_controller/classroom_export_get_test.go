@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestExportClassroom(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	student := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+	members := []*database.UserClassrooms{
+		factory.UserClassroom(student.ID, classroom.ID, database.Student),
+	}
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	team := factory.Team(classroom.ID, members)
+	project := factory.AssignmentProject(assignment.ID, team.ID)
+
+	app, _, _ := setupApp(t, owner)
+	route := fmt.Sprintf("/api/v1/classrooms/%s/export", classroom.ID)
+
+	t.Run("exports classroom as json", func(t *testing.T) {
+		req := httptest.NewRequest("GET", route, nil)
+		req.Header.Set("Accept", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var bundle utils.ClassroomBundle
+		err = json.NewDecoder(resp.Body).Decode(&bundle)
+		assert.NoError(t, err)
+
+		assert.Equal(t, utils.ClassroomBundleVersion, bundle.Version)
+		assert.Equal(t, classroom.Name, bundle.Classroom.Name)
+		assert.Len(t, bundle.Members, 2)
+		assert.Len(t, bundle.Teams, 1)
+		assert.Len(t, bundle.Assignments, 1)
+		assert.Equal(t, project.ProjectID, bundle.Assignments[0].Projects[0].ProjectID)
+	})
+
+	t.Run("exports classroom as zip", func(t *testing.T) {
+		req := httptest.NewRequest("GET", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		bundle, err := utils.ReadClassroomBundle(data)
+		assert.NoError(t, err)
+		assert.Equal(t, classroom.Name, bundle.Classroom.Name)
+	})
+}
@@ -2,17 +2,35 @@ package api
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gorm.io/gen/field"
 )
 
+type getClassroomInvitationsQuery struct {
+	listQuery
+	Status *database.ClassroomInvitationStatus `query:"status"`
+}
+
+func (q getClassroomInvitationsQuery) isValid() bool {
+	return q.Status == nil || *q.Status <= database.ClassroomInvitationFailed
+}
+
 // @Summary		GetClassroomInvitations
 // @Description	GetClassroomInvitations
 // @Id				GetClassroomInvitations
 // @Tags			classroom
 // @Produce		json
-// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Param			classroomId	path		string								true	"Classroom ID"	Format(uuid)
+// @Param			status		query		database.ClassroomInvitationStatus	false	"Filter by status"
+// @Param			search		query		string								false	"Search by email"
+// @Param			sort		query		string								false	"Sort by email, status or createdAt, prefixed with - for descending order"	Enums(email, -email, status, -status, createdAt, -createdAt)
+// @Param			page		query		int									false	"Page, all invitations are returned if neither page nor perPage is set"
+// @Param			perPage		query		int									false	"Page size (default: 20, max: 100)"
 // @Success		200			{array}		ClassroomInvitation
+// @Header			200			{string}	Link	"Links to the first, previous, next and last page"
+// @Header			200			{int}		X-Total	"Total number of invitations"
 // @Failure		400			{object}	HTTPError
 // @Failure		401			{object}	HTTPError
 // @Failure		403			{object}	HTTPError
@@ -23,11 +41,38 @@ func (ctrl *DefaultController) GetClassroomInvitations(c *fiber.Ctx) (err error)
 	ctx := context.Get(c)
 	classroom := ctx.GetUserClassroom()
 
+	var params getClassroomInvitationsQuery
+	if err = c.QueryParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !params.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Status is not valid")
+	}
+
 	queryInvitations := query.ClassroomInvitation
-	invitations, err := queryInvitations.
+	dbQuery := queryInvitations.
 		WithContext(c.Context()).
-		Where(query.ClassroomInvitation.ClassroomID.Eq(classroom.ClassroomID)).
-		Find()
+		Where(query.ClassroomInvitation.ClassroomID.Eq(classroom.ClassroomID))
+
+	if params.Status != nil {
+		dbQuery = dbQuery.Where(queryInvitations.Status.Eq(uint8(*params.Status)))
+	}
+
+	if params.Search != "" {
+		dbQuery = dbQuery.Where(containsIgnoreCase(params.Search, queryInvitations.Email))
+	}
+
+	order, ok := params.orderBy(map[string]field.OrderExpr{
+		"email":     queryInvitations.Email,
+		"status":    queryInvitations.Status,
+		"createdAt": queryInvitations.CreatedAt,
+	}, queryInvitations.CreatedAt.Desc())
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Sort field is not valid")
+	}
+
+	invitations, err := findPage(c, dbQuery.Order(order...), params.paginationQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http/httptest"
@@ -9,6 +10,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
 )
 
@@ -39,4 +41,60 @@ func TestGetOwnedClassroomInvitations(t *testing.T) {
 		assert.Len(t, invitations, 1)
 		assert.Equal(t, invitation.Email, invitations[0].Email)
 	})
+
+	accepted := factory.Invitation(classroom.ID)
+	accepted.Email = "accepted@example.com"
+	accepted.Status = database.ClassroomInvitationAccepted
+	if err := query.ClassroomInvitation.WithContext(context.Background()).Save(accepted); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("GetClassroomInvitations filtered by status", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/invitations?status=%d", classroom.ID.String(), database.ClassroomInvitationAccepted)
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "1", resp.Header.Get("X-Total"))
+
+		var invitations []*database.ClassroomInvitation
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&invitations))
+		assert.Len(t, invitations, 1)
+		assert.Equal(t, accepted.ID, invitations[0].ID)
+	})
+
+	t.Run("GetClassroomInvitations paginated, sorted and searched", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/invitations?sort=createdAt&page=2&perPage=1", classroom.ID.String())
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-Total"))
+		assert.Contains(t, resp.Header.Get("Link"), `page=1&perPage=1&sort=createdAt>; rel="prev"`)
+
+		var invitations []*database.ClassroomInvitation
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&invitations))
+		assert.Len(t, invitations, 1)
+		assert.Equal(t, accepted.ID, invitations[0].ID)
+
+		route = fmt.Sprintf("/api/v1/classrooms/%s/invitations?search=ACCEPTED@", classroom.ID.String())
+		resp, err = app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		invitations = nil
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&invitations))
+		assert.Len(t, invitations, 1)
+		assert.Equal(t, accepted.ID, invitations[0].ID)
+	})
+
+	t.Run("GetClassroomInvitations rejects unknown status and sort field", func(t *testing.T) {
+		for _, parameters := range []string{"status=9", "status=pending", "sort=token"} {
+			route := fmt.Sprintf("/api/v1/classrooms/%s/invitations?%s", classroom.ID.String(), parameters)
+
+			resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, parameters)
+		}
+	})
 }
@@ -1,11 +1,8 @@
 package api
 
 import (
-	"context"
 	"fmt"
-	"log"
 	"net/mail"
-	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -70,11 +67,10 @@ func (ctrl *DefaultController) InviteToClassroom(c *fiber.Ctx) (err error) {
 
 	invitableEmails := filterInvitableEmails(validatedEmailAddresses, invites)
 
-	invitations := make([]*database.ClassroomInvitation, len(invitableEmails))
-
 	// Create invitations
 	err = query.Q.Transaction(func(tx *query.Query) error {
-		for i, email := range invitableEmails {
+		outbox := mailRepo.NewOutboxRepository(c.Context(), tx, &classroom.ClassroomID)
+		for _, email := range invitableEmails {
 			_, err := tx.ClassroomInvitation.
 				WithContext(c.Context()).
 				Where(tx.ClassroomInvitation.Email.Eq(email.Address)).
@@ -93,7 +89,23 @@ func (ctrl *DefaultController) InviteToClassroom(c *fiber.Ctx) (err error) {
 			if err = tx.ClassroomInvitation.WithContext(c.Context()).Create(newInvitation); err != nil {
 				return err
 			}
-			invitations[i] = newInvitation
+
+			// The mail is sent by the delivery worker once the invitation is committed
+			err = outbox.SendClassroomInvitation(
+				email.Address,
+				fmt.Sprintf(`New Invitation for Classroom "%s"`, classroom.Classroom.Name),
+				mailRepo.ClassroomInvitationData{
+					InvitationID:       newInvitation.ID,
+					ClassroomName:      classroom.Classroom.Name,
+					ClassroomOwnerName: classroom.Classroom.Owner.Name,
+					RecipientEmail:     newInvitation.Email,
+					InvitationPath:     fmt.Sprintf("/classrooms/%s/invitations/%s", classroom.ClassroomID.String(), newInvitation.ID.String()),
+					ExpireDate:         newInvitation.ExpiryDate,
+				},
+			)
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -101,8 +113,6 @@ func (ctrl *DefaultController) InviteToClassroom(c *fiber.Ctx) (err error) {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	go ctrl.sendMailsWorker(&classroom.Classroom, invitableEmails, invitations)
-
 	return c.SendStatus(fiber.StatusCreated)
 }
 
@@ -127,42 +137,3 @@ func filterInvitableEmails(emails []*mail.Address, invitations []*database.Class
 
 	return invitableEmails
 }
-
-func (ctrl *DefaultController) sendMailsWorker(classroom *database.Classroom, invitableEmails []*mail.Address, invitations []*database.ClassroomInvitation) {
-	var wg sync.WaitGroup
-	wg.Add(len(invitableEmails))
-
-	for i, email := range invitableEmails {
-		go func(classroom *database.Classroom, email string, invitation *database.ClassroomInvitation) {
-			defer wg.Done()
-
-			log.Println("Sending invitation to", email)
-			data := mailRepo.ClassroomInvitationData{
-				ClassroomName:      classroom.Name,
-				ClassroomOwnerName: classroom.Owner.Name,
-				RecipientEmail:     invitation.Email,
-				InvitationPath:     fmt.Sprintf("/classrooms/%s/invitations/%s", classroom.ID.String(), invitation.ID.String()),
-				ExpireDate:         invitation.ExpiryDate,
-			}
-			for range 3 {
-				if err := ctrl.mailRepo.SendClassroomInvitation(
-					email,
-					fmt.Sprintf(`New Invitation for Classroom "%s"`, classroom.Name),
-					data,
-				); err == nil {
-					log.Println("Sent invitation to", email)
-					return
-				}
-			}
-
-			log.Println("Could not send invitation to", email)
-			invitation.Status = database.ClassroomInvitationFailed
-			if _, err := query.ClassroomInvitation.WithContext(context.Background()).Updates(invitation); err != nil {
-				log.Println("Could not update invitation status")
-			}
-		}(classroom, email.Address, invitations[i])
-	}
-
-	wg.Wait()
-	log.Println("All invitations have been processed")
-}
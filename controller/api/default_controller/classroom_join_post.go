@@ -9,6 +9,7 @@ import (
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	gitlabModel "gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
@@ -139,6 +140,16 @@ func (ctrl *DefaultController) JoinClassroom(c *fiber.Ctx) (err error) {
 			return err
 		}
 
+		outbox := webhook.NewOutbox(c.Context(), tx, invitation.ClassroomID)
+		if err = outbox.Emit(database.MemberJoinedEvent, webhook.MemberData{
+			UserID:   userID,
+			Name:     user.Name,
+			Username: user.GitlabUsername,
+			Role:     member.Role,
+		}); err != nil {
+			return err
+		}
+
 		invitation.Status = database.ClassroomInvitationAccepted
 		invitation.Email = user.GitlabEmail
 		if err = tx.ClassroomInvitation.WithContext(c.Context()).Save(invitation); err != nil {
@@ -187,6 +198,10 @@ func (ctrl *DefaultController) JoinClassroom(c *fiber.Ctx) (err error) {
 				return err
 			}
 
+			if err = outbox.Emit(database.TeamCreatedEvent, webhook.TeamData{TeamID: team.ID, Name: team.Name}); err != nil {
+				return err
+			}
+
 			repo.ChangeGroupDescription(subgroup.ID, utils.CreateTeamGitlabDescription(&invitation.Classroom, team, ctrl.config.PublicURL))
 
 			if err = repo.AddUserToGroup(subgroup.ID, userID, gitlabModel.ReporterPermissions); err != nil {
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+)
+
+// @Summary		ResendClassroomMail
+// @Description	Queue a failed or bounced mail of the classroom's outbox for delivery again.
+// @Id				ResendClassroomMail
+// @Tags			classroom
+// @Param			classroomId		path	string	true	"Classroom ID"	Format(uuid)
+// @Param			mailId			path	string	true	"Mail ID"		Format(uuid)
+// @Param			X-Csrf-Token	header	string	true	"Csrf-Token"
+// @Success		202
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/mails/{mailId}/resend [post]
+func (ctrl *DefaultController) ResendClassroomMail(c *fiber.Ctx) (err error) {
+	var params Params
+
+	if err = c.ParamsParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if params.ClassroomID == nil || params.MailID == nil {
+		return fiber.ErrBadRequest
+	}
+
+	queryOutgoingMail := query.OutgoingMail
+	outgoingMail, err := queryOutgoingMail.
+		WithContext(c.Context()).
+		Where(queryOutgoingMail.ClassroomID.Eq(*params.ClassroomID)).
+		Where(queryOutgoingMail.ID.Eq(*params.MailID)).
+		First()
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+
+	if outgoingMail.Status != database.MailFailed && outgoingMail.Status != database.MailBounced {
+		return fiber.NewError(fiber.StatusBadRequest, "Only failed or bounced mails can be resent")
+	}
+
+	outgoingMail.Status = database.MailPending
+	outgoingMail.Attempts = 0
+	outgoingMail.NextAttemptAt = time.Now()
+	outgoingMail.LastError = nil
+
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.OutgoingMail.WithContext(c.Context()).Save(outgoingMail); err != nil {
+			return err
+		}
+
+		// Give the invitation another chance, as its mail is sent again
+		if outgoingMail.ClassroomInvitationID != nil {
+			_, err := tx.ClassroomInvitation.
+				WithContext(c.Context()).
+				Where(tx.ClassroomInvitation.ID.Eq(*outgoingMail.ClassroomInvitationID)).
+				Where(tx.ClassroomInvitation.Status.Eq(uint8(database.ClassroomInvitationFailed))).
+				Update(tx.ClassroomInvitation.Status, database.ClassroomInvitationPending)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
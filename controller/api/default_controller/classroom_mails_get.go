@@ -0,0 +1,64 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type getClassroomMailsQuery struct {
+	Status database.OutgoingMailStatus `query:"status"`
+}
+
+func (q getClassroomMailsQuery) isValid() bool {
+	switch q.Status {
+	case "", database.MailPending, database.MailSent, database.MailFailed, database.MailBounced, database.MailSuppressed:
+		return true
+	default:
+		return false
+	}
+}
+
+// @Summary		GetClassroomMails
+// @Description	Get the mails of the classroom's outbox, newest first.
+// @Id				GetClassroomMails
+// @Tags			classroom
+// @Produce		json
+// @Param			classroomId	path		string						true	"Classroom ID"	Format(uuid)
+// @Param			status		query		database.OutgoingMailStatus	false	"Only mails with the given status"
+// @Success		200			{array}		OutgoingMail
+// @Failure		400			{object}	HTTPError
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/mails [get]
+func (ctrl *DefaultController) GetClassroomMails(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	classroom := ctx.GetUserClassroom()
+
+	var urlQuery getClassroomMailsQuery
+	if err = c.QueryParser(&urlQuery); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !urlQuery.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	queryOutgoingMail := query.OutgoingMail
+	mailQuery := queryOutgoingMail.
+		WithContext(c.Context()).
+		Where(queryOutgoingMail.ClassroomID.Eq(classroom.ClassroomID))
+	if urlQuery.Status != "" {
+		mailQuery = mailQuery.Where(queryOutgoingMail.Status.Eq(string(urlQuery.Status)))
+	}
+
+	mails, err := mailQuery.Order(queryOutgoingMail.CreatedAt.Desc()).Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(mails)
+}
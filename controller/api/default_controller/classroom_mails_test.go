@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestClassroomMails(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+	invitation := factory.Invitation(classroom.ID)
+	invitation.Status = database.ClassroomInvitationFailed
+	_, err := query.ClassroomInvitation.WithContext(context.Background()).Updates(invitation)
+	assert.NoError(t, err)
+
+	lastError := "550 User unknown"
+	failedMail := &database.OutgoingMail{
+		ClassroomID:           &classroom.ID,
+		ClassroomInvitationID: &invitation.ID,
+		Kind:                  database.ClassroomInvitationMail,
+		Recipient:             invitation.Email,
+		Subject:               "Invitation",
+		Data:                  []byte("{}"),
+		Status:                database.MailBounced,
+		Attempts:              1,
+		LastError:             &lastError,
+	}
+	sentMail := &database.OutgoingMail{
+		ClassroomID: &classroom.ID,
+		Kind:        database.AssignmentNotificationMail,
+		Recipient:   owner.GitlabEmail,
+		Subject:     "Assignment",
+		Data:        []byte("{}"),
+		Status:      database.MailSent,
+		Attempts:    1,
+	}
+	err = query.OutgoingMail.WithContext(context.Background()).Create(failedMail, sentMail)
+	assert.NoError(t, err)
+
+	app, _, _ := setupApp(t, owner)
+
+	t.Run("lists mails", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/mails", classroom.ID.String())
+
+		req := httptest.NewRequest("GET", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var mails []*database.OutgoingMail
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&mails))
+		assert.Len(t, mails, 2)
+	})
+
+	t.Run("filters mails by status", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/mails?status=bounced", classroom.ID.String())
+
+		req := httptest.NewRequest("GET", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var mails []*database.OutgoingMail
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&mails))
+		assert.Len(t, mails, 1)
+		assert.Equal(t, failedMail.ID, mails[0].ID)
+	})
+
+	t.Run("rejects unknown status", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/mails?status=unknown", classroom.ID.String())
+
+		req := httptest.NewRequest("GET", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects resending sent mails", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/mails/%s/resend", classroom.ID.String(), sentMail.ID.String())
+
+		req := httptest.NewRequest("POST", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("resends failed mails", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/mails/%s/resend", classroom.ID.String(), failedMail.ID.String())
+
+		req := httptest.NewRequest("POST", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		outgoingMail, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.ID.Eq(failedMail.ID)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.MailPending, outgoingMail.Status)
+		assert.Equal(t, 0, outgoingMail.Attempts)
+		assert.Nil(t, outgoingMail.LastError)
+
+		invitation, err := query.ClassroomInvitation.
+			WithContext(context.Background()).
+			Where(query.ClassroomInvitation.ID.Eq(invitation.ID)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.ClassroomInvitationPending, invitation.Status)
+	})
+}
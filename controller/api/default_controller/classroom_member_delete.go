@@ -0,0 +1,219 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	mailRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type projectHandling string //@Name ProjectHandling
+
+const (
+	// keepProjects leaves the access of the removed member to the team projects untouched.
+	keepProjects projectHandling = "keep"
+	// archiveProjects downgrades the removed member to read-only access on the team projects.
+	archiveProjects projectHandling = "archive"
+	// transferProjects removes the member from the team projects, so they are only owned by the team.
+	transferProjects projectHandling = "transfer"
+)
+
+type removeClassroomMemberQuery struct {
+	Projects projectHandling `query:"projects"`
+	Notify   bool            `query:"notify"`
+}
+
+func (q *removeClassroomMemberQuery) isValid() bool {
+	if q.Projects == "" {
+		q.Projects = transferProjects
+	}
+	return q.Projects == keepProjects || q.Projects == archiveProjects || q.Projects == transferProjects
+}
+
+// @Summary		Remove member from the classroom
+// @Description	Remove member from the classroom. The member is removed from the classroom group and its team. The projects of the team can be kept, archived (read-only) or transferred to the team.
+// @Id				RemoveClassroomMember
+// @Tags			member
+// @Param			classroomId		path	string				true	"Classroom ID"	Format(uuid)
+// @Param			memberId		path	int					true	"Member ID"
+// @Param			projects		query	api.projectHandling	false	"Handling of the team projects (default: transfer)"
+// @Param			notify			query	bool				false	"Notify the member via mail"
+// @Param			X-Csrf-Token	header	string				true	"Csrf-Token"
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/members/{memberId} [delete]
+func (ctrl *DefaultController) RemoveClassroomMember(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	classroom := ctx.GetUserClassroom()
+	member := ctx.GetClassroomMember()
+	repo := ctx.GetGitlabRepository()
+
+	urlQuery := new(removeClassroomMemberQuery)
+	if err = c.QueryParser(urlQuery); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !urlQuery.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	if classroom.Classroom.OwnerID == member.UserID {
+		return fiber.NewError(fiber.StatusForbidden, "The Creator of the classroom cannot be removed.")
+	}
+
+	if member.Role != database.Student && classroom.Role != database.Owner {
+		return fiber.NewError(fiber.StatusForbidden, "Only owners can remove owners and moderators.")
+	}
+
+	if member.Role == database.Owner && classroom.Classroom.OwnerID != classroom.UserID {
+		return fiber.NewError(fiber.StatusForbidden, "Only the Creator of the classroom can remove owners.")
+	}
+
+	if err = repo.GroupAccessLogin(classroom.Classroom.GroupAccessToken); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if member.TeamID != nil {
+		var projects []*database.AssignmentProjects
+		queryAssignmentProjects := query.AssignmentProjects
+		projects, err = queryAssignmentProjects.
+			WithContext(c.Context()).
+			Preload(queryAssignmentProjects.Assignment).
+			Where(queryAssignmentProjects.TeamID.Eq(*member.TeamID)).
+			Where(queryAssignmentProjects.ProjectStatus.Eq(string(database.Accepted))).
+			Find()
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		switch urlQuery.Projects {
+		case archiveProjects:
+			caches := []utils.ProjectAccessLevelCache{}
+			defer func() {
+				if recover() != nil || err != nil {
+					for _, cache := range caches {
+						if err := repo.ChangeUserAccessLevelInProject(cache.ProjectID, cache.UserID, cache.AccessLevel); err != nil {
+							log.Println(err)
+						}
+					}
+				}
+			}()
+
+			for _, project := range projects {
+				permission, err := repo.GetAccessLevelOfUserInProject(project.ProjectID, member.UserID)
+				if err != nil {
+					return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+				}
+
+				if permission == model.ReporterPermissions {
+					continue
+				}
+
+				if err = repo.ChangeUserAccessLevelInProject(project.ProjectID, member.UserID, model.ReporterPermissions); err != nil {
+					return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+				}
+
+				caches = append(caches, utils.ProjectAccessLevelCache{UserID: member.UserID, ProjectID: project.ProjectID, AccessLevel: permission})
+			}
+
+		case transferProjects:
+			removedFrom := []*database.AssignmentProjects{}
+			defer func() {
+				if recover() != nil || err != nil {
+					for _, project := range removedFrom {
+						accessLevel := model.DeveloperPermissions
+						if project.Assignment.Closed {
+							accessLevel = model.ReporterPermissions
+						}
+						if err := repo.AddProjectMember(project.ProjectID, member.UserID, accessLevel); err != nil {
+							log.Println(err)
+						}
+					}
+				}
+			}()
+
+			for _, project := range projects {
+				if err = repo.RemoveUserFromProject(project.ProjectID, member.UserID); err != nil {
+					return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+				}
+				removedFrom = append(removedFrom, project)
+			}
+		}
+
+		if err = repo.RemoveUserFromGroup(member.Team.GroupID, member.UserID); err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		defer func() {
+			if recover() != nil || err != nil {
+				if err := repo.AddUserToGroup(member.Team.GroupID, member.UserID, model.ReporterPermissions); err != nil {
+					log.Println(err)
+				}
+			}
+		}()
+	}
+
+	accessLevel, err := repo.GetAccessLevelOfUserInGroup(classroom.Classroom.GroupID, member.UserID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err = repo.RemoveUserFromGroup(classroom.Classroom.GroupID, member.UserID); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	defer func() {
+		if recover() != nil || err != nil {
+			if err := repo.AddUserToGroup(classroom.Classroom.GroupID, member.UserID, accessLevel); err != nil {
+				log.Println(err)
+			}
+		}
+	}()
+
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if _, err := tx.UserClassrooms.
+			WithContext(c.Context()).
+			Where(tx.UserClassrooms.UserID.Eq(member.UserID)).
+			Where(tx.UserClassrooms.ClassroomID.Eq(member.ClassroomID)).
+			Delete(); err != nil {
+			return err
+		}
+
+		// Revoke the accepted invitation, so the user can be invited again
+		if _, err := tx.ClassroomInvitation.
+			WithContext(c.Context()).
+			Where(tx.ClassroomInvitation.ClassroomID.Eq(member.ClassroomID)).
+			Where(tx.ClassroomInvitation.Email.Eq(member.User.GitlabEmail)).
+			Where(tx.ClassroomInvitation.Status.Eq(uint8(database.ClassroomInvitationAccepted))).
+			Update(tx.ClassroomInvitation.Status, database.ClassroomInvitationRevoked); err != nil {
+			return err
+		}
+
+		if urlQuery.Notify {
+			return mailRepo.NewOutboxRepository(c.Context(), tx, &member.ClassroomID).SendClassroomRemovalNotification(
+				member.User.GitlabEmail,
+				fmt.Sprintf(`You have been removed from Classroom "%s"`, classroom.Classroom.Name),
+				mailRepo.ClassroomRemovalData{
+					ClassroomName:      classroom.Classroom.Name,
+					ClassroomOwnerName: classroom.Classroom.Owner.Name,
+					RecipientName:      member.User.Name,
+				},
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestRemoveClassroomMember(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	student := factory.User()
+	members := []*database.UserClassrooms{
+		factory.UserClassroom(student.ID, classroom.ID, database.Student),
+	}
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	team := factory.Team(classroom.ID, members)
+	assignmentProject := factory.AssignmentProject(assignment.ID, team.ID)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+
+	t.Run("creator can not be removed", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/members/%d", classroom.ID, owner.ID)
+		req := httptest.NewRequest("DELETE", route, nil)
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("invalid project handling", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/members/%d?projects=delete", classroom.ID, student.ID)
+		req := httptest.NewRequest("DELETE", route, nil)
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rollback if removing from classroom group fails", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/members/%d?projects=archive", classroom.ID, student.ID)
+
+		gitlabRepo.
+			EXPECT().
+			GroupAccessLogin(classroom.GroupAccessToken).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			GetAccessLevelOfUserInProject(assignmentProject.ProjectID, student.ID).
+			Return(model.DeveloperPermissions, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			ChangeUserAccessLevelInProject(assignmentProject.ProjectID, student.ID, model.ReporterPermissions).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			RemoveUserFromGroup(team.GroupID, student.ID).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			GetAccessLevelOfUserInGroup(classroom.GroupID, student.ID).
+			Return(model.GuestPermissions, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			RemoveUserFromGroup(classroom.GroupID, student.ID).
+			Return(fmt.Errorf("error")).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			AddUserToGroup(team.GroupID, student.ID, model.ReporterPermissions).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			ChangeUserAccessLevelInProject(assignmentProject.ProjectID, student.ID, model.DeveloperPermissions).
+			Return(nil).
+			Times(1)
+
+		req := httptest.NewRequest("DELETE", route, nil)
+		resp, err := app.Test(req)
+
+		gitlabRepo.AssertExpectations(t)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+
+		_, err = query.UserClassrooms.
+			WithContext(context.Background()).
+			Where(query.UserClassrooms.UserID.Eq(student.ID)).
+			Where(query.UserClassrooms.ClassroomID.Eq(classroom.ID)).
+			First()
+		assert.NoError(t, err)
+	})
+
+	t.Run("removes member and transfers projects to the team", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/members/%d?notify=true", classroom.ID, student.ID)
+
+		gitlabRepo.
+			EXPECT().
+			GroupAccessLogin(classroom.GroupAccessToken).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			RemoveUserFromProject(assignmentProject.ProjectID, student.ID).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			RemoveUserFromGroup(team.GroupID, student.ID).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			GetAccessLevelOfUserInGroup(classroom.GroupID, student.ID).
+			Return(model.GuestPermissions, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			RemoveUserFromGroup(classroom.GroupID, student.ID).
+			Return(nil).
+			Times(1)
+
+		req := httptest.NewRequest("DELETE", route, nil)
+		resp, err := app.Test(req)
+
+		gitlabRepo.AssertExpectations(t)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+		outgoingMail, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.Recipient.Eq(student.GitlabEmail)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.ClassroomRemovalMail, outgoingMail.Kind)
+		assert.Equal(t, fmt.Sprintf(`You have been removed from Classroom "%s"`, classroom.Name), outgoingMail.Subject)
+
+		_, err = query.UserClassrooms.
+			WithContext(context.Background()).
+			Where(query.UserClassrooms.UserID.Eq(student.ID)).
+			Where(query.UserClassrooms.ClassroomID.Eq(classroom.ID)).
+			First()
+		assert.Error(t, err)
+
+		project, err := query.AssignmentProjects.
+			WithContext(context.Background()).
+			Where(query.AssignmentProjects.ID.Eq(assignmentProject.ID)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, team.ID, project.TeamID)
+	})
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,6 +9,7 @@ import (
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	mailRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
 
@@ -156,10 +158,23 @@ func (ctrl *DefaultController) UpdateMemberTeam(c *fiber.Ctx) (err error) {
 		}
 	}()
 
-	queryUserClassrooms := query.UserClassrooms
-	if err = queryUserClassrooms.
-		WithContext(c.Context()).
-		Save(member); err != nil {
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.UserClassrooms.WithContext(c.Context()).Save(member); err != nil {
+			return err
+		}
+
+		return mailRepo.NewOutboxRepository(c.Context(), tx, &member.ClassroomID).SendTeamChangeNotification(
+			member.User.GitlabEmail,
+			fmt.Sprintf(`You have been moved to the team "%s"`, newTeam.Name),
+			mailRepo.TeamChangeData{
+				ClassroomName: classroom.Classroom.Name,
+				RecipientName: member.User.Name,
+				TeamName:      newTeam.Name,
+				Path:          fmt.Sprintf("/classrooms/%s", member.ClassroomID.String()),
+			},
+		)
+	})
+	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -5,17 +5,35 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gorm.io/gen/field"
 )
 
+type getClassroomMembersQuery struct {
+	listQuery
+	Role *database.Role `query:"role"`
+}
+
+func (q getClassroomMembersQuery) isValid() bool {
+	return q.Role == nil || *q.Role <= database.Student
+}
+
 // @Summary		GetClassroomMembers
 // @Description	GetClassroomMembers
 // @Id				GetClassroomMembers
 // @Tags			member
 // @Produce		json
-// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Param			classroomId	path		string			true	"Classroom ID"	Format(uuid)
+// @Param			role		query		database.Role	false	"Filter by role"
+// @Param			search		query		string			false	"Search by name, username or email"
+// @Param			sort		query		string			false	"Sort by name, username or role, prefixed with - for descending order"	Enums(name, -name, username, -username, role, -role)
+// @Param			page		query		int				false	"Page, all members are returned if neither page nor perPage is set"
+// @Param			perPage		query		int				false	"Page size (default: 20, max: 100)"
 // @Success		200			{array}		api.UserClassroomResponse
+// @Header			200			{string}	Link	"Links to the first, previous, next and last page"
+// @Header			200			{int}		X-Total	"Total number of members"
 // @Failure		400			{object}	HTTPError
 // @Failure		401			{object}	HTTPError
 // @Failure		404			{object}	HTTPError
@@ -25,8 +43,37 @@ func (ctrl *DefaultController) GetClassroomMembers(c *fiber.Ctx) (err error) {
 	ctx := context.Get(c)
 	classroom := ctx.GetUserClassroom()
 
-	members, err := classroomMemberQuery(c, classroom.ClassroomID).
-		Find()
+	var params getClassroomMembersQuery
+	if err = c.QueryParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !params.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Role is not valid")
+	}
+
+	queryUser := query.User
+	dbQuery := classroomMemberQuery(c, classroom.ClassroomID).
+		Join(queryUser, query.UserClassrooms.UserID.EqCol(queryUser.ID))
+
+	if params.Role != nil {
+		dbQuery = dbQuery.Where(query.UserClassrooms.Role.Eq(uint8(*params.Role)))
+	}
+
+	if params.Search != "" {
+		dbQuery = dbQuery.Where(containsIgnoreCase(params.Search, queryUser.Name, queryUser.GitlabUsername, queryUser.GitlabEmail))
+	}
+
+	order, ok := params.orderBy(map[string]field.OrderExpr{
+		"name":     queryUser.Name,
+		"username": queryUser.GitlabUsername,
+		"role":     query.UserClassrooms.Role,
+	}, query.UserClassrooms.Role, queryUser.Name)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Sort field is not valid")
+	}
+
+	members, err := findPage(c, dbQuery.Order(order...), params.paginationQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -56,4 +56,49 @@ func TestGetClassroomMembers(t *testing.T) {
 		assert.Equal(t, owner.ID, membersResponse[0].User.ID)
 		assert.Equal(t, member.ID, membersResponse[1].User.ID)
 	})
+
+	t.Run("GetClassroomMembers filtered by role", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/members?role=%d", classroom.ID.String(), database.Student)
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "1", resp.Header.Get("X-Total"))
+
+		var membersResponse []*UserClassroomResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&membersResponse))
+		assert.Len(t, membersResponse, 1)
+		assert.Equal(t, member.ID, membersResponse[0].User.ID)
+	})
+
+	t.Run("GetClassroomMembers paginated", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/members?sort=-role&page=1&perPage=1", classroom.ID.String())
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-Total"))
+		assert.Contains(t, resp.Header.Get("Link"), `page=2&perPage=1&sort=-role>; rel="next"`)
+
+		var membersResponse []*UserClassroomResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&membersResponse))
+		assert.Len(t, membersResponse, 1)
+		assert.Equal(t, member.ID, membersResponse[0].User.ID)
+	})
+
+	t.Run("GetClassroomMembers rejects unknown role", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/members?role=7", classroom.ID.String())
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("GetClassroomMembers rejects unknown sort field", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/members?sort=password", classroom.ID.String())
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
 }
@@ -5,14 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand/v2"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
 	gitlabModel "gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	fiberContext "gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
@@ -57,8 +61,17 @@ func (ctrl *DefaultController) AcceptAssignment(c *fiber.Ctx) (err error) {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	// Keep the template variant of a previous, failed attempt
+	if assignmentProject.TemplateProjectID == nil {
+		templateProjectID, err := selectTemplateProjectID(c.Context(), assignmentProject)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		assignmentProject.TemplateProjectID = &templateProjectID
+	}
+
 	// Check if template repository still exists
-	templateProject, err := repo.GetProjectById(assignmentProject.Assignment.TemplateProjectID)
+	templateProject, err := repo.GetProjectById(*assignmentProject.TemplateProjectID)
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
@@ -77,6 +90,48 @@ func (ctrl *DefaultController) AcceptAssignment(c *fiber.Ctx) (err error) {
 	return c.SendStatus(fiber.StatusAccepted)
 }
 
+// selectTemplateProjectID chooses the template variant for the team of the assignment project
+// based on the distribution of the assignment.
+func selectTemplateProjectID(ctx context.Context, assignmentProject *database.AssignmentProjects) (int, error) {
+	queryVariant := query.AssignmentTemplateVariant
+	variants, err := queryVariant.
+		WithContext(ctx).
+		Where(queryVariant.AssignmentID.Eq(assignmentProject.AssignmentID)).
+		Order(queryVariant.Position).
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(variants) == 0 {
+		return assignmentProject.Assignment.TemplateProjectID, nil
+	}
+
+	switch assignmentProject.Assignment.TemplateDistribution {
+	case database.RoundRobinDistribution:
+		queryTeam := query.Team
+		teams, err := queryTeam.
+			WithContext(ctx).
+			Where(queryTeam.ClassroomID.Eq(assignmentProject.Team.ClassroomID)).
+			Order(queryTeam.CreatedAt, queryTeam.ID).
+			Find()
+		if err != nil {
+			return 0, err
+		}
+
+		index := slices.IndexFunc(teams, func(team *database.Team) bool {
+			return team.ID == assignmentProject.TeamID
+		})
+		if index == -1 {
+			return 0, errors.New("team of the project not found in classroom")
+		}
+		return variants[index%len(variants)].TemplateProjectID, nil
+
+	default:
+		return variants[rand.IntN(len(variants))].TemplateProjectID, nil
+	}
+}
+
 const (
 	mergeRequestDescription string = `
 👋! GitLab Classroom created this merge request as a place for your teacher to leave feedback on your work. It will update automatically. **Don't close or merge this merge request**, unless you're instructed to do so by your teacher.
@@ -106,20 +161,18 @@ func (ctrl *DefaultController) acceptAssignment(repo gitlab.Repository, userID i
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	queryAssignmentProjects := query.AssignmentProjects
-
 	var err error
 	defer func() {
 		if recover() != nil || err != nil {
 			assignmentProject.ProjectStatus = database.Failed
-			if err := queryAssignmentProjects.WithContext(ctx).
-				Save(assignmentProject); err != nil {
+			if err := saveProjectStatus(ctx, assignmentProject, database.ProjectFailedEvent); err != nil {
 				log.Println("Error while setting Project to Failed!", err)
 			}
+			ctrl.publishProjectStep(assignmentProject, events.FailedStep)
 		}
 	}()
 
-	project, err := repo.ForkProjectWithOnlyDefaultBranch(assignmentProject.Assignment.TemplateProjectID, gitlabModel.Private, assignmentProject.Team.GroupID, assignmentProject.Assignment.Name, assignmentProject.Assignment.Description)
+	project, err := repo.ForkProjectWithOnlyDefaultBranch(templateProject.ID, gitlabModel.Private, assignmentProject.Team.GroupID, assignmentProject.Assignment.Name, assignmentProject.Assignment.Description)
 	if err != nil {
 		log.Println("Error while forking the template Project", err)
 		return
@@ -131,6 +184,7 @@ func (ctrl *DefaultController) acceptAssignment(repo gitlab.Repository, userID i
 			}
 		}
 	}()
+	ctrl.publishProjectStep(assignmentProject, events.ForkedStep)
 
 	// wait till default branch of forked project is the same as the template project
 	// this is necessary because the default branch is not immediately available after forking
@@ -154,6 +208,7 @@ func (ctrl *DefaultController) acceptAssignment(repo gitlab.Repository, userID i
 		log.Println("Error while adding members to the project", err)
 		return
 	}
+	ctrl.publishProjectStep(assignmentProject, events.MembersAddedStep)
 	// We don't need to clean up this step because the project will be deleted
 
 	_, err = repo.CreateBranch(project.ID, "feedback", project.DefaultBranch)
@@ -161,6 +216,7 @@ func (ctrl *DefaultController) acceptAssignment(repo gitlab.Repository, userID i
 		log.Println("Error while creating feedback branch", err)
 		return
 	}
+	ctrl.publishProjectStep(assignmentProject, events.FeedbackBranchCreatedStep)
 	// We don't need to clean up this step because the project will be deleted
 
 	queryUsers := query.User
@@ -182,6 +238,7 @@ func (ctrl *DefaultController) acceptAssignment(repo gitlab.Repository, userID i
 		log.Println("Error while creating merge request", err)
 		return
 	}
+	ctrl.publishProjectStep(assignmentProject, events.MergeRequestCreatedStep)
 	// We don't need to clean up this step because the project will be deleted
 
 	// In a few cases the main branch isn't available directly after the creation, this would cause an error when setting up protection rules for it, there we wait for the default branch to exist
@@ -211,15 +268,46 @@ func (ctrl *DefaultController) acceptAssignment(repo gitlab.Repository, userID i
 		log.Println("Error while protecting feedback branch", err)
 		return
 	}
+	ctrl.publishProjectStep(assignmentProject, events.BranchesProtectedStep)
 	// We don't need to clean up this step because the project will be deleted
 
 	assignmentProject.ProjectID = project.ID
 	assignmentProject.ProjectStatus = database.Accepted
 
-	if err = queryAssignmentProjects.WithContext(ctx).Save(assignmentProject); err != nil {
+	if err = saveProjectStatus(ctx, assignmentProject, database.ProjectAcceptedEvent); err != nil {
 		log.Println("Error while setting Project to Accepted", err)
 		return
 	}
+	ctrl.publishProjectStep(assignmentProject, events.AcceptedStep)
+}
+
+// publishProjectStep streams the progress of the project creation to the classroom and the members of the team.
+func (ctrl *DefaultController) publishProjectStep(assignmentProject *database.AssignmentProjects, step events.ProjectStep) {
+	ctrl.broker.Publish(events.Event{
+		Type:        events.ProjectCreationEvent,
+		ClassroomID: assignmentProject.Assignment.ClassroomID,
+		UserIDs: utils.Map(assignmentProject.Team.Member, func(member *database.UserClassrooms) int {
+			return member.UserID
+		}),
+		Data: events.ProjectCreationData{
+			ProjectID:    assignmentProject.ID,
+			AssignmentID: assignmentProject.AssignmentID,
+			TeamID:       assignmentProject.TeamID,
+			Step:         step,
+		},
+	})
+}
+
+// saveProjectStatus saves the project and emits the event of its new status to the webhooks of the classroom.
+func saveProjectStatus(ctx context.Context, assignmentProject *database.AssignmentProjects, event database.WebhookEvent) error {
+	return query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.AssignmentProjects.WithContext(ctx).Save(assignmentProject); err != nil {
+			return err
+		}
+
+		return webhook.NewOutbox(ctx, tx, assignmentProject.Assignment.ClassroomID).
+			Emit(event, webhook.NewProjectData(assignmentProject))
+	})
 }
 
 func waitForDefaultBranch(ctx context.Context, repo gitlab.Repository, projectID int, defaultBranch string) error {
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestSelectTemplateProjectID(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	teams := make([]*database.Team, 3)
+	for i := range teams {
+		student := factory.User()
+		teams[i] = factory.Team(classroom.ID, []*database.UserClassrooms{
+			factory.UserClassroom(student.ID, classroom.ID, database.Student),
+		})
+	}
+
+	newAssignment := func(distribution database.TemplateDistribution, templateProjectIDs ...int) *database.Assignment {
+		assignment := factory.Assignment(classroom.ID, nil, false)
+		assignment.TemplateDistribution = distribution
+		if err := query.Assignment.WithContext(context.Background()).Save(assignment); err != nil {
+			t.Fatal(err)
+		}
+		for i, templateProjectID := range templateProjectIDs {
+			err := query.AssignmentTemplateVariant.WithContext(context.Background()).Create(&database.AssignmentTemplateVariant{
+				AssignmentID:      assignment.ID,
+				TemplateProjectID: templateProjectID,
+				Position:          i,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		return assignment
+	}
+
+	withoutVariants := newAssignment(database.RoundRobinDistribution)
+	roundRobin := newAssignment(database.RoundRobinDistribution, 101, 102)
+	random := newAssignment(database.RandomDistribution, 201, 202)
+
+	tests := []struct {
+		name       string
+		assignment *database.Assignment
+		team       *database.Team
+		want       []int
+	}{
+		{name: "uses the template of the assignment without variants", assignment: withoutVariants, team: teams[0], want: []int{1234}},
+		{name: "round robin assigns the first variant to the first team", assignment: roundRobin, team: teams[0], want: []int{101}},
+		{name: "round robin assigns the second variant to the second team", assignment: roundRobin, team: teams[1], want: []int{102}},
+		{name: "round robin starts over with the third team", assignment: roundRobin, team: teams[2], want: []int{101}},
+		{name: "random assigns one of the variants", assignment: random, team: teams[0], want: []int{201, 202}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			project := &database.AssignmentProjects{
+				AssignmentID: test.assignment.ID,
+				Assignment:   *test.assignment,
+				TeamID:       test.team.ID,
+				Team:         *test.team,
+			}
+
+			seen := map[int]bool{}
+			for range 100 {
+				templateProjectID, err := selectTemplateProjectID(context.Background(), project)
+				assert.NoError(t, err)
+				seen[templateProjectID] = true
+			}
+
+			assert.Len(t, seen, len(test.want))
+			for _, want := range test.want {
+				assert.True(t, seen[want], "template project %d not selected", want)
+			}
+		})
+	}
+}
+
+func TestAcceptAssignment(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	student := factory.User()
+	team := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(student.ID, classroom.ID, database.Student),
+	})
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	assignment.TemplateDistribution = database.RoundRobinDistribution
+	if err := query.Assignment.WithContext(context.Background()).Save(assignment); err != nil {
+		t.Fatal(err)
+	}
+	err := query.AssignmentTemplateVariant.WithContext(context.Background()).Create(
+		&database.AssignmentTemplateVariant{AssignmentID: assignment.ID, TemplateProjectID: 101, Position: 0},
+		&database.AssignmentTemplateVariant{AssignmentID: assignment.ID, TemplateProjectID: 102, Position: 1},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project := factory.AssignmentProject(assignment.ID, team.ID)
+	project.ProjectStatus = database.Pending
+	if err := query.AssignmentProjects.WithContext(context.Background()).Save(project); err != nil {
+		t.Fatal(err)
+	}
+
+	app, gitlabRepo, _ := setupApp(t, student)
+	route := fmt.Sprintf("/api/v1/classrooms/%s/projects/%s/accept", classroom.ID.String(), project.ID.String())
+
+	t.Run("records the selected template variant", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GroupAccessLogin(classroom.GroupAccessToken).
+			Return(nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectById(101).
+			Return(&model.Project{ID: 101, DefaultBranch: "main"}, nil).
+			Once()
+		// The fork fails, so the project creation stops right after the template variant is recorded
+		gitlabRepo.EXPECT().
+			ForkProjectWithOnlyDefaultBranch(101, model.Private, team.GroupID, assignment.Name, assignment.Description).
+			Return(nil, errors.New("fork failed")).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("POST", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		var acceptedProject *database.AssignmentProjects
+		assert.Eventually(t, func() bool {
+			acceptedProject, err = query.AssignmentProjects.
+				WithContext(context.Background()).
+				Where(query.AssignmentProjects.ID.Eq(project.ID)).
+				First()
+			return err == nil && acceptedProject.ProjectStatus == database.Failed
+		}, 5*time.Second, 50*time.Millisecond)
+
+		assert.Equal(t, 101, *acceptedProject.TemplateProjectID)
+		gitlabRepo.AssertExpectations(t)
+	})
+
+	t.Run("keeps the template variant of a failed attempt", func(t *testing.T) {
+		_, err := query.AssignmentProjects.
+			WithContext(context.Background()).
+			Where(query.AssignmentProjects.ID.Eq(project.ID)).
+			Update(query.AssignmentProjects.TemplateProjectID, 102)
+		assert.NoError(t, err)
+
+		gitlabRepo.EXPECT().
+			GroupAccessLogin(classroom.GroupAccessToken).
+			Return(nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectById(102).
+			Return(&model.Project{ID: 102, DefaultBranch: "main"}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			ForkProjectWithOnlyDefaultBranch(102, model.Private, team.GroupID, mock.Anything, mock.Anything).
+			Return(nil, errors.New("fork failed")).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("POST", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		var acceptedProject *database.AssignmentProjects
+		assert.Eventually(t, func() bool {
+			acceptedProject, err = query.AssignmentProjects.
+				WithContext(context.Background()).
+				Where(query.AssignmentProjects.ID.Eq(project.ID)).
+				First()
+			return err == nil && acceptedProject.ProjectStatus == database.Failed
+		}, 5*time.Second, 50*time.Millisecond)
+
+		assert.Equal(t, 102, *acceptedProject.TemplateProjectID)
+		gitlabRepo.AssertExpectations(t)
+	})
+}
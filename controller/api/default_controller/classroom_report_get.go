@@ -61,6 +61,7 @@ func assignmentGradingQuery(c *fiber.Ctx, classroomID uuid.UUID) query.IAssignme
 		Preload(queryAssignment.Projects).
 		Preload(queryAssignment.GradingManualRubrics).
 		Preload(queryAssignment.JUnitTests).
+		Preload(queryAssignment.TemplateVariants.Order(query.AssignmentTemplateVariant.Position)).
 		Preload(queryAssignment.Projects.Team).
 		Preload(queryAssignment.Projects.Team.Member).
 		Preload(field.NewRelation("Projects.Team.Member.User", "")).
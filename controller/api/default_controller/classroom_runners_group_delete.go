@@ -0,0 +1,37 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		DeleteClassroomGroupRunner
+// @Description	Delete a runner of the classroom group. The runner is unregistered on its next contact with GitLab.
+// @Id				DeleteClassroomGroupRunner
+// @Tags			runners
+// @Param			classroomId		path	string	true	"Classroom ID"	Format(uuid)
+// @Param			runnerId		path	int		true	"Runner ID"
+// @Param			X-Csrf-Token	header	string	true	"Csrf-Token"
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/runners/group/{runnerId} [delete]
+func (ctrl *DefaultController) DeleteClassroomGroupRunner(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+	classroom := ctx.GetUserClassroom()
+
+	runnerId, err := classroomGroupRunnerID(c, repo, classroom.Classroom.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err = repo.DeleteRunner(runnerId); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
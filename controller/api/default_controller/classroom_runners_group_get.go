@@ -0,0 +1,59 @@
+package api
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		GetClassroomGroupRunners
+// @Description	Get the runners registered for the group of the classroom.
+// @Id				GetClassroomGroupRunners
+// @Tags			runners
+// @Produce		json
+// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Success		200			{array}		model.RunnerDetails
+// @Failure		400			{object}	HTTPError
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/runners/group [get]
+func (ctrl *DefaultController) GetClassroomGroupRunners(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+	classroom := ctx.GetUserClassroom()
+
+	runners, err := repo.GetRunnersOfGroup(classroom.Classroom.GroupID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(runners)
+}
+
+// classroomGroupRunnerID returns the runner id of the route, if the runner is registered for the group of the classroom.
+func classroomGroupRunnerID(c *fiber.Ctx, repo gitlab.Repository, groupId int) (int, error) {
+	var params Params
+	if err := c.ParamsParser(&params); err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if params.RunnerID == nil {
+		return 0, fiber.ErrBadRequest
+	}
+
+	runners, err := repo.GetRunnersOfGroup(groupId)
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if !slices.ContainsFunc(runners, func(runner *model.RunnerDetails) bool { return runner.ID == *params.RunnerID }) {
+		return 0, fiber.NewError(fiber.StatusNotFound, "Runner is not registered for the classroom")
+	}
+
+	return *params.RunnerID, nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type updateGroupRunnerRequest struct {
+	Paused *bool `json:"paused"`
+} //@Name UpdateGroupRunnerRequest
+
+func (r updateGroupRunnerRequest) isValid() bool {
+	return r.Paused != nil
+}
+
+// @Summary		UpdateClassroomGroupRunner
+// @Description	Pause or resume a runner of the classroom group.
+// @Id				UpdateClassroomGroupRunner
+// @Tags			runners
+// @Accept			json
+// @Param			classroomId		path	string							true	"Classroom ID"	Format(uuid)
+// @Param			runnerId		path	int								true	"Runner ID"
+// @Param			runner			body	api.updateGroupRunnerRequest	true	"Runner Update"
+// @Param			X-Csrf-Token	header	string							true	"Csrf-Token"
+// @Success		202
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/runners/group/{runnerId} [patch]
+func (ctrl *DefaultController) UpdateClassroomGroupRunner(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+	classroom := ctx.GetUserClassroom()
+
+	var requestBody updateGroupRunnerRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Request Body is not valid")
+	}
+
+	runnerId, err := classroomGroupRunnerID(c, repo, classroom.Classroom.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err = repo.PauseRunner(runnerId, *requestBody.Paused); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
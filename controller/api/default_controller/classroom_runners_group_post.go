@@ -0,0 +1,78 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// defaultRunnerImage is the docker image of jobs which do not specify one.
+const defaultRunnerImage = "alpine:latest"
+
+type createGroupRunnerRequest struct {
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	RunUntagged bool     `json:"runUntagged"`
+} //@Name CreateGroupRunnerRequest
+
+func (r createGroupRunnerRequest) isValid() bool {
+	return r.Description != "" && (r.RunUntagged || len(r.Tags) > 0)
+}
+
+type GroupRunnerRegistrationResponse struct {
+	ID             int        `json:"id"`
+	Token          string     `json:"token"`
+	TokenExpiresAt *time.Time `json:"tokenExpiresAt"`
+	// RegisterCommand registers a docker runner with the token on the machine it is run on
+	RegisterCommand string `json:"registerCommand"`
+} //@Name GroupRunnerRegistrationResponse
+
+// @Summary		CreateClassroomGroupRunner
+// @Description	Create a runner for the group of the classroom. The response contains the authentication token and the command to register the runner, the token is only returned once.
+// @Id				CreateClassroomGroupRunner
+// @Tags			runners
+// @Accept			json
+// @Produce		json
+// @Param			classroomId		path		string							true	"Classroom ID"	Format(uuid)
+// @Param			runner			body		api.createGroupRunnerRequest	true	"Runner Info"
+// @Param			X-Csrf-Token	header		string							true	"Csrf-Token"
+// @Success		201				{object}	api.GroupRunnerRegistrationResponse
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/runners/group [post]
+func (ctrl *DefaultController) CreateClassroomGroupRunner(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+	classroom := ctx.GetUserClassroom()
+
+	var requestBody createGroupRunnerRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Request Body is not valid")
+	}
+
+	registration, err := repo.CreateGroupRunner(classroom.Classroom.GroupID, requestBody.Description, requestBody.Tags, requestBody.RunUntagged)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	// Description, tags and run untagged belong to the runner in GitLab, they must not be passed on registration
+	command := fmt.Sprintf("gitlab-runner register --non-interactive --url %q --token %q --executor docker --docker-image %s",
+		ctrl.config.GitLab.GetURL(), registration.Token, defaultRunnerImage)
+
+	c.Set("Location", fmt.Sprintf("/api/v1/classrooms/%s/runners/group/%d", classroom.ClassroomID.String(), registration.ID))
+	return c.Status(fiber.StatusCreated).JSON(GroupRunnerRegistrationResponse{
+		ID:              registration.ID,
+		Token:           registration.Token,
+		TokenExpiresAt:  registration.TokenExpiresAt,
+		RegisterCommand: command,
+	})
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestClassroomGroupRunners(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	targetRoute := fmt.Sprintf("/api/v1/classrooms/%s/runners/group", classroom.ID.String())
+
+	gitlabRepo.EXPECT().
+		GetRunnersOfGroup(classroom.GroupID).
+		Return([]*model.RunnerDetails{{ID: 3, Description: "Lab runner"}}, nil).
+		Maybe()
+
+	t.Run("lists runners of the group", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", targetRoute, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var runners []*model.RunnerDetails
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&runners))
+		assert.Len(t, runners, 1)
+		assert.Equal(t, 3, runners[0].ID)
+	})
+
+	t.Run("rejects runners without tags which do not run untagged jobs", func(t *testing.T) {
+		resp, err := app.Test(newPostJsonRequest(targetRoute, createGroupRunnerRequest{Description: "Lab runner"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("creates runner with register command", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			CreateGroupRunner(classroom.GroupID, "Lab runner", []string{"docker"}, false).
+			Return(&model.RunnerRegistration{ID: 4, Token: "glrt-secret"}, nil).
+			Once()
+
+		resp, err := app.Test(newPostJsonRequest(targetRoute, createGroupRunnerRequest{Description: "Lab runner", Tags: []string{"docker"}}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		assert.Equal(t, targetRoute+"/4", resp.Header.Get("Location"))
+
+		var registration GroupRunnerRegistrationResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&registration))
+		assert.Equal(t, "glrt-secret", registration.Token)
+		assert.Contains(t, registration.RegisterCommand, `--url "`+testGitlabUrl+`"`)
+		assert.Contains(t, registration.RegisterCommand, `--token "glrt-secret"`)
+	})
+
+	t.Run("pauses runner of the group", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			PauseRunner(3, true).
+			Return(nil).
+			Once()
+
+		paused := true
+		resp, err := app.Test(newJsonRequest(targetRoute+"/3", updateGroupRunnerRequest{Paused: &paused}, "PATCH"))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	})
+
+	t.Run("deletes runner of the group", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			DeleteRunner(3).
+			Return(nil).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("DELETE", targetRoute+"/3", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("rejects runners of other groups", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("DELETE", targetRoute+"/5", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
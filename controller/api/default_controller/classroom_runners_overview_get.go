@@ -0,0 +1,207 @@
+package api
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"golang.org/x/sync/errgroup"
+)
+
+type RunnerTagWarning struct {
+	AssignmentID      uuid.UUID `json:"assignmentId"`
+	AssignmentName    string    `json:"assignmentName"`
+	TemplateProjectID int       `json:"templateProjectId"`
+	Job               string    `json:"job"`
+	Tags              []string  `json:"tags"`
+} //@Name RunnerTagWarning
+
+type RunnerProjectError struct {
+	AssignmentID uuid.UUID `json:"assignmentId"`
+	ProjectID    uuid.UUID `json:"projectId"`
+	Error        string    `json:"error"`
+} //@Name RunnerProjectError
+
+type RunnerOverviewResponse struct {
+	Runners        []*model.RunnerDetails `json:"runners"`
+	OnlineRunners  int                    `json:"onlineRunners"`
+	OfflineRunners int                    `json:"offlineRunners"`
+	RunningJobs    int                    `json:"runningJobs"`
+	PendingJobs    int                    `json:"pendingJobs"`
+	// AverageQueueSeconds is the average time the running and pending jobs waited for a runner
+	AverageQueueSeconds float64 `json:"averageQueueSeconds"`
+	// Warnings lists the jobs of the assignment templates which no online runner picks up
+	Warnings []*RunnerTagWarning `json:"warnings"`
+	// Errors lists the projects whose jobs could not be fetched from GitLab, their jobs are not counted
+	Errors []*RunnerProjectError `json:"errors"`
+} //@Name RunnerOverviewResponse
+
+// @Summary		GetClassroomRunnerOverview
+// @Description	Get the runners of the classroom with their tags, the running and pending jobs of the accepted projects and warnings for jobs of the assignment templates no runner serves.
+// @Id				GetClassroomRunnerOverview
+// @Tags			runners
+// @Produce		json
+// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Success		200			{object}	api.RunnerOverviewResponse
+// @Failure		400			{object}	HTTPError
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/runners/overview [get]
+func (ctrl *DefaultController) GetClassroomRunnerOverview(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+	classroom := ctx.GetUserClassroom()
+
+	runners, err := repo.GetRunnerDetailsForGroup(classroom.Classroom.GroupID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	response := RunnerOverviewResponse{Runners: runners, Warnings: []*RunnerTagWarning{}, Errors: []*RunnerProjectError{}}
+	for _, runner := range runners {
+		if runnerIsAvailable(runner) {
+			response.OnlineRunners++
+		} else {
+			response.OfflineRunners++
+		}
+	}
+
+	queryAssignment := query.Assignment
+	assignments, err := queryAssignment.
+		WithContext(c.Context()).
+		Preload(queryAssignment.Projects.On(query.AssignmentProjects.ProjectStatus.Eq(string(database.Accepted)))).
+		Preload(queryAssignment.TemplateVariants.Order(query.AssignmentTemplateVariant.Position)).
+		Where(queryAssignment.ClassroomID.Eq(classroom.ClassroomID)).
+		Where(queryAssignment.Closed.Is(false)).
+		Order(queryAssignment.Name).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	var mu sync.Mutex
+	var queueSeconds float64
+
+	var eg errgroup.Group
+	eg.SetLimit(pipelineConcurrency)
+	for _, assignment := range assignments {
+		for _, project := range assignment.Projects {
+			eg.Go(func() error {
+				jobs, err := repo.GetProjectJobs(project.ProjectID, "running", "pending")
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					response.Errors = append(response.Errors, &RunnerProjectError{
+						AssignmentID: assignment.ID,
+						ProjectID:    project.ID,
+						Error:        err.Error(),
+					})
+					return nil
+				}
+				for _, job := range jobs {
+					if job.Status == "running" {
+						response.RunningJobs++
+					} else {
+						response.PendingJobs++
+					}
+					queueSeconds += job.QueuedDuration
+				}
+				return nil
+			})
+		}
+	}
+	_ = eg.Wait()
+	slices.SortFunc(response.Errors, func(a, b *RunnerProjectError) int {
+		return cmp.Compare(a.ProjectID.String(), b.ProjectID.String())
+	})
+
+	if jobs := response.RunningJobs + response.PendingJobs; jobs > 0 {
+		response.AverageQueueSeconds = queueSeconds / float64(jobs)
+	}
+
+	for _, assignment := range assignments {
+		for _, templateProjectID := range assignmentTemplateProjectIDs(assignment) {
+			warnings, err := unservedTemplateJobs(repo, assignment, templateProjectID, runners)
+			if err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+			response.Warnings = append(response.Warnings, warnings...)
+		}
+	}
+
+	return c.JSON(response)
+}
+
+// runnerIsAvailable reports whether the runner picks up jobs.
+func runnerIsAvailable(runner *model.RunnerDetails) bool {
+	return runner.Online && !runner.Paused
+}
+
+// runnerServes reports whether the runner picks up jobs with the given tags.
+func runnerServes(runner *model.RunnerDetails, tags []string) bool {
+	if !runnerIsAvailable(runner) {
+		return false
+	}
+	if len(tags) == 0 {
+		return runner.RunUntagged
+	}
+	return utils.All(tags, func(tag string) bool { return slices.Contains(runner.TagList, tag) })
+}
+
+// assignmentTemplateProjectIDs returns the templates the projects of the assignment are created from.
+// These are the template variants if the assignment has any, otherwise its template.
+func assignmentTemplateProjectIDs(assignment *database.Assignment) []int {
+	if len(assignment.TemplateVariants) == 0 {
+		return []int{assignment.TemplateProjectID}
+	}
+	return utils.Map(assignment.TemplateVariants, func(variant *database.AssignmentTemplateVariant) int {
+		return variant.TemplateProjectID
+	})
+}
+
+// unservedTemplateJobs lists the jobs in the .gitlab-ci.yml of the template of the assignment no runner serves.
+// Templates without or with an invalid .gitlab-ci.yml have no jobs.
+func unservedTemplateJobs(repo gitlab.Repository, assignment *database.Assignment, templateProjectID int, runners []*model.RunnerDetails) ([]*RunnerTagWarning, error) {
+	file, err := repo.GetRepositoryFile(templateProjectID, ".gitlab-ci.yml", "HEAD")
+	if err != nil {
+		var gitlabError *model.GitLabError
+		if errors.As(err, &gitlabError) && gitlabError.Response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	jobs, err := utils.GitlabCIJobTags([]byte(file.Content))
+	if err != nil {
+		return nil, nil
+	}
+
+	var warnings []*RunnerTagWarning
+	for job, tags := range jobs {
+		if !slices.ContainsFunc(runners, func(runner *model.RunnerDetails) bool { return runnerServes(runner, tags) }) {
+			warnings = append(warnings, &RunnerTagWarning{
+				AssignmentID:      assignment.ID,
+				AssignmentName:    assignment.Name,
+				TemplateProjectID: templateProjectID,
+				Job:               job,
+				Tags:              tags,
+			})
+		}
+	}
+	slices.SortFunc(warnings, func(a, b *RunnerTagWarning) int { return cmp.Compare(a.Job, b.Job) })
+
+	return warnings, nil
+}
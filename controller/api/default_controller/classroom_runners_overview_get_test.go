@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestGetClassroomRunnerOverview(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	student := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	closedAssignment := factory.Assignment(classroom.ID, &dueDate, false)
+	closedAssignment.Closed = true
+	if err := query.Assignment.WithContext(context.Background()).Save(closedAssignment); err != nil {
+		t.Fatal(err)
+	}
+
+	team := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(student.ID, classroom.ID, database.Student),
+	})
+	project := factory.AssignmentProject(assignment.ID, team.ID)
+	factory.AssignmentProject(closedAssignment.ID, team.ID)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	route := fmt.Sprintf("/api/v1/classrooms/%s/runners/overview", classroom.ID.String())
+
+	t.Run("returns runners, jobs and warnings", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetRunnerDetailsForGroup(classroom.GroupID).
+			Return([]*model.RunnerDetails{
+				{ID: 1, Online: true, TagList: []string{"docker"}, RunUntagged: true},
+				{ID: 2, Online: false, TagList: []string{"gpu"}},
+			}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectJobs(project.ProjectID, "running", "pending").
+			Return([]*model.Job{
+				{ID: 1, Status: "running", QueuedDuration: 10},
+				{ID: 2, Status: "pending", QueuedDuration: 30},
+			}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetRepositoryFile(assignment.TemplateProjectID, ".gitlab-ci.yml", "HEAD").
+			Return(&model.File{Content: "build:\n  script: make\ntrain:\n  tags: [gpu]\n  script: make train\n"}, nil).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var overview RunnerOverviewResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&overview))
+		assert.Len(t, overview.Runners, 2)
+		assert.Equal(t, 1, overview.OnlineRunners)
+		assert.Equal(t, 1, overview.OfflineRunners)
+		assert.Equal(t, 1, overview.RunningJobs)
+		assert.Equal(t, 1, overview.PendingJobs)
+		assert.Equal(t, 20.0, overview.AverageQueueSeconds)
+
+		assert.Len(t, overview.Warnings, 1)
+		assert.Equal(t, assignment.ID, overview.Warnings[0].AssignmentID)
+		assert.Equal(t, "train", overview.Warnings[0].Job)
+		assert.Equal(t, []string{"gpu"}, overview.Warnings[0].Tags)
+	})
+
+	t.Run("ignores templates without pipeline", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetRunnerDetailsForGroup(classroom.GroupID).
+			Return([]*model.RunnerDetails{}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectJobs(project.ProjectID, "running", "pending").
+			Return([]*model.Job{}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetRepositoryFile(assignment.TemplateProjectID, ".gitlab-ci.yml", "HEAD").
+			Return(nil, &model.GitLabError{
+				Response: &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{Method: "GET", URL: &url.URL{}}},
+				Message:  "404 File Not Found",
+			}).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var overview RunnerOverviewResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&overview))
+		assert.Empty(t, overview.Warnings)
+		assert.Equal(t, 0.0, overview.AverageQueueSeconds)
+	})
+
+	t.Run("records projects whose jobs could not be fetched", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetRunnerDetailsForGroup(classroom.GroupID).
+			Return([]*model.RunnerDetails{}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectJobs(project.ProjectID, "running", "pending").
+			Return(nil, fmt.Errorf("unavailable")).
+			Once()
+		gitlabRepo.EXPECT().
+			GetRepositoryFile(assignment.TemplateProjectID, ".gitlab-ci.yml", "HEAD").
+			Return(&model.File{Content: "build:\n  script: make\n"}, nil).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var overview RunnerOverviewResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&overview))
+		assert.Equal(t, 0, overview.RunningJobs)
+		assert.Len(t, overview.Errors, 1)
+		assert.Equal(t, assignment.ID, overview.Errors[0].AssignmentID)
+		assert.Equal(t, project.ID, overview.Errors[0].ProjectID)
+		assert.Equal(t, "unavailable", overview.Errors[0].Error)
+	})
+
+	t.Run("warns for the jobs of every template variant", func(t *testing.T) {
+		err := query.AssignmentTemplateVariant.WithContext(context.Background()).Create(
+			&database.AssignmentTemplateVariant{AssignmentID: assignment.ID, TemplateProjectID: 101, Position: 0},
+			&database.AssignmentTemplateVariant{AssignmentID: assignment.ID, TemplateProjectID: 102, Position: 1},
+		)
+		assert.NoError(t, err)
+
+		gitlabRepo.EXPECT().
+			GetRunnerDetailsForGroup(classroom.GroupID).
+			Return([]*model.RunnerDetails{{ID: 1, Online: true, TagList: []string{"docker"}, RunUntagged: true}}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetProjectJobs(project.ProjectID, "running", "pending").
+			Return([]*model.Job{}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetRepositoryFile(101, ".gitlab-ci.yml", "HEAD").
+			Return(&model.File{Content: "build:\n  script: make\n"}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			GetRepositoryFile(102, ".gitlab-ci.yml", "HEAD").
+			Return(&model.File{Content: "train:\n  tags: [gpu]\n  script: make train\n"}, nil).
+			Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var overview RunnerOverviewResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&overview))
+		assert.Len(t, overview.Warnings, 1)
+		assert.Equal(t, 102, overview.Warnings[0].TemplateProjectID)
+		assert.Equal(t, "train", overview.Warnings[0].Job)
+		gitlabRepo.AssertExpectations(t)
+	})
+}
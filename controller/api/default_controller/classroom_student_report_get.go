@@ -0,0 +1,95 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type reportFormat string //@Name ReportFormat
+
+const (
+	jsonReport reportFormat = "json"
+	htmlReport reportFormat = "html"
+	pdfReport  reportFormat = "pdf"
+)
+
+type getStudentReportQuery struct {
+	Format reportFormat `query:"format"`
+}
+
+func (q *getStudentReportQuery) isValid() bool {
+	if q.Format == "" {
+		q.Format = jsonReport
+	}
+	return q.Format == jsonReport || q.Format == htmlReport || q.Format == pdfReport
+}
+
+// @Summary		GetStudentReport
+// @Description	Get the grades and feedback of the own team across all assignments of the classroom, including the results of every autograding test. The report can be downloaded as HTML or PDF summary.
+// @Id				GetStudentReport
+// @Tags			report
+// @Produce		json
+// @Produce		text/html
+// @Produce		application/pdf
+// @Param			classroomId	path		string				true	"Classroom ID"	Format(uuid)
+// @Param			format		query		api.reportFormat	false	"Format of the report (default: json)"
+// @Success		200			{array}		utils.ReportDataItem
+// @Success		200			{file}		application/pdf
+// @Failure		400			{object}	HTTPError
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/report [get]
+func (ctrl *DefaultController) GetStudentReport(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	classroom := ctx.GetUserClassroom()
+
+	urlQuery := new(getStudentReportQuery)
+	if err = c.QueryParser(urlQuery); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !urlQuery.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	if classroom.TeamID == nil {
+		return fiber.NewError(fiber.StatusNotFound, "You are not a member of a team")
+	}
+
+	assignments, err := assignmentGradingQuery(c, classroom.ClassroomID).
+		Join(query.AssignmentProjects, query.AssignmentProjects.AssignmentID.EqCol(query.Assignment.ID)).
+		Where(query.AssignmentProjects.TeamID.Eq(*classroom.TeamID)).
+		Order(query.Assignment.CreatedAt).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	reports, err := utils.GenerateReports(assignments, classroom.Classroom.ManualGradingRubrics, classroom.TeamID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	title := fmt.Sprintf("%s - %s", classroom.Classroom.Name, classroom.Team.Name)
+	filename := fmt.Sprintf("report_%s_%s_%s", time.Now().Format(time.DateOnly), classroom.Classroom.Name, classroom.Team.Name)
+
+	switch urlQuery.Format {
+	case htmlReport:
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s.html", filename))
+		return utils.GenerateHTMLReport(c.Response().BodyWriter(), title, reports)
+	case pdfReport:
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s.pdf", filename))
+		return utils.GeneratePDFReport(c.Response().BodyWriter(), title, reports)
+	default:
+		return c.JSON(reports)
+	}
+}
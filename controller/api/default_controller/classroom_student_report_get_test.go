@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStudentReport(t *testing.T) {
+	restoreDatabase(t)
+
+	db, err := gorm.Open(postgres.Open(integrationTest.dbURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query.SetDefault(db)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	member := factory.User()
+	otherMember := factory.User()
+
+	dueDate := time.Now().Add(1 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	team := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(member.ID, classroom.ID, database.Student),
+	})
+	otherTeam := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(otherMember.ID, classroom.ID, database.Student),
+	})
+	assignmentProject := factory.AssignmentProject(assignment.ID, team.ID)
+	factory.AssignmentProject(assignment.ID, otherTeam.ID)
+
+	// ------------ END OF SEEDING DATA -----------------
+
+	app, _, _ := setupApp(t, member)
+	route := fmt.Sprintf("/api/v1/classrooms/%s/report", classroom.ID.String())
+
+	t.Run("returns report of own team", func(t *testing.T) {
+		req := httptest.NewRequest("GET", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var reports [][]*utils.ReportDataItem
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&reports))
+		assert.Len(t, reports, 1)
+		assert.Len(t, reports[0], 1)
+		assert.Equal(t, assignmentProject.ID, reports[0][0].ProjectID)
+		assert.Equal(t, member.GitlabEmail, reports[0][0].Email)
+	})
+
+	t.Run("returns html summary", func(t *testing.T) {
+		req := httptest.NewRequest("GET", route+"?format=html", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.True(t, strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), fiber.MIMETextHTML))
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), assignment.Name)
+	})
+
+	t.Run("returns pdf summary", func(t *testing.T) {
+		req := httptest.NewRequest("GET", route+"?format=pdf", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/pdf", resp.Header.Get(fiber.HeaderContentType))
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(body), "%PDF"))
+	})
+
+	t.Run("rejects unknown format", func(t *testing.T) {
+		req := httptest.NewRequest("GET", route+"?format=docx", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"fmt"
+
 	"github.com/gofiber/fiber/v2"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	mailRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
 
@@ -62,7 +65,15 @@ func (ctrl *DefaultController) RemoveMemberFromTeam(c *fiber.Ctx) (err error) {
 			}
 		}
 
-		return nil
+		return mailRepo.NewOutboxRepository(c.Context(), tx, &member.ClassroomID).SendTeamChangeNotification(
+			member.User.GitlabEmail,
+			fmt.Sprintf(`You have been removed from your team in Classroom "%s"`, classroom.Classroom.Name),
+			mailRepo.TeamChangeData{
+				ClassroomName: classroom.Classroom.Name,
+				RecipientName: member.User.Name,
+				Path:          fmt.Sprintf("/classrooms/%s", member.ClassroomID.String()),
+			},
+		)
 	})
 
 	if err != nil {
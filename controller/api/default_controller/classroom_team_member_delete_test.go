@@ -79,5 +79,13 @@ func TestRemoveMemberFromTeam(t *testing.T) {
 
 		assert.Equal(t, updatedUserClassoom.UserID, removeMember.ID)
 		assert.Nil(t, updatedUserClassoom.TeamID)
+
+		outgoingMail, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.Recipient.Eq(removeMember.GitlabEmail)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.TeamChangeMail, outgoingMail.Kind)
+		assert.Equal(t, fmt.Sprintf(`You have been removed from your team in Classroom "%s"`, classroom.Name), outgoingMail.Subject)
 	})
 }
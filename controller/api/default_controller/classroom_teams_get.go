@@ -5,8 +5,10 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gorm.io/gen/field"
 )
 
 // @Summary		GetClassroomTeams
@@ -15,7 +17,13 @@ import (
 // @Tags			team
 // @Produce		json
 // @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Param			search		query		string	false	"Search by name"
+// @Param			sort		query		string	false	"Sort by name or createdAt, prefixed with - for descending order"	Enums(name, -name, createdAt, -createdAt)
+// @Param			page		query		int		false	"Page, all teams are returned if neither page nor perPage is set"
+// @Param			perPage		query		int		false	"Page size (default: 20, max: 100)"
 // @Success		200			{array}		api.TeamResponse
+// @Header			200			{string}	Link	"Links to the first, previous, next and last page"
+// @Header			200			{int}		X-Total	"Total number of teams"
 // @Failure		400			{object}	HTTPError
 // @Failure		401			{object}	HTTPError
 // @Failure		404			{object}	HTTPError
@@ -25,8 +33,26 @@ func (ctrl *DefaultController) GetClassroomTeams(c *fiber.Ctx) (err error) {
 	ctx := context.Get(c)
 	classroom := ctx.GetUserClassroom()
 
-	teams, err := classroomTeamQuery(c, classroom.ClassroomID).
-		Find()
+	var params listQuery
+	if err = c.QueryParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	queryTeam := query.Team
+	dbQuery := classroomTeamQuery(c, classroom.ClassroomID)
+	if params.Search != "" {
+		dbQuery = dbQuery.Where(containsIgnoreCase(params.Search, queryTeam.Name))
+	}
+
+	order, ok := params.orderBy(map[string]field.OrderExpr{
+		"name":      queryTeam.Name,
+		"createdAt": queryTeam.CreatedAt,
+	}, queryTeam.Name)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Sort field is not valid")
+	}
+
+	teams, err := findPage(c, dbQuery.Order(order...), params.paginationQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
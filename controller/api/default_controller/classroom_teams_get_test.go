@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http/httptest"
@@ -63,4 +64,48 @@ func TestGetClassroomTeams(t *testing.T) {
 		assert.Len(t, teamResponse, 1)
 		assert.Equal(t, teamResponse[0].ID, team.ID)
 	})
+
+	t.Run("TestGetClassroomTeams paginated, sorted and searched", func(t *testing.T) {
+		team.Name = "Alpha"
+		if err := query.Team.WithContext(context.Background()).Save(team); err != nil {
+			t.Fatal(err)
+		}
+		secondTeam := factory.Team(classroom.ID, []*database.UserClassrooms{})
+		secondTeam.Name = "Beta"
+		if err := query.Team.WithContext(context.Background()).Save(secondTeam); err != nil {
+			t.Fatal(err)
+		}
+
+		ownerApp, _, _ := setupApp(t, owner)
+		route := fmt.Sprintf("/api/v1/classrooms/%s/teams?sort=-name&page=1&perPage=1", classroom.ID.String())
+
+		resp, err := ownerApp.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-Total"))
+		assert.Contains(t, resp.Header.Get("Link"), `page=2&perPage=1&sort=-name>; rel="next"`)
+
+		var teamResponse []*TeamResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&teamResponse))
+		assert.Len(t, teamResponse, 1)
+		assert.Equal(t, secondTeam.ID, teamResponse[0].ID)
+
+		route = fmt.Sprintf("/api/v1/classrooms/%s/teams?search=alp", classroom.ID.String())
+		resp, err = ownerApp.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		teamResponse = nil
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&teamResponse))
+		assert.Len(t, teamResponse, 1)
+		assert.Equal(t, team.ID, teamResponse[0].ID)
+	})
+
+	t.Run("TestGetClassroomTeams rejects unknown sort field", func(t *testing.T) {
+		route := fmt.Sprintf("/api/v1/classrooms/%s/teams?sort=groupId", classroom.ID.String())
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
 }
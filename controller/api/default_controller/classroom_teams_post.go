@@ -9,6 +9,7 @@ import (
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
 )
 
@@ -127,7 +128,15 @@ func (ctrl *DefaultController) CreateTeam(c *fiber.Ctx) (err error) {
 		Member:      member,
 	}
 
-	if err = queryTeam.WithContext(c.Context()).Create(newTeam); err != nil {
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.Team.WithContext(c.Context()).Create(newTeam); err != nil {
+			return err
+		}
+
+		return webhook.NewOutbox(c.Context(), tx, classroom.ClassroomID).
+			Emit(database.TeamCreatedEvent, webhook.TeamData{TeamID: newTeam.ID, Name: newTeam.Name})
+	})
+	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+)
+
+// @Summary		GetTemplateStarterKits
+// @Description	Get the names of the starter kits a template project can be created from.
+// @Id				GetTemplateStarterKits
+// @Tags			classroom
+// @Produce		json
+// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Success		200			{array}		string
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/templateProjects/starterKits [get]
+func (ctrl *DefaultController) GetTemplateStarterKits(c *fiber.Ctx) error {
+	return c.JSON(utils.StarterKits())
+}
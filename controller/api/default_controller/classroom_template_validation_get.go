@@ -0,0 +1,166 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type TemplateIssueSeverity string //@Name TemplateIssueSeverity
+
+const (
+	// TemplateError prevents the students from accepting the assignment
+	TemplateError TemplateIssueSeverity = "error"
+	// TemplateWarning breaks features like the autograding, but the assignment can be accepted
+	TemplateWarning TemplateIssueSeverity = "warning"
+)
+
+type TemplateIssue struct {
+	Severity TemplateIssueSeverity `json:"severity"`
+	Code     string                `json:"code"`
+	Message  string                `json:"message"`
+} //@Name TemplateIssue
+
+type TemplateValidationResponse struct {
+	TemplateProjectID int                `json:"templateProjectId"`
+	Languages         map[string]float32 `json:"languages"`
+	// Valid is false if any issue is an error
+	Valid  bool             `json:"valid"`
+	Issues []*TemplateIssue `json:"issues"`
+} //@Name TemplateValidationResponse
+
+func (r *TemplateValidationResponse) add(severity TemplateIssueSeverity, code string, message string) {
+	r.Issues = append(r.Issues, &TemplateIssue{Severity: severity, Code: code, Message: message})
+	if severity == TemplateError {
+		r.Valid = false
+	}
+}
+
+// @Summary		ValidateTemplateProject
+// @Description	Validate a template project before creating an assignment with it. The checks run with the access token of the classroom, which forks the template when a team accepts the assignment.
+// @Id				ValidateTemplateProject
+// @Tags			assignment
+// @Produce		json
+// @Param			classroomId			path		string	true	"Classroom ID"	Format(uuid)
+// @Param			templateProjectId	path		int		true	"Template Project ID"
+// @Success		200					{object}	api.TemplateValidationResponse
+// @Failure		400					{object}	HTTPError
+// @Failure		401					{object}	HTTPError
+// @Failure		403					{object}	HTTPError
+// @Failure		404					{object}	HTTPError
+// @Failure		500					{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/templateProjects/{templateProjectId}/validation [get]
+func (ctrl *DefaultController) ValidateTemplateProject(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+	classroom := ctx.GetUserClassroom()
+
+	var params Params
+	if err = c.ParamsParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if params.TemplateProjectID == nil {
+		return fiber.ErrBadRequest
+	}
+
+	if err = repo.GroupAccessLogin(classroom.Classroom.GroupAccessToken); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	response, template, err := validateTemplateProject(repo, *params.TemplateProjectID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if template == nil || template.EmptyRepo {
+		return c.JSON(response)
+	}
+
+	response.Languages, err = repo.GetProjectLanguages(template.ID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if len(response.Languages) == 0 {
+		response.add(TemplateWarning, "no_languages", "GitLab detected no programming language in the template")
+	}
+
+	hasCI, err := repo.CheckIfFileExistsInProject(template.ID, ".gitlab-ci.yml")
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if !hasCI {
+		response.add(TemplateWarning, "missing_ci", "The template has no .gitlab-ci.yml, no pipeline runs for the projects of the students")
+		return c.JSON(response)
+	}
+
+	pipeline, err := latestPipeline(repo, template.ID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if pipeline == nil {
+		response.add(TemplateWarning, "no_pipeline", "No pipeline ran on the default branch of the template yet")
+		return c.JSON(response)
+	}
+	if pipeline.Status == "failed" {
+		response.add(TemplateWarning, "pipeline_failed", fmt.Sprintf("The latest pipeline of the template failed: %s", pipeline.WebURL))
+	}
+
+	report, err := repo.GetProjectPipelineTestReportSummary(template.ID, pipeline.ID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if report.TotalCount == 0 {
+		response.add(TemplateWarning, "no_test_report", "The latest pipeline of the template has no JUnit test report, the autograding has no results")
+	}
+
+	return c.JSON(response)
+}
+
+// validateTemplateProject checks if the template can be forked by the logged in repository. The template is nil if it is not accessible.
+func validateTemplateProject(repo gitlab.Repository, templateProjectID int) (*TemplateValidationResponse, *model.Project, error) {
+	response := &TemplateValidationResponse{TemplateProjectID: templateProjectID, Valid: true, Issues: []*TemplateIssue{}}
+
+	template, err := repo.GetProjectById(templateProjectID)
+	if err != nil {
+		var gitlabError *model.GitLabError
+		if errors.As(err, &gitlabError) &&
+			(gitlabError.Response.StatusCode == http.StatusForbidden || gitlabError.Response.StatusCode == http.StatusNotFound) {
+			response.add(TemplateError, "not_accessible", "The template does not exist or the classroom has no access to it")
+			return response, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	if template.Archived {
+		response.add(TemplateError, "archived", "The template is archived")
+	}
+	if !template.ForkingEnabled {
+		response.add(TemplateError, "forking_disabled", "Forking is disabled for the template")
+	}
+	if template.EmptyRepo {
+		response.add(TemplateError, "empty_repository", "The repository of the template is empty")
+	} else if template.DefaultBranch == "" {
+		response.add(TemplateError, "missing_default_branch", "The template has no default branch")
+	} else if template.DefaultBranch == feedbackBranch {
+		response.add(TemplateError, "reserved_default_branch", fmt.Sprintf("The default branch %q is reserved for the feedback of the assignment", feedbackBranch))
+	}
+
+	return response, template, nil
+}
+
+// templateErrors joins the messages of the errors of the validation.
+func templateErrors(response *TemplateValidationResponse) string {
+	var messages []string
+	for _, issue := range response.Issues {
+		if issue.Severity == TemplateError {
+			messages = append(messages, fmt.Sprintf("template %d: %s", response.TemplateProjectID, issue.Message))
+		}
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestValidateTemplateProject(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	route := func(templateProjectID int) string {
+		return fmt.Sprintf("/api/v1/classrooms/%s/templateProjects/%d/validation", classroom.ID.String(), templateProjectID)
+	}
+
+	gitlabRepo.EXPECT().
+		GroupAccessLogin(classroom.GroupAccessToken).
+		Return(nil).
+		Maybe()
+
+	validate := func(t *testing.T, templateProjectID int) TemplateValidationResponse {
+		resp, err := app.Test(httptest.NewRequest("GET", route(templateProjectID), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response TemplateValidationResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return response
+	}
+
+	codes := func(response TemplateValidationResponse) []string {
+		codes := make([]string, len(response.Issues))
+		for i, issue := range response.Issues {
+			codes[i] = issue.Code
+		}
+		return codes
+	}
+
+	t.Run("accepts complete template", func(t *testing.T) {
+		gitlabRepo.EXPECT().GetProjectById(1).Return(&model.Project{ID: 1, DefaultBranch: "main", ForkingEnabled: true}, nil).Once()
+		gitlabRepo.EXPECT().GetProjectLanguages(1).Return(map[string]float32{"Go": 100}, nil).Once()
+		gitlabRepo.EXPECT().CheckIfFileExistsInProject(1, ".gitlab-ci.yml").Return(true, nil).Once()
+		gitlabRepo.EXPECT().GetProjectLatestPipeline(1, (*string)(nil)).Return(&model.Pipeline{ID: 5, Status: "success"}, nil).Once()
+		gitlabRepo.EXPECT().GetProjectPipelineTestReportSummary(1, 5).Return(&model.TestReport{TotalCount: 3}, nil).Once()
+
+		response := validate(t, 1)
+		assert.True(t, response.Valid)
+		assert.Empty(t, response.Issues)
+		assert.Equal(t, float32(100), response.Languages["Go"])
+	})
+
+	t.Run("warns about missing test report", func(t *testing.T) {
+		gitlabRepo.EXPECT().GetProjectById(2).Return(&model.Project{ID: 2, DefaultBranch: "main", ForkingEnabled: true}, nil).Once()
+		gitlabRepo.EXPECT().GetProjectLanguages(2).Return(map[string]float32{"Java": 100}, nil).Once()
+		gitlabRepo.EXPECT().CheckIfFileExistsInProject(2, ".gitlab-ci.yml").Return(true, nil).Once()
+		gitlabRepo.EXPECT().GetProjectLatestPipeline(2, (*string)(nil)).Return(&model.Pipeline{ID: 6, Status: "failed"}, nil).Once()
+		gitlabRepo.EXPECT().GetProjectPipelineTestReportSummary(2, 6).Return(&model.TestReport{}, nil).Once()
+
+		response := validate(t, 2)
+		assert.True(t, response.Valid)
+		assert.Equal(t, []string{"pipeline_failed", "no_test_report"}, codes(response))
+	})
+
+	t.Run("reports templates which cannot be forked", func(t *testing.T) {
+		gitlabRepo.EXPECT().GetProjectById(3).Return(&model.Project{ID: 3, EmptyRepo: true}, nil).Once()
+
+		response := validate(t, 3)
+		assert.False(t, response.Valid)
+		assert.Equal(t, []string{"forking_disabled", "empty_repository"}, codes(response))
+	})
+
+	t.Run("reports templates the classroom cannot access", func(t *testing.T) {
+		gitlabRepo.EXPECT().GetProjectById(4).Return(nil, &model.GitLabError{
+			Response: &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{Method: "GET", URL: &url.URL{}}},
+			Message:  "404 Project Not Found",
+		}).Once()
+
+		response := validate(t, 4)
+		assert.False(t, response.Valid)
+		assert.Equal(t, []string{"not_accessible"}, codes(response))
+	})
+}
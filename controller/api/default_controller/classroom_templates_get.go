@@ -1,45 +1,216 @@
 package api
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"golang.org/x/sync/errgroup"
 )
 
+type templateSource string //@Name TemplateSource
+
+const (
+	// groupTemplates are the projects in the templates subgroup of the classroom
+	groupTemplates templateSource = "group"
+	// topicTemplates are the projects marked with the template topic
+	topicTemplates templateSource = "topic"
+
+	templatesGroupName = "Templates"
+	templatesGroupPath = "templates"
+	templateTopic      = "classroom-template"
+)
+
+type getClassroomTemplatesQuery struct {
+	paginationQuery
+	Search string         `query:"search"`
+	Source templateSource `query:"source"`
+}
+
+func (q *getClassroomTemplatesQuery) isValid() bool {
+	if q.Source == "" {
+		q.Source = topicTemplates
+	}
+	return q.Source == groupTemplates || q.Source == topicTemplates
+}
+
+type TemplateResponse struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Description    string             `json:"description"`
+	WebURL         string             `json:"webUrl"`
+	LastActivityAt *time.Time         `json:"lastActivityAt"`
+	Languages      map[string]float32 `json:"languages"`
+	HasCI          bool               `json:"hasCi"`
+	// HasTestReport is true if the latest pipeline of the default branch has a test report
+	HasTestReport bool `json:"hasTestReport"`
+	// Error is set if the details of the template could not be fetched from GitLab
+	Error *string `json:"error" validate:"optional"`
+} //@Name TemplateResponse
+
 // @Summary		GetClassroomTemplates
-// @Description	GetClassroomTemplates
+// @Description	Get the template projects of the classroom. These are the projects with the topic "classroom-template" or the projects in the templates subgroup of the classroom, which is created when the first template is created.
+// @Description	The templates are always paginated, as the details of every listed template are fetched from GitLab.
 // @Id				GetClassroomTemplates
 // @Tags			classroom
 // @Produce		json
-// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
-// @Success		200			{array}		TemplateResponse
+// @Param			classroomId	path		string				true	"Classroom ID"	Format(uuid)
+// @Param			search		query		string				false	"Search by name"
+// @Param			source		query		api.templateSource	false	"Source of the templates (default: topic)"
+// @Param			page		query		int					false	"Page (default: 1)"
+// @Param			perPage		query		int					false	"Page size (default: 20, max: 100)"
+// @Success		200			{array}		api.TemplateResponse
+// @Header			200			{string}	Link	"Links to the first, previous, next and last page"
+// @Header			200			{int}		X-Total	"Total number of templates"
 // @Failure		400			{object}	HTTPError
 // @Failure		401			{object}	HTTPError
 // @Failure		403			{object}	HTTPError
 // @Failure		404			{object}	HTTPError
 // @Failure		500			{object}	HTTPError
 // @Router			/api/v1/classrooms/{classroomId}/templateProjects [get]
-func (ctrl *DefaultController) GetClassroomTemplates(c *fiber.Ctx) error {
-	type templateResponse struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} //@Name TemplateResponse
-
+func (ctrl *DefaultController) GetClassroomTemplates(c *fiber.Ctx) (err error) {
 	ctx := context.Get(c)
-	search := c.Query("search")
-
 	repo := ctx.GetGitlabRepository()
-	projects, err := repo.GetAllProjects(search)
+	classroom := ctx.GetUserClassroom()
+
+	var params getClassroomTemplatesQuery
+	if err = c.QueryParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !params.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Query is not valid")
+	}
+
+	var fetchPage func(page int, perPage int) ([]*model.Project, int, error)
+	switch params.Source {
+	case groupTemplates:
+		// The templates group is created with the first template, until then there are no templates
+		if classroom.Classroom.TemplatesGroupID == nil {
+			fetchPage = func(int, int) ([]*model.Project, int, error) { return nil, 0, nil }
+			break
+		}
+
+		// The templates group belongs to the classroom group, which the owner may not see on its own
+		if err = repo.GroupAccessLogin(classroom.Classroom.GroupAccessToken); err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		fetchPage = func(page int, perPage int) ([]*model.Project, int, error) {
+			return repo.GetProjectsOfGroupPage(*classroom.Classroom.TemplatesGroupID, params.Search, page, perPage)
+		}
+	case topicTemplates:
+		fetchPage = func(page int, perPage int) ([]*model.Project, int, error) {
+			return repo.GetProjectsWithTopicPage(templateTopic, params.Search, page, perPage)
+		}
+	}
+
+	projects, err := findTemplates(c, params.paginationQuery, fetchPage)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
-	response := utils.Map(projects, func(project *model.Project) *templateResponse {
-		return &templateResponse{
-			ID:   project.ID,
-			Name: project.Name,
+
+	response := utils.Map(projects, func(project *model.Project) *TemplateResponse {
+		return &TemplateResponse{
+			ID:             project.ID,
+			Name:           project.Name,
+			Description:    project.Description,
+			WebURL:         project.WebUrl,
+			LastActivityAt: project.LastActivityAt,
 		}
 	})
 
+	g := new(errgroup.Group)
+	g.SetLimit(pipelineConcurrency)
+	for _, template := range response {
+		g.Go(func() error {
+			if err := fetchTemplateDetails(repo, template); err != nil {
+				template.Error = utils.Ptr(err.Error())
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
 	return c.JSON(response)
 }
+
+// findTemplates fetches the requested page of templates and sets the pagination headers.
+// Without pagination the first page with the default page size is fetched.
+func findTemplates(c *fiber.Ctx, pagination paginationQuery, fetchPage func(page int, perPage int) ([]*model.Project, int, error)) ([]*model.Project, error) {
+	pagination.normalize()
+	projects, total, err := fetchPage(pagination.Page, pagination.PerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	setPaginationHeaders(c, pagination, total)
+	return projects, nil
+}
+
+// templatesGroupID returns the id of the templates subgroup of the classroom and creates the subgroup if it does not exist yet.
+// It is only used to create templates, listing the templates never creates the subgroup.
+func (ctrl *DefaultController) templatesGroupID(c *fiber.Ctx, repo gitlab.Repository, classroom *database.Classroom) (int, error) {
+	if classroom.TemplatesGroupID != nil {
+		return *classroom.TemplatesGroupID, nil
+	}
+
+	groupID, err, _ := ctrl.g.Do("templates-"+classroom.ID.String(), func() (interface{}, error) {
+		group, err := repo.CreateSubGroup(
+			templatesGroupName,
+			templatesGroupPath,
+			classroom.GroupID,
+			model.Private,
+			fmt.Sprintf("Template projects of classroom %s", classroom.Name),
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		queryClassroom := query.Classroom
+		if _, err = queryClassroom.
+			WithContext(c.Context()).
+			Where(queryClassroom.ID.Eq(classroom.ID)).
+			Update(queryClassroom.TemplatesGroupID, group.ID); err != nil {
+			return 0, err
+		}
+
+		return group.ID, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	classroom.TemplatesGroupID = utils.Ptr(groupID.(int))
+	return groupID.(int), nil
+}
+
+// fetchTemplateDetails adds the languages, the presence of a CI configuration and of a test report to the template.
+// A missing test report is not an error, because not every pipeline produces one.
+func fetchTemplateDetails(repo gitlab.Repository, template *TemplateResponse) (err error) {
+	template.Languages, err = repo.GetProjectLanguages(template.ID)
+	if err != nil {
+		return err
+	}
+
+	template.HasCI, err = repo.CheckIfFileExistsInProject(template.ID, ".gitlab-ci.yml")
+	if err != nil || !template.HasCI {
+		return err
+	}
+
+	pipeline, err := latestPipeline(repo, template.ID)
+	if err != nil || pipeline == nil {
+		return err
+	}
+
+	if report, err := repo.GetProjectPipelineTestReportSummary(template.ID, pipeline.ID); err == nil {
+		template.HasTestReport = report.TotalCount > 0
+	}
+	return nil
+}
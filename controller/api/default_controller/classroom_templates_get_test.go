@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestGetClassroomTemplates(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	route := fmt.Sprintf("/api/v1/classrooms/%s/templateProjects", classroom.ID.String())
+
+	gitlabRepo.EXPECT().
+		GroupAccessLogin(classroom.GroupAccessToken).
+		Return(nil).
+		Maybe()
+
+	t.Run("lists no group templates before the templates group exists", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", route+"?source=group", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "0", resp.Header.Get("X-Total"))
+
+		var templates []*TemplateResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&templates))
+		assert.Empty(t, templates)
+
+		reloaded, err := query.Classroom.WithContext(context.Background()).Where(query.Classroom.ID.Eq(classroom.ID)).First()
+		assert.NoError(t, err)
+		assert.Nil(t, reloaded.TemplatesGroupID)
+	})
+
+	t.Run("lists a page of the templates group", func(t *testing.T) {
+		_, err := query.Classroom.
+			WithContext(context.Background()).
+			Where(query.Classroom.ID.Eq(classroom.ID)).
+			Update(query.Classroom.TemplatesGroupID, 77)
+		assert.NoError(t, err)
+
+		gitlabRepo.EXPECT().
+			GetProjectsOfGroupPage(77, "java", 2, 1).
+			Return([]*model.Project{{ID: 5, Name: "Java Template"}}, 3, nil).
+			Once()
+		gitlabRepo.EXPECT().GetProjectLanguages(5).Return(map[string]float32{"Java": 100}, nil).Once()
+		gitlabRepo.EXPECT().CheckIfFileExistsInProject(5, ".gitlab-ci.yml").Return(true, nil).Once()
+		gitlabRepo.EXPECT().GetProjectLatestPipeline(5, (*string)(nil)).Return(&model.Pipeline{ID: 9}, nil).Once()
+		gitlabRepo.EXPECT().GetProjectPipelineTestReportSummary(5, 9).Return(&model.TestReport{TotalCount: 4}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", route+"?source=group&search=java&page=2&perPage=1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "3", resp.Header.Get("X-Total"))
+		assert.Contains(t, resp.Header.Get("Link"), `page=3&perPage=1&search=java&source=group>; rel="next"`)
+		assert.Contains(t, resp.Header.Get("Link"), `page=1&perPage=1&search=java&source=group>; rel="prev"`)
+
+		var templates []*TemplateResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&templates))
+		assert.Len(t, templates, 1)
+		assert.Equal(t, "Java Template", templates[0].Name)
+		assert.True(t, templates[0].HasCI)
+		assert.True(t, templates[0].HasTestReport)
+		assert.Equal(t, float32(100), templates[0].Languages["Java"])
+		assert.Nil(t, templates[0].Error)
+	})
+
+	t.Run("lists the first page of projects with template topic by default", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			GetProjectsWithTopicPage("classroom-template", "", 1, 20).
+			Return([]*model.Project{{ID: 6, Name: "Go Template"}, {ID: 7, Name: "Rust Template"}}, 2, nil).
+			Once()
+		gitlabRepo.EXPECT().GetProjectLanguages(6).Return(map[string]float32{}, nil).Once()
+		gitlabRepo.EXPECT().CheckIfFileExistsInProject(6, ".gitlab-ci.yml").Return(false, nil).Once()
+		gitlabRepo.EXPECT().GetProjectLanguages(7).Return(nil, errors.New("languages are not available")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-Total"))
+		assert.Contains(t, resp.Header.Get("Link"), `page=1&perPage=20>; rel="last"`)
+
+		var templates []*TemplateResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&templates))
+		assert.Len(t, templates, 2)
+		assert.False(t, templates[0].HasCI)
+		assert.False(t, templates[0].HasTestReport)
+		assert.Nil(t, templates[0].Error)
+		// The template is listed even if its details are not available
+		assert.Equal(t, "Rust Template", templates[1].Name)
+		assert.Equal(t, "languages are not available", *templates[1].Error)
+	})
+
+	t.Run("rejects unknown source", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", route+"?source=instance", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+const templateDefaultBranch = "main"
+
+type createTemplateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	StarterKit  string `json:"starterKit"`
+} //@Name CreateTemplateRequest
+
+func (r createTemplateRequest) isValid() bool {
+	return r.Name != "" && r.StarterKit != ""
+}
+
+// @Summary		CreateClassroomTemplate
+// @Description	Create a template project from a starter kit in the templates subgroup of the classroom. The pipeline of the starter kit publishes a test report, so the autograding works without changes.
+// @Id				CreateClassroomTemplate
+// @Tags			classroom
+// @Accept			json
+// @Produce		json
+// @Param			classroomId		path		string						true	"Classroom ID"	Format(uuid)
+// @Param			template		body		api.createTemplateRequest	true	"Template Info"
+// @Param			X-Csrf-Token	header		string						true	"Csrf-Token"
+// @Success		201				{object}	api.TemplateResponse
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/templateProjects [post]
+func (ctrl *DefaultController) CreateClassroomTemplate(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+	classroom := ctx.GetUserClassroom()
+
+	var requestBody createTemplateRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Request Body is not valid")
+	}
+
+	files, err := utils.StarterKitFiles(requestBody.StarterKit)
+	if err != nil {
+		if errors.Is(err, utils.ErrUnknownStarterKit) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err = repo.GroupAccessLogin(classroom.Classroom.GroupAccessToken); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	groupID, err := ctrl.templatesGroupID(c, repo, &classroom.Classroom)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	project, err := repo.CreateGroupProject(groupID, requestBody.Name, requestBody.Description, []string{templateTopic})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	defer func() {
+		if recover() != nil || err != nil {
+			if err := repo.DeleteProject(project.ID); err != nil {
+				log.Println(err.Error())
+			}
+		}
+	}()
+
+	if _, err = repo.CreateCommit(project.ID, templateDefaultBranch, fmt.Sprintf("Add %s starter kit", requestBody.StarterKit), files); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(TemplateResponse{
+		ID:             project.ID,
+		Name:           project.Name,
+		Description:    project.Description,
+		WebURL:         project.WebUrl,
+		LastActivityAt: project.LastActivityAt,
+		HasCI:          true,
+	})
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestCreateClassroomTemplate(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	templatesGroupID := 77
+	classroom.TemplatesGroupID = &templatesGroupID
+	if err := query.Classroom.WithContext(context.Background()).Save(classroom); err != nil {
+		t.Fatal(err)
+	}
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	route := fmt.Sprintf("/api/v1/classrooms/%s/templateProjects", classroom.ID.String())
+
+	gitlabRepo.EXPECT().
+		GroupAccessLogin(classroom.GroupAccessToken).
+		Return(nil).
+		Maybe()
+
+	t.Run("rejects unknown starter kit", func(t *testing.T) {
+		resp, err := app.Test(newPostJsonRequest(route, createTemplateRequest{Name: "Sheet 1", StarterKit: "rust-cargo"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("creates template from starter kit", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			CreateGroupProject(templatesGroupID, "Sheet 1", "Lists", []string{"classroom-template"}).
+			Return(&model.Project{ID: 12, Name: "Sheet 1"}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			CreateCommit(12, "main", "Add python-pytest starter kit", mock.MatchedBy(func(files map[string]string) bool {
+				return assert.Contains(t, files[".gitlab-ci.yml"], "junit: report.xml") &&
+					assert.Contains(t, files, "tests/test_calculator.py")
+			})).
+			Return(&model.Commit{ID: "abc"}, nil).
+			Once()
+
+		resp, err := app.Test(newPostJsonRequest(route, createTemplateRequest{Name: "Sheet 1", Description: "Lists", StarterKit: "python-pytest"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var template TemplateResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&template))
+		assert.Equal(t, 12, template.ID)
+		assert.True(t, template.HasCI)
+	})
+
+	t.Run("deletes project if the commit fails", func(t *testing.T) {
+		gitlabRepo.EXPECT().
+			CreateGroupProject(templatesGroupID, "Sheet 2", "", []string{"classroom-template"}).
+			Return(&model.Project{ID: 13, Name: "Sheet 2"}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			CreateCommit(13, "main", "Add c-ctest starter kit", mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+		gitlabRepo.EXPECT().
+			DeleteProject(13).
+			Return(nil).
+			Once()
+
+		resp, err := app.Test(newPostJsonRequest(route, createTemplateRequest{Name: "Sheet 2", StarterKit: "c-ctest"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	})
+	t.Run("creates templates group with the first template", func(t *testing.T) {
+		_, err := query.Classroom.
+			WithContext(context.Background()).
+			Where(query.Classroom.ID.Eq(classroom.ID)).
+			UpdateSimple(query.Classroom.TemplatesGroupID.Null())
+		assert.NoError(t, err)
+
+		gitlabRepo.EXPECT().
+			CreateSubGroup("Templates", "templates", classroom.GroupID, model.Private, mock.Anything).
+			Return(&model.Group{ID: 78}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			CreateGroupProject(78, "Sheet 3", "", []string{"classroom-template"}).
+			Return(&model.Project{ID: 14, Name: "Sheet 3"}, nil).
+			Once()
+		gitlabRepo.EXPECT().
+			CreateCommit(14, "main", "Add python-pytest starter kit", mock.Anything).
+			Return(&model.Commit{ID: "def"}, nil).
+			Once()
+
+		resp, err := app.Test(newPostJsonRequest(route, createTemplateRequest{Name: "Sheet 3", StarterKit: "python-pytest"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		reloaded, err := query.Classroom.WithContext(context.Background()).Where(query.Classroom.ID.Eq(classroom.ID)).First()
+		assert.NoError(t, err)
+		assert.Equal(t, 78, *reloaded.TemplatesGroupID)
+	})
+}
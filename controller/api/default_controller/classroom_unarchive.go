@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	fiberContext "gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gorm.io/gen/field"
+)
+
+// @Summary		UnarchiveClassroom
+// @Description	UnarchiveClassroom restores the access levels of the students and renews the group access token if it was revoked.
+// @Id				UnarchiveClassroom
+// @Tags			classroom
+// @Produce		json
+// @Param			classroomId		path	string	true	"Classroom ID"	Format(uuid)
+// @Param			X-Csrf-Token	header	string	true	"Csrf-Token"
+// @Success		202
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/unarchive [patch]
+func (ctrl *DefaultController) UnarchiveClassroom(c *fiber.Ctx) (err error) {
+	ctx := fiberContext.Get(c)
+	userClassroom := ctx.GetUserClassroom()
+	classroom := &userClassroom.Classroom
+	repo := ctx.GetGitlabRepository()
+
+	if !classroom.Archived {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	// The sync worker archives classrooms whose access token was revoked,
+	// so a new token is needed before we can work with the group again.
+	if err = renewGroupAccessToken(c.Context(), repo, classroom); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err = repo.GroupAccessLogin(classroom.GroupAccessToken); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	teams, err := query.Team.
+		WithContext(c.Context()).
+		Preload(query.Team.Member).
+		Preload(query.Team.AssignmentProjects).
+		Preload(field.NewRelation("AssignmentProjects.Assignment", "")).
+		Where(query.Team.ClassroomID.Eq(classroom.ID)).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	caches := []utils.ProjectAccessLevelCache{}
+	defer func() {
+		if recover() != nil || err != nil {
+			for _, cache := range caches {
+				repo.ChangeUserAccessLevelInProject(cache.ProjectID, cache.UserID, cache.AccessLevel)
+			}
+		}
+	}()
+	for _, team := range teams {
+		for _, project := range team.AssignmentProjects {
+			if project.ProjectStatus != database.Accepted {
+				continue
+			}
+
+			// Students keep write access until the assignment is closed
+			accessLevel := model.DeveloperPermissions
+			if project.Assignment.Closed {
+				accessLevel = model.ReporterPermissions
+			}
+
+			for _, member := range team.Member {
+				if member.Role != database.Student {
+					continue
+				}
+
+				permission, err := repo.GetAccessLevelOfUserInProject(project.ProjectID, member.UserID)
+				if err != nil {
+					return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+				}
+
+				if permission == accessLevel || permission == model.OwnerPermissions {
+					continue
+				}
+
+				if err := repo.ChangeUserAccessLevelInProject(project.ProjectID, member.UserID, accessLevel); err != nil {
+					return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+				}
+
+				caches = append(caches, utils.ProjectAccessLevelCache{UserID: member.UserID, ProjectID: project.ProjectID, AccessLevel: permission})
+			}
+		}
+	}
+
+	if _, err = query.Classroom.
+		WithContext(c.Context()).
+		Where(query.Classroom.ID.Eq(classroom.ID)).
+		Update(query.Classroom.Archived, false); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+// renewGroupAccessToken creates a new group access token if the current one is revoked, inactive or deleted.
+// Otherwise, the token is rotated like in the RotateAccessTokenMiddleware.
+// The repo has to be logged in as an owner of the classroom group.
+func renewGroupAccessToken(ctx context.Context, repo gitlab.Repository, classroom *database.Classroom) error {
+	accessToken, err := repo.GetGroupAccessToken(classroom.GroupID, classroom.GroupAccessTokenID)
+	if err == nil && accessToken.Active && !accessToken.Revoked {
+		return rotateGroupAccessToken(ctx, repo, classroom)
+	}
+	if err != nil {
+		log.Println("Could not fetch access token of classroom", classroom.ID, err)
+	}
+
+	expiresAt := time.Now().AddDate(0, 0, 364)
+	accessToken, err = repo.CreateGroupAccessToken(classroom.GroupID, "GitClassrooms", model.OwnerPermissions, expiresAt, "api")
+	if err != nil {
+		return err
+	}
+
+	log.Println("Recreated access token for classroom", classroom.ID)
+
+	classroom.GroupAccessTokenID = accessToken.ID
+	classroom.GroupAccessToken = accessToken.Token
+	classroom.GroupAccessTokenCreatedAt = accessToken.CreatedAt
+	return query.Classroom.WithContext(ctx).Save(classroom)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestPatchClassroomUnarchive(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	user2 := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	members := []*database.UserClassrooms{
+		factory.UserClassroom(user2.ID, classroom.ID, database.Student),
+	}
+
+	dueDate := time.Now().Add(1 * time.Hour)
+
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+	team := factory.Team(classroom.ID, members)
+	assignmentProject := factory.AssignmentProject(assignment.ID, team.ID)
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+	targetRoute := fmt.Sprintf("/api/v1/classrooms/%s/unarchive", classroom.ID.String())
+
+	t.Run("classroom not archived", func(t *testing.T) {
+		classroom.Archived = false
+		saveClassroom(t, classroom)
+
+		req := httptest.NewRequest("PATCH", targetRoute, nil)
+		resp, err := app.Test(req)
+
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+		assert.NoError(t, err)
+	})
+
+	t.Run("recreates revoked access token and restores access levels", func(t *testing.T) {
+		classroom.Archived = true
+		saveClassroom(t, classroom)
+
+		gitlabRepo.
+			EXPECT().
+			GetGroupAccessToken(classroom.GroupID, classroom.GroupAccessTokenID).
+			Return(&model.GroupAccessToken{ID: classroom.GroupAccessTokenID, Revoked: true}, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			CreateGroupAccessToken(classroom.GroupID, "GitClassrooms", model.OwnerPermissions, mock.Anything, "api").
+			Return(&model.GroupAccessToken{ID: 42, Token: "new-token", CreatedAt: time.Now()}, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			GroupAccessLogin("new-token").
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			GetAccessLevelOfUserInProject(assignmentProject.ProjectID, user2.ID).
+			Return(model.ReporterPermissions, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			ChangeUserAccessLevelInProject(assignmentProject.ProjectID, user2.ID, model.DeveloperPermissions).
+			Return(nil).
+			Times(1)
+
+		req := httptest.NewRequest("PATCH", targetRoute, nil)
+		resp, err := app.Test(req)
+
+		gitlabRepo.AssertExpectations(t)
+
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+		assert.NoError(t, err)
+
+		dbClassroom, err := query.Classroom.WithContext(context.Background()).Where(query.Classroom.ID.Eq(classroom.ID)).First()
+		assert.NoError(t, err)
+		assert.Equal(t, false, dbClassroom.Archived)
+		assert.Equal(t, 42, dbClassroom.GroupAccessTokenID)
+		assert.Equal(t, "new-token", dbClassroom.GroupAccessToken)
+	})
+}
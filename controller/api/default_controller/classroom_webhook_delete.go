@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+)
+
+// @Summary		DeleteClassroomWebhook
+// @Description	Delete a webhook of the classroom together with its delivery log.
+// @Id				DeleteClassroomWebhook
+// @Tags			webhook
+// @Param			classroomId		path	string	true	"Classroom ID"	Format(uuid)
+// @Param			webhookId		path	string	true	"Webhook ID"	Format(uuid)
+// @Param			X-Csrf-Token	header	string	true	"Csrf-Token"
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/webhooks/{webhookId} [delete]
+func (ctrl *DefaultController) DeleteClassroomWebhook(c *fiber.Ctx) (err error) {
+	webhook, err := classroomWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err = query.Webhook.WithContext(c.Context()).Delete(webhook); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
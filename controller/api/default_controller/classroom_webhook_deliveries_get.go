@@ -0,0 +1,71 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+)
+
+type getWebhookDeliveriesQuery struct {
+	paginationQuery
+	Status database.WebhookDeliveryStatus `query:"status"`
+}
+
+func (q getWebhookDeliveriesQuery) isValid() bool {
+	switch q.Status {
+	case "", database.WebhookPending, database.WebhookDelivered, database.WebhookFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// @Summary		GetClassroomWebhookDeliveries
+// @Description	Get the delivery log of a webhook of the classroom, newest first.
+// @Id				GetClassroomWebhookDeliveries
+// @Tags			webhook
+// @Produce		json
+// @Param			classroomId	path		string							true	"Classroom ID"	Format(uuid)
+// @Param			webhookId	path		string							true	"Webhook ID"	Format(uuid)
+// @Param			status		query		database.WebhookDeliveryStatus	false	"Only deliveries with the given status"
+// @Param			page		query		int								false	"Page, all deliveries are returned if neither page nor perPage is set"
+// @Param			perPage		query		int								false	"Page size (default: 20, max: 100)"
+// @Success		200			{array}		database.WebhookDelivery
+// @Header			200			{string}	Link	"Links to the first, previous, next and last page"
+// @Header			200			{int}		X-Total	"Total number of deliveries"
+// @Failure		400			{object}	HTTPError
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/webhooks/{webhookId}/deliveries [get]
+func (ctrl *DefaultController) GetClassroomWebhookDeliveries(c *fiber.Ctx) (err error) {
+	webhook, err := classroomWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	var urlQuery getWebhookDeliveriesQuery
+	if err = c.QueryParser(&urlQuery); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !urlQuery.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	queryDelivery := query.WebhookDelivery
+	dbQuery := queryDelivery.
+		WithContext(c.Context()).
+		Where(queryDelivery.WebhookID.Eq(webhook.ID))
+	if urlQuery.Status != "" {
+		dbQuery = dbQuery.Where(queryDelivery.Status.Eq(string(urlQuery.Status)))
+	}
+
+	deliveries, err := findPage(c, dbQuery.Order(queryDelivery.CreatedAt.Desc()), urlQuery.paginationQuery)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(deliveries)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+)
+
+// @Summary		RedeliverClassroomWebhookDelivery
+// @Description	Queue a delivery of a webhook of the classroom for delivery again, e.g. after the receiver was fixed.
+// @Id				RedeliverClassroomWebhookDelivery
+// @Tags			webhook
+// @Param			classroomId		path	string	true	"Classroom ID"	Format(uuid)
+// @Param			webhookId		path	string	true	"Webhook ID"	Format(uuid)
+// @Param			deliveryId		path	string	true	"Delivery ID"	Format(uuid)
+// @Param			X-Csrf-Token	header	string	true	"Csrf-Token"
+// @Success		202
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func (ctrl *DefaultController) RedeliverClassroomWebhookDelivery(c *fiber.Ctx) (err error) {
+	webhook, err := classroomWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	var params Params
+	if err = c.ParamsParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if params.DeliveryID == nil {
+		return fiber.ErrBadRequest
+	}
+
+	queryDelivery := query.WebhookDelivery
+	delivery, err := queryDelivery.
+		WithContext(c.Context()).
+		Where(queryDelivery.WebhookID.Eq(webhook.ID)).
+		Where(queryDelivery.ID.Eq(*params.DeliveryID)).
+		First()
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+
+	if delivery.Status == database.WebhookPending {
+		return fiber.NewError(fiber.StatusBadRequest, "The delivery is still pending")
+	}
+
+	delivery.Status = database.WebhookPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.LastError = nil
+	delivery.ResponseStatus = nil
+	delivery.DeliveredAt = nil
+
+	if err = queryDelivery.WithContext(c.Context()).Save(delivery); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
+)
+
+// @Summary		UpdateClassroomWebhook
+// @Description	Update the URL, the events and the activation of a webhook of the classroom. The secret is kept and the URL has to resolve to public addresses.
+// @Id				UpdateClassroomWebhook
+// @Tags			webhook
+// @Accept			json
+// @Param			classroomId		path	string				true	"Classroom ID"	Format(uuid)
+// @Param			webhookId		path	string				true	"Webhook ID"	Format(uuid)
+// @Param			webhook			body	api.webhookRequest	true	"Webhook"
+// @Param			X-Csrf-Token	header	string				true	"Csrf-Token"
+// @Success		202
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/webhooks/{webhookId} [put]
+func (ctrl *DefaultController) UpdateClassroomWebhook(c *fiber.Ctx) (err error) {
+	storedWebhook, err := classroomWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	var requestBody webhookRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	if err = webhook.CheckURL(c.Context(), requestBody.URL); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	storedWebhook.URL = requestBody.URL
+	storedWebhook.Events = requestBody.Events
+	storedWebhook.Active = requestBody.Active == nil || *requestBody.Active
+
+	if err = query.Webhook.WithContext(c.Context()).Save(storedWebhook); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		GetClassroomWebhooks
+// @Description	Get the outgoing webhooks of the classroom.
+// @Id				GetClassroomWebhooks
+// @Tags			webhook
+// @Produce		json
+// @Param			classroomId	path		string	true	"Classroom ID"	Format(uuid)
+// @Success		200			{array}		database.Webhook
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/webhooks [get]
+func (ctrl *DefaultController) GetClassroomWebhooks(c *fiber.Ctx) (err error) {
+	classroom := context.Get(c).GetUserClassroom()
+
+	var webhooks []*database.Webhook
+
+	queryWebhook := query.Webhook
+	webhooks, err = queryWebhook.
+		WithContext(c.Context()).
+		Where(queryWebhook.ClassroomID.Eq(classroom.ClassroomID)).
+		Order(queryWebhook.CreatedAt).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(webhooks)
+}
+
+// classroomWebhook returns the webhook of the route params, if it belongs to the classroom.
+func classroomWebhook(c *fiber.Ctx) (*database.Webhook, error) {
+	var params Params
+	if err := c.ParamsParser(&params); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if params.ClassroomID == nil || params.WebhookID == nil {
+		return nil, fiber.ErrBadRequest
+	}
+
+	queryWebhook := query.Webhook
+	webhook, err := queryWebhook.
+		WithContext(c.Context()).
+		Where(queryWebhook.ClassroomID.Eq(*params.ClassroomID)).
+		Where(queryWebhook.ID.Eq(*params.WebhookID)).
+		First()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+
+	return webhook, nil
+}
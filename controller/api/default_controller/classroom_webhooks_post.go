@@ -0,0 +1,96 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type webhookRequest struct {
+	URL    string                  `json:"url"`
+	Events []database.WebhookEvent `json:"events"`
+	// Active is true if not set
+	Active *bool `json:"active" validate:"optional"`
+} //@Name WebhookRequest
+
+func (r webhookRequest) isValid() bool {
+	webhookURL, err := url.ParseRequestURI(r.URL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return false
+	}
+	if len(r.Events) == 0 {
+		return false
+	}
+	for _, event := range r.Events {
+		if !slices.Contains(database.WebhookEvents, event) {
+			return false
+		}
+	}
+	return true
+}
+
+type CreateWebhookResponse struct {
+	*database.Webhook
+	// Secret is only shown once, the deliveries are signed with it in the X-GitClassrooms-Signature header
+	Secret string `json:"secret"`
+} //@Name CreateWebhookResponse
+
+// @Summary		CreateClassroomWebhook
+// @Description	Create an outgoing webhook for the classroom. The events are posted as JSON to the URL, signed with the HMAC-SHA256 of the body in the X-GitClassrooms-Signature header ("sha256=<hex>"). Failed deliveries are retried with exponential backoff. The URL has to resolve to public addresses and redirects are not followed.
+// @Id				CreateClassroomWebhook
+// @Tags			webhook
+// @Accept			json
+// @Produce		json
+// @Param			classroomId		path		string				true	"Classroom ID"	Format(uuid)
+// @Param			webhook			body		api.webhookRequest	true	"Webhook"
+// @Param			X-Csrf-Token	header		string				true	"Csrf-Token"
+// @Success		201				{object}	api.CreateWebhookResponse
+// @Header			201				{string}	Location	"/api/v1/classrooms/{classroomId}/webhooks/{webhookId}"
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		404				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/classrooms/{classroomId}/webhooks [post]
+func (ctrl *DefaultController) CreateClassroomWebhook(c *fiber.Ctx) (err error) {
+	classroom := context.Get(c).GetUserClassroom()
+
+	var requestBody webhookRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	if err = webhook.CheckURL(c.Context(), requestBody.URL); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	newWebhook := &database.Webhook{
+		ClassroomID: classroom.ClassroomID,
+		URL:         requestBody.URL,
+		Secret:      secret,
+		Events:      requestBody.Events,
+		Active:      requestBody.Active == nil || *requestBody.Active,
+	}
+	if err = query.Webhook.WithContext(c.Context()).Create(newWebhook); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	c.Set("Location", fmt.Sprintf("/api/v1/classrooms/%s/webhooks/%s", classroom.ClassroomID.String(), newWebhook.ID.String()))
+	c.Status(fiber.StatusCreated)
+	return c.JSON(CreateWebhookResponse{Webhook: newWebhook, Secret: secret})
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestClassroomWebhooks(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+	factory.UserClassroom(owner.ID, classroom.ID, database.Owner)
+
+	app, _, _ := setupApp(t, owner)
+	route := fmt.Sprintf("/api/v1/classrooms/%s/webhooks", classroom.ID.String())
+
+	var created CreateWebhookResponse
+
+	t.Run("creates webhook", func(t *testing.T) {
+		requestBody := webhookRequest{
+			URL:    "https://203.0.113.10/hooks/classroom",
+			Events: []database.WebhookEvent{database.TeamCreatedEvent, database.GradesUpdatedEvent},
+		}
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		err = json.NewDecoder(resp.Body).Decode(&created)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.Secret)
+		assert.True(t, created.Active)
+		assert.Equal(t, requestBody.Events, created.Events)
+	})
+
+	t.Run("rejects invalid webhooks", func(t *testing.T) {
+		for _, requestBody := range []webhookRequest{
+			{URL: "ftp://example.com", Events: []database.WebhookEvent{database.TeamCreatedEvent}},
+			{URL: "https://example.com", Events: []database.WebhookEvent{}},
+			{URL: "https://example.com", Events: []database.WebhookEvent{"teamDeleted"}},
+			{URL: "http://127.0.0.1:8080/hooks", Events: []database.WebhookEvent{database.TeamCreatedEvent}},
+			{URL: "http://169.254.169.254/latest/meta-data", Events: []database.WebhookEvent{database.TeamCreatedEvent}},
+		} {
+			req := newPostJsonRequest(route, requestBody)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		}
+	})
+
+	t.Run("updates webhook", func(t *testing.T) {
+		requestBody := webhookRequest{
+			URL:    "https://203.0.113.20/hooks",
+			Events: []database.WebhookEvent{database.ProjectAcceptedEvent},
+			Active: utils.Ptr(false),
+		}
+
+		req := newPutJsonRequest(fmt.Sprintf("%s/%s", route, created.ID.String()), requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		req = httptest.NewRequest("GET", route, nil)
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var webhooks []*database.Webhook
+		err = json.NewDecoder(resp.Body).Decode(&webhooks)
+		assert.NoError(t, err)
+		assert.Len(t, webhooks, 1)
+		assert.Equal(t, requestBody.URL, webhooks[0].URL)
+		assert.False(t, webhooks[0].Active)
+
+		stored, err := query.Webhook.WithContext(context.Background()).Where(query.Webhook.ID.Eq(created.ID)).First()
+		assert.NoError(t, err)
+		assert.Equal(t, created.Secret, stored.Secret)
+	})
+
+	t.Run("lists and redelivers deliveries", func(t *testing.T) {
+		lastError := "webhook answered with status 500"
+		delivery := &database.WebhookDelivery{
+			WebhookID: created.ID,
+			Event:     database.ProjectAcceptedEvent,
+			Payload:   []byte("{}"),
+			Status:    database.WebhookFailed,
+			Attempts:  6,
+			LastError: &lastError,
+		}
+		err := query.WebhookDelivery.WithContext(context.Background()).Create(delivery)
+		assert.NoError(t, err)
+
+		deliveriesRoute := fmt.Sprintf("%s/%s/deliveries", route, created.ID.String())
+		req := httptest.NewRequest("GET", deliveriesRoute+"?status=failed", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var deliveries []*database.WebhookDelivery
+		err = json.NewDecoder(resp.Body).Decode(&deliveries)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, delivery.ID, deliveries[0].ID)
+
+		req = httptest.NewRequest("POST", fmt.Sprintf("%s/%s/redeliver", deliveriesRoute, delivery.ID.String()), nil)
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		delivery, err = query.WebhookDelivery.WithContext(context.Background()).Where(query.WebhookDelivery.ID.Eq(delivery.ID)).First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.WebhookPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+
+		req = httptest.NewRequest("POST", fmt.Sprintf("%s/%s/redeliver", deliveriesRoute, delivery.ID.String()), nil)
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("emits only to active webhooks", func(t *testing.T) {
+		err := webhook.NewOutbox(context.Background(), query.Q, classroom.ID).
+			Emit(database.ProjectAcceptedEvent, webhook.ProjectData{})
+		assert.NoError(t, err)
+
+		count, err := query.WebhookDelivery.WithContext(context.Background()).Where(query.WebhookDelivery.WebhookID.Eq(created.ID)).Count()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("deletes webhook", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", fmt.Sprintf("%s/%s", route, created.ID.String()), nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+		req = httptest.NewRequest("DELETE", fmt.Sprintf("%s/%s", route, created.ID.String()), nil)
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("creates inactive webhook", func(t *testing.T) {
+		requestBody := webhookRequest{
+			URL:    "https://203.0.113.30/hooks",
+			Events: []database.WebhookEvent{database.ProjectAcceptedEvent},
+			Active: utils.Ptr(false),
+		}
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var inactive CreateWebhookResponse
+		err = json.NewDecoder(resp.Body).Decode(&inactive)
+		assert.NoError(t, err)
+		assert.False(t, inactive.Active)
+
+		stored, err := query.Webhook.WithContext(context.Background()).Where(query.Webhook.ID.Eq(inactive.ID)).First()
+		assert.NoError(t, err)
+		assert.False(t, stored.Active)
+
+		err = webhook.NewOutbox(context.Background(), query.Q, classroom.ID).
+			Emit(database.ProjectAcceptedEvent, webhook.ProjectData{})
+		assert.NoError(t, err)
+
+		count, err := query.WebhookDelivery.WithContext(context.Background()).Where(query.WebhookDelivery.WebhookID.Eq(inactive.ID)).Count()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gorm.io/gen/field"
 )
 
 type filter string //@Name Filter
@@ -19,10 +20,20 @@ const (
 )
 
 type classroomRequestQuery struct {
+	listQuery
 	Filter   filter `query:"filter"`
 	Archived bool   `query:"archived"`
 }
 
+func (q classroomRequestQuery) isValid() bool {
+	switch q.Filter {
+	case "", ownedClassrooms, moderatorClassrooms, studentClassrooms:
+		return true
+	default:
+		return false
+	}
+}
+
 // @Summary		Get classrooms
 // @Description	Get classrooms
 // @Id				GetClassrooms
@@ -30,7 +41,14 @@ type classroomRequestQuery struct {
 // @Produce		json
 // @Param			filter		query		api.filter	false	"Filter Options"
 // @Param			archived	query		bool		false	"Archived"
+// @Param			search		query		string		false	"Search by name"
+// @Param			sort		query		string		false	"Sort by name or createdAt, prefixed with - for descending order"	Enums(name, -name, createdAt, -createdAt)
+// @Param			page		query		int			false	"Page, all classrooms are returned if neither page nor perPage is set"
+// @Param			perPage		query		int			false	"Page size (default: 20, max: 100)"
 // @Success		200			{array}		api.UserClassroomResponse
+// @Header			200			{string}	Link	"Links to the first, previous, next and last page"
+// @Header			200			{int}		X-Total	"Total number of classrooms"
+// @Failure		400			{object}	HTTPError
 // @Failure		401			{object}	HTTPError
 // @Failure		500			{object}	HTTPError
 // @Router			/api/v1/classrooms [get]
@@ -44,6 +62,10 @@ func (ctrl *DefaultController) GetClassrooms(c *fiber.Ctx) (err error) {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
+	if !urlQuery.isValid() {
+		return fiber.NewError(fiber.StatusBadRequest, "Filter is not valid")
+	}
+
 	dbQuery := userClassroomQuery(c, userID)
 	switch urlQuery.Filter {
 	case ownedClassrooms:
@@ -52,7 +74,6 @@ func (ctrl *DefaultController) GetClassrooms(c *fiber.Ctx) (err error) {
 		dbQuery = dbQuery.Where(query.UserClassrooms.Role.Eq(uint8(database.Moderator)))
 	case studentClassrooms:
 		dbQuery = dbQuery.Where(query.UserClassrooms.Role.Eq(uint8(database.Student)))
-	default:
 	}
 
 	if urlQuery.Archived {
@@ -61,7 +82,19 @@ func (ctrl *DefaultController) GetClassrooms(c *fiber.Ctx) (err error) {
 		dbQuery = dbQuery.Join(query.Classroom, query.UserClassrooms.ClassroomID.EqCol(query.Classroom.ID)).Where(query.Classroom.Archived.Not())
 	}
 
-	classrooms, err := dbQuery.Find()
+	if urlQuery.Search != "" {
+		dbQuery = dbQuery.Where(containsIgnoreCase(urlQuery.Search, query.Classroom.Name))
+	}
+
+	order, ok := urlQuery.orderBy(map[string]field.OrderExpr{
+		"name":      query.Classroom.Name,
+		"createdAt": query.Classroom.CreatedAt,
+	}, query.Classroom.CreatedAt)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Sort field is not valid")
+	}
+
+	classrooms, err := findPage(c, dbQuery.Order(order...), urlQuery.paginationQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http/httptest"
 	"testing"
@@ -121,4 +122,49 @@ func TestGetClassrooms(t *testing.T) {
 		assert.Equal(t, classroomResponse.Classroom.ID, classroom.ID)
 		assert.Equal(t, classroomResponse.Role, database.Student)
 	})
+
+	t.Run("return classrooms paginated, sorted and searched", func(t *testing.T) {
+		classroom.Name = "Alpha"
+		if err := query.Classroom.WithContext(context.Background()).Save(classroom); err != nil {
+			t.Fatal(err)
+		}
+		secondClassroom := factory.Classroom(owner.ID)
+		secondClassroom.Name = "Beta"
+		if err := query.Classroom.WithContext(context.Background()).Save(secondClassroom); err != nil {
+			t.Fatal(err)
+		}
+		factory.UserClassroom(owner.ID, secondClassroom.ID, database.Owner)
+
+		app, _, _ := setupApp(t, owner)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/classrooms?filter=owned&sort=-name&page=1&perPage=1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-Total"))
+		assert.Contains(t, resp.Header.Get("Link"), `rel="next"`)
+
+		var classroomsResponse []*UserClassroomResponse
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&classroomsResponse))
+		assert.Len(t, classroomsResponse, 1)
+		assert.Equal(t, secondClassroom.ID, classroomsResponse[0].Classroom.ID)
+
+		resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/classrooms?filter=owned&search=alp", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		classroomsResponse = nil
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&classroomsResponse))
+		assert.Len(t, classroomsResponse, 1)
+		assert.Equal(t, classroom.ID, classroomsResponse[0].Classroom.ID)
+	})
+
+	t.Run("rejects unknown filter and sort field", func(t *testing.T) {
+		app, _, _ := setupApp(t, owner)
+
+		for _, route := range []string{"/api/v1/classrooms?filter=admin", "/api/v1/classrooms?sort=ownerId"} {
+			resp, err := app.Test(httptest.NewRequest("GET", route, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, route)
+		}
+	})
 }
@@ -0,0 +1,417 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	mailRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+type importClassroomRequest struct {
+	Name        string `form:"name"`
+	WithResults bool   `form:"withResults"`
+}
+
+// @Summary		ImportClassroom
+// @Description	Imports a classroom bundle created by ExportClassroom into a new classroom with a new GitLab group.
+// @Description	The settings, rubrics and assignments are always imported. With withResults the members, teams, projects and grading results are imported as records as well.
+// @Description	The members are matched with the users of this instance by their GitLab username or email and added to the GitLab groups of the classroom and their team, unknown members are invited by mail. The teams get new GitLab groups.
+// @Description	As the projects still belong to the GitLab group of the exported classroom, the imported classroom is archived in this case.
+// @Id				ImportClassroom
+// @Tags			classroom
+// @Accept			mpfd
+// @Param			bundle			formData	file	true	"Classroom bundle (ZIP or JSON)"
+// @Param			name			formData	string	false	"Name of the new classroom (default: name of the exported classroom)"
+// @Param			withResults		formData	bool	false	"Import members, teams, projects and grading results"
+// @Param			X-Csrf-Token	header		string	true	"Csrf-Token"
+// @Success		201
+// @Header			201	{string}	Location	"/api/v1/classroom/{classroomId}"
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/classrooms/import [post]
+func (ctrl *DefaultController) ImportClassroom(c *fiber.Ctx) (err error) {
+	ctx := context.Get(c)
+	repo := ctx.GetGitlabRepository()
+
+	userID := ctx.GetUserID()
+
+	var requestBody importClassroomRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	fileHeader, err := c.FormFile("bundle")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	bundle, err := utils.ReadClassroomBundle(data)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	name := bundle.Classroom.Name
+	if requestBody.Name != "" {
+		name = requestBody.Name
+	}
+	if name == "" || bundle.Classroom.MaxTeamSize < 1 {
+		return fiber.ErrBadRequest
+	}
+
+	queryUser := query.User
+	user, err := queryUser.WithContext(c.Context()).Where(queryUser.ID.Eq(userID)).First()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	group, err := repo.CreateGroup(
+		name,
+		model.Private,
+		bundle.Classroom.Description,
+	)
+	if err != nil {
+		var gitlabError *model.GitLabError
+		if errors.As(err, &gitlabError) {
+			if strings.Contains(gitlabError.Message, "{:path=>[\"has already been taken\"]}}") {
+				return fiber.NewError(fiber.StatusBadRequest, "A classroom with this name already exists")
+			}
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	defer func() {
+		if recover() != nil || err != nil {
+			if err := repo.DeleteGroup(group.ID); err != nil {
+				log.Println(err.Error())
+			}
+		}
+	}()
+
+	expiresAt := time.Now().AddDate(0, 0, 364)
+
+	accessToken, err := repo.CreateGroupAccessToken(group.ID, "GitClassrooms", model.OwnerPermissions, expiresAt, "api")
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	// We don't need to delete the accessToken because it will be deleted when the group is deleted
+
+	// The teams get new groups, as the groups in the bundle belong to the exporting instance
+	teamGroupIDs := make(map[uuid.UUID]int)
+	if requestBody.WithResults {
+		for _, bundleTeam := range bundle.Teams {
+			subgroup, err := repo.CreateSubGroup(
+				bundleTeam.Name,
+				bundleTeam.Name,
+				group.ID,
+				model.Private,
+				fmt.Sprintf("Team %s of classroom %s", bundleTeam.Name, name),
+			)
+			if err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+			// We don't need to delete the subgroup because it will be deleted when the group is deleted
+			teamGroupIDs[bundleTeam.ID] = subgroup.ID
+		}
+	}
+
+	var classroom *database.Classroom
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		classroom = &database.Classroom{
+			Name:                    name,
+			Description:             bundle.Classroom.Description,
+			OwnerID:                 userID,
+			CreateTeams:             bundle.Classroom.CreateTeams,
+			MaxTeamSize:             bundle.Classroom.MaxTeamSize,
+			MaxTeams:                bundle.Classroom.MaxTeams,
+			GroupID:                 group.ID,
+			GroupAccessTokenID:      accessToken.ID,
+			GroupAccessToken:        accessToken.Token,
+			StudentsViewAllProjects: bundle.Classroom.StudentsViewAllProjects,
+			Archived:                requestBody.WithResults,
+			Member:                  []*database.UserClassrooms{{UserID: userID, Role: database.Owner}},
+		}
+
+		if err := tx.Classroom.WithContext(c.Context()).Create(classroom); err != nil {
+			return err
+		}
+
+		rubrics := make(map[uuid.UUID]*database.ManualGradingRubric, len(bundle.Rubrics))
+		for _, bundleRubric := range bundle.Rubrics {
+			rubric := &database.ManualGradingRubric{
+				Name:        bundleRubric.Name,
+				Description: bundleRubric.Description,
+				ClassroomID: classroom.ID,
+				MaxScore:    bundleRubric.MaxScore,
+			}
+			if err := tx.ManualGradingRubric.WithContext(c.Context()).Create(rubric); err != nil {
+				return err
+			}
+			rubrics[bundleRubric.ID] = rubric
+		}
+
+		teams := make(map[uuid.UUID]*database.Team)
+		if requestBody.WithResults {
+			for _, bundleTeam := range bundle.Teams {
+				team := &database.Team{
+					Name:        bundleTeam.Name,
+					GroupID:     teamGroupIDs[bundleTeam.ID],
+					ClassroomID: classroom.ID,
+				}
+				if err := tx.Team.WithContext(c.Context()).Create(team); err != nil {
+					return err
+				}
+				teams[bundleTeam.ID] = team
+			}
+
+			if err := importBundleMembers(c, tx, repo, classroom, user, bundle.Members, teams); err != nil {
+				return err
+			}
+		}
+
+		for _, bundleAssignment := range bundle.Assignments {
+			assignment := &database.Assignment{
+				ClassroomID:                   classroom.ID,
+				TemplateProjectID:             bundleAssignment.TemplateProjectID,
+				Name:                          bundleAssignment.Name,
+				Description:                   bundleAssignment.Description,
+				DueDate:                       bundleAssignment.DueDate,
+				Closed:                        bundleAssignment.Closed,
+				GradingJUnitAutoGradingActive: bundleAssignment.GradingJUnitAutoGradingActive,
+				JUnitTests: utils.Map(bundleAssignment.JUnitTests, func(test utils.BundleJUnitTest) *database.AssignmentJunitTest {
+					return &database.AssignmentJunitTest{Name: test.Name, Score: test.Score}
+				}),
+				TemplateDistribution: database.RandomDistribution,
+			}
+			if bundleAssignment.TemplateDistribution == database.RoundRobinDistribution {
+				assignment.TemplateDistribution = database.RoundRobinDistribution
+			}
+			for i, templateProjectID := range bundleAssignment.TemplateVariantIDs {
+				assignment.TemplateVariants = append(assignment.TemplateVariants, &database.AssignmentTemplateVariant{
+					TemplateProjectID: templateProjectID,
+					Position:          i,
+				})
+			}
+			for _, rubricID := range bundleAssignment.RubricIDs {
+				rubric, ok := rubrics[rubricID]
+				if !ok {
+					return fmt.Errorf("assignment %s references unknown rubric %s", bundleAssignment.Name, rubricID)
+				}
+				assignment.GradingManualRubrics = append(assignment.GradingManualRubrics, rubric)
+			}
+
+			if err := tx.Assignment.WithContext(c.Context()).Create(assignment); err != nil {
+				return err
+			}
+
+			if !requestBody.WithResults {
+				continue
+			}
+
+			for _, bundleProject := range bundleAssignment.Projects {
+				team, ok := teams[bundleProject.TeamID]
+				if !ok {
+					return fmt.Errorf("project %d references unknown team %s", bundleProject.ProjectID, bundleProject.TeamID)
+				}
+
+				project := &database.AssignmentProjects{
+					TeamID:                 team.ID,
+					AssignmentID:           assignment.ID,
+					ProjectID:              bundleProject.ProjectID,
+					ProjectStatus:          database.Pending,
+					GradingJUnitTestResult: bundleProject.GradingJUnitTestResult,
+					TemplateProjectID:      bundleProject.TemplateProjectID,
+				}
+				switch bundleProject.ProjectStatus {
+				case string(database.Creating):
+					project.ProjectStatus = database.Creating
+				case string(database.Accepted):
+					project.ProjectStatus = database.Accepted
+				case string(database.Failed):
+					project.ProjectStatus = database.Failed
+				}
+
+				for _, bundleResult := range bundleProject.GradingManualResults {
+					rubric, ok := rubrics[bundleResult.RubricID]
+					if !ok {
+						return fmt.Errorf("project %d references unknown rubric %s", bundleProject.ProjectID, bundleResult.RubricID)
+					}
+					project.GradingManualResults = append(project.GradingManualResults, &database.ManualGradingResult{
+						RubricID: rubric.ID,
+						Score:    bundleResult.Score,
+						Feedback: bundleResult.Feedback,
+					})
+				}
+
+				if err := tx.AssignmentProjects.WithContext(c.Context()).Create(project); err != nil {
+					return err
+				}
+			}
+		}
+
+		if _, err := repo.ChangeGroupDescription(group.ID, utils.CreateClassroomGitlabDescription(classroom, ctrl.config.PublicURL)); err != nil {
+			return err
+		}
+
+		invitation := &database.ClassroomInvitation{
+			Status:      database.ClassroomInvitationAccepted,
+			ClassroomID: classroom.ID,
+			Email:       user.GitlabEmail,
+			ExpiryDate:  time.Now().AddDate(0, 0, 14),
+		}
+		return tx.ClassroomInvitation.WithContext(c.Context()).Create(invitation)
+	})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	c.Set("Location", fmt.Sprintf("/api/v1/classrooms/%s", classroom.ID.String()))
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// importBundleMembers adds the members of the bundle to the classroom and its GitLab group, and the team members to the group of their team.
+// The user IDs of the bundle belong to the exporting instance, so the members are matched with the users of this instance
+// by their GitLab username or email. Members unknown to this instance are invited by mail instead.
+// The GitLab memberships are removed together with the group of the classroom, if the import fails.
+func importBundleMembers(c *fiber.Ctx, tx *query.Query, repo gitlab.Repository, classroom *database.Classroom, owner *database.User, members []utils.BundleMember, teams map[uuid.UUID]*database.Team) error {
+	outbox := mailRepo.NewOutboxRepository(c.Context(), tx, &classroom.ID)
+	imported := map[int]bool{classroom.OwnerID: true}
+	for _, member := range members {
+		user, err := findBundleMember(c, tx, member)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			if member.GitlabEmail == "" {
+				continue
+			}
+
+			invitation := &database.ClassroomInvitation{
+				Status:      database.ClassroomInvitationPending,
+				ClassroomID: classroom.ID,
+				Email:       member.GitlabEmail,
+				ExpiryDate:  time.Now().AddDate(0, 0, 14),
+			}
+			if err := tx.ClassroomInvitation.WithContext(c.Context()).Create(invitation); err != nil {
+				return err
+			}
+
+			err = outbox.SendClassroomInvitation(
+				invitation.Email,
+				fmt.Sprintf(`New Invitation for Classroom "%s"`, classroom.Name),
+				mailRepo.ClassroomInvitationData{
+					InvitationID:       invitation.ID,
+					ClassroomName:      classroom.Name,
+					ClassroomOwnerName: owner.Name,
+					RecipientEmail:     invitation.Email,
+					InvitationPath:     fmt.Sprintf("/classrooms/%s/invitations/%s", classroom.ID.String(), invitation.ID.String()),
+					ExpireDate:         invitation.ExpiryDate,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if imported[user.ID] {
+			continue
+		}
+		imported[user.ID] = true
+
+		userClassroom := &database.UserClassrooms{
+			UserID:      user.ID,
+			ClassroomID: classroom.ID,
+			Role:        member.Role,
+		}
+		// The importing user is the only owner of the classroom
+		if userClassroom.Role == database.Owner {
+			userClassroom.Role = database.Moderator
+		}
+		var team *database.Team
+		if member.TeamID != nil {
+			var ok bool
+			team, ok = teams[*member.TeamID]
+			if !ok {
+				return fmt.Errorf("member %s references unknown team %s", member.GitlabUsername, *member.TeamID)
+			}
+			userClassroom.TeamID = &team.ID
+		}
+
+		if err := tx.UserClassrooms.WithContext(c.Context()).Create(userClassroom); err != nil {
+			return err
+		}
+
+		if err := repo.AddUserToGroup(classroom.GroupID, user.ID, memberAccessLevel(classroom, userClassroom.Role)); err != nil {
+			return err
+		}
+		if team != nil {
+			if err := repo.AddUserToGroup(team.GroupID, user.ID, model.ReporterPermissions); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// memberAccessLevel returns the access level of a member with the role in the GitLab group of the classroom.
+// Students only see the projects of the other teams, if the classroom allows it.
+func memberAccessLevel(classroom *database.Classroom, role database.Role) model.AccessLevelValue {
+	switch {
+	case role == database.Owner:
+		return model.OwnerPermissions
+	case role == database.Moderator || classroom.StudentsViewAllProjects:
+		return model.ReporterPermissions
+	default:
+		return model.GuestPermissions
+	}
+}
+
+// findBundleMember returns the user with the GitLab username or else the email of the member, or nil if there is none.
+func findBundleMember(c *fiber.Ctx, tx *query.Query, member utils.BundleMember) (*database.User, error) {
+	queryUser := tx.User
+	conditions := make([]gen.Condition, 0, 2)
+	if member.GitlabUsername != "" {
+		conditions = append(conditions, queryUser.GitlabUsername.Eq(member.GitlabUsername))
+	}
+	if member.GitlabEmail != "" {
+		conditions = append(conditions, queryUser.GitlabEmail.Eq(member.GitlabEmail))
+	}
+
+	for _, condition := range conditions {
+		user, err := queryUser.WithContext(c.Context()).Where(condition).First()
+		if err == nil {
+			return user, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
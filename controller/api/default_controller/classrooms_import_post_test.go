@@ -0,0 +1,164 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestImportClassroom(t *testing.T) {
+	restoreDatabase(t)
+
+	owner := factory.User()
+	student := factory.User()
+	// other has the user ID, which the unknown member had on the exporting instance
+	other := factory.User()
+
+	teamID := uuid.New()
+	bundle := utils.ClassroomBundle{
+		Version:    utils.ClassroomBundleVersion,
+		ExportedAt: time.Now(),
+		Classroom: utils.BundleClassroom{
+			Name:        "Imported Classroom",
+			Description: "Imported",
+			MaxTeamSize: 2,
+			GroupID:     7,
+		},
+		Teams: []utils.BundleTeam{{ID: teamID, Name: "Team 1", GroupID: 8}},
+		Members: []utils.BundleMember{
+			{UserID: 1000, GitlabUsername: "someone-else", GitlabEmail: "exporter@example.com", Role: database.Owner},
+			{UserID: 1001, GitlabUsername: student.GitlabUsername, GitlabEmail: "other-address@example.com", Role: database.Student, TeamID: &teamID},
+			{UserID: other.ID, GitlabUsername: "unknown", GitlabEmail: "unknown@example.com", Role: database.Student, TeamID: &teamID},
+		},
+	}
+
+	app, gitlabRepo, _ := setupApp(t, owner)
+
+	newImportRequest := func(bundle utils.ClassroomBundle, withResults bool) *http.Request {
+		data, err := json.Marshal(bundle)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("bundle", "classroom.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(data)
+		if withResults {
+			writer.WriteField("withResults", "true")
+		}
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/api/v1/classrooms/import", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("rejects invalid bundles", func(t *testing.T) {
+		resp, err := app.Test(newImportRequest(utils.ClassroomBundle{Version: 99}, false))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("imports members by username or email", func(t *testing.T) {
+		gitlabRepo.
+			EXPECT().
+			CreateGroup(bundle.Classroom.Name, model.Private, bundle.Classroom.Description).
+			Return(&model.Group{ID: 3}, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			CreateGroupAccessToken(3, "GitClassrooms", model.OwnerPermissions, mock.AnythingOfType("time.Time"), "api").
+			Return(&model.GroupAccessToken{ID: 31, Token: "imported-token"}, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			CreateSubGroup("Team 1", "Team 1", 3, model.Private, mock.Anything).
+			Return(&model.Group{ID: 32}, nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			AddUserToGroup(3, student.ID, model.GuestPermissions).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			AddUserToGroup(32, student.ID, model.ReporterPermissions).
+			Return(nil).
+			Times(1)
+
+		gitlabRepo.
+			EXPECT().
+			ChangeGroupDescription(3, mock.Anything).
+			Return(nil, nil).
+			Times(1)
+
+		resp, err := app.Test(newImportRequest(bundle, true))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		gitlabRepo.AssertExpectations(t)
+
+		classroom, err := query.Classroom.
+			WithContext(context.Background()).
+			Preload(query.Classroom.Member).
+			Preload(query.Classroom.Teams).
+			Where(query.Classroom.GroupID.Eq(3)).
+			First()
+		assert.NoError(t, err)
+		assert.True(t, classroom.Archived)
+
+		assert.Len(t, classroom.Teams, 1)
+		assert.Equal(t, 32, classroom.Teams[0].GroupID)
+
+		roles := map[int]database.Role{}
+		for _, member := range classroom.Member {
+			roles[member.UserID] = member.Role
+		}
+		assert.Equal(t, map[int]database.Role{owner.ID: database.Owner, student.ID: database.Student}, roles)
+
+		queryInvitation := query.ClassroomInvitation
+		invitations, err := queryInvitation.
+			WithContext(context.Background()).
+			Where(queryInvitation.ClassroomID.Eq(classroom.ID)).
+			Where(queryInvitation.Status.Eq(uint8(database.ClassroomInvitationPending))).
+			Find()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"exporter@example.com", "unknown@example.com"}, utils.Map(invitations, func(invitation *database.ClassroomInvitation) string {
+			return invitation.Email
+		}))
+
+		queryOutgoingMail := query.OutgoingMail
+		outgoingMails, err := queryOutgoingMail.
+			WithContext(context.Background()).
+			Where(queryOutgoingMail.ClassroomID.Eq(classroom.ID)).
+			Where(queryOutgoingMail.Kind.Eq(string(database.ClassroomInvitationMail))).
+			Find()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"exporter@example.com", "unknown@example.com"}, utils.Map(outgoingMails, func(outgoingMail *database.OutgoingMail) string {
+			return outgoingMail.Recipient
+		}))
+	})
+}
@@ -1,11 +1,15 @@
 package api
 
 import (
+	"sync"
+
 	"gitlab.hs-flensburg.de/gitlab-classroom/config"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	"golang.org/x/sync/singleflight"
 
 	"github.com/google/uuid"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
 	mailRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
 )
 
@@ -16,17 +20,33 @@ type Params struct {
 	MemberID            *int       `params:"memberId"`
 	TeamID              *uuid.UUID `params:"teamId"`
 	InvitationID        *uuid.UUID `params:"invitationId"`
+	MailID              *uuid.UUID `params:"mailId"`
+	RunnerID            *int       `params:"runnerId"`
+	TemplateProjectID   *int       `params:"templateProjectId"`
+	TokenID             *uuid.UUID `params:"tokenId"`
+	WebhookID           *uuid.UUID `params:"webhookId"`
+	DeliveryID          *uuid.UUID `params:"deliveryId"`
+	RunID               *uuid.UUID `params:"runId"`
 }
 
 type DefaultController struct {
-	config   config.ApplicationConfig
-	mailRepo mailRepo.Repository
-	g        *singleflight.Group
+	config  config.ApplicationConfig
+	mailbox *mailRepo.MemoryMailbox
+	g       *singleflight.Group
+	// pipelines caches the latest pipeline per GitLab project
+	pipelines *utils.TTLCache[int, *projectPipeline]
+	// broker streams the live events to the users
+	broker *events.Broker
+	// gradingRuns holds the cancel functions of the grading runs processed by this instance
+	gradingRuns sync.Map
 }
 
-func NewApiV1Controller(mailRepo mailRepo.Repository, config config.ApplicationConfig) *DefaultController {
+// NewApiV1Controller creates the controller of the API.
+// The mailbox is only given with the memory mail transport in dev mode and enables the dev mail endpoints.
+func NewApiV1Controller(config config.ApplicationConfig, mailbox *mailRepo.MemoryMailbox, broker *events.Broker) *DefaultController {
 	g := &singleflight.Group{}
-	return &DefaultController{mailRepo: mailRepo, config: config, g: g}
+	pipelines := utils.NewTTLCache[int, *projectPipeline](pipelineCacheTTL)
+	return &DefaultController{config: config, mailbox: mailbox, g: g, pipelines: pipelines, broker: broker}
 }
 
 type UserResponse struct {
@@ -16,9 +16,11 @@ import (
 
 	"gitlab.hs-flensburg.de/gitlab-classroom/config"
 	"gitlab.hs-flensburg.de/gitlab-classroom/config/auth"
+	gitlabConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/gitlab"
 	authController "gitlab.hs-flensburg.de/gitlab-classroom/controller/auth"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
 	gitlabRepoMock "gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/_mock"
 	mailRepoMock "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail/_mock"
 	"gitlab.hs-flensburg.de/gitlab-classroom/router"
@@ -29,6 +31,7 @@ import (
 )
 
 const testUrl = "http://example.com"
+const testGitlabUrl = "https://gitlab.example.com"
 
 type IntegrationTest struct {
 	dbURL        string
@@ -139,7 +142,7 @@ func setupApp(t *testing.T, user *database.User) (*fiber.App, *gitlabRepoMock.Mo
 
 	app := fiber.New()
 
-	apiController := NewApiV1Controller(mailRepo, config.ApplicationConfig{PublicURL: integrationTest.publicUrl})
+	apiController := NewApiV1Controller(config.ApplicationConfig{PublicURL: integrationTest.publicUrl, GitLab: &gitlabConfig.GitlabConfig{URL: testGitlabUrl}, Auth: &auth.OAuthConfig{TokenKey: "test"}}, nil, events.NewBroker())
 	authCtrl := authController.NewTestAuthController(user, gitlabRepo)
 
 	router.Routes(app, authCtrl, apiController, "public", &auth.OAuthConfig{RedirectURL: integrationTest.publicUrl})
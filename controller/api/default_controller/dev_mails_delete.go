@@ -0,0 +1,24 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary		DeleteDevMails
+// @Description	Delete the mails kept in memory. Only available in dev mode with the memory mail transport.
+// @Id				DeleteDevMails
+// @Tags			dev
+// @Param			X-Csrf-Token	header	string	true	"Csrf-Token"
+// @Success		204
+// @Failure		401	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/dev/mails [delete]
+func (ctrl *DefaultController) DeleteDevMails(c *fiber.Ctx) error {
+	if ctrl.mailbox == nil {
+		return fiber.NewError(fiber.StatusNotFound, "The dev mode or the memory mail transport is not enabled")
+	}
+
+	ctrl.mailbox.Clear()
+	return c.SendStatus(fiber.StatusNoContent)
+}
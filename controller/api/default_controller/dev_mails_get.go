@@ -0,0 +1,23 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary		GetDevMails
+// @Description	Get the mails kept in memory, oldest first. Only available in dev mode with the memory mail transport.
+// @Id				GetDevMails
+// @Tags			dev
+// @Produce		json
+// @Success		200	{array}		MailMessage
+// @Failure		401	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/dev/mails [get]
+func (ctrl *DefaultController) GetDevMails(c *fiber.Ctx) error {
+	if ctrl.mailbox == nil {
+		return fiber.NewError(fiber.StatusNotFound, "The dev mode or the memory mail transport is not enabled")
+	}
+
+	return c.JSON(ctrl.mailbox.Messages())
+}
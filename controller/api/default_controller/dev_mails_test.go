@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/config"
+	mailConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
+	mailRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+)
+
+func TestDevMails(t *testing.T) {
+	restoreDatabase(t)
+
+	repo, err := mailRepo.NewMailRepository(integrationTest.publicUrl, &mailConfig.MailConfig{
+		FromName:    "GitClassrooms",
+		FromAddress: "classroom@example.com",
+		Transport:   mailConfig.MemoryTransport,
+	})
+	assert.NoError(t, err)
+
+	mailbox, ok := repo.Mailbox()
+	assert.True(t, ok)
+
+	err = repo.SendClassroomRemovalNotification("student@example.com", "Removed", mailRepo.ClassroomRemovalData{ClassroomName: "Classroom"})
+	assert.NoError(t, err)
+
+	app := fiber.New()
+	handler := NewApiV1Controller(config.ApplicationConfig{}, mailbox, events.NewBroker())
+	app.Get("/api/v1/dev/mails", handler.GetDevMails)
+	app.Delete("/api/v1/dev/mails", handler.DeleteDevMails)
+
+	t.Run("lists mails", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/dev/mails", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var messages []*mailRepo.Message
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&messages))
+		assert.Len(t, messages, 1)
+		assert.Equal(t, "student@example.com", messages[0].To)
+	})
+
+	t.Run("deletes mails", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/api/v1/dev/mails", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+		assert.Empty(t, mailbox.Messages())
+	})
+
+	t.Run("not available without memory transport", func(t *testing.T) {
+		app := fiber.New()
+		handler := NewApiV1Controller(config.ApplicationConfig{}, nil, events.NewBroker())
+		app.Get("/api/v1/dev/mails", handler.GetDevMails)
+
+		req := httptest.NewRequest("GET", "/api/v1/dev/mails", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
+)
+
+// eventStreamKeepAlive is the interval of the comments, which keep idle streams open behind proxies
+const eventStreamKeepAlive = 15 * time.Second
+
+// streamEvents streams the events matching the filter as server-sent events until the client disconnects.
+// Events published while the client reconnects are not replayed, so the client should reload its data after a reconnect.
+func (ctrl *DefaultController) streamEvents(c *fiber.Ctx, filter events.Filter) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // Disable the response buffering of nginx
+
+	subscription := ctrl.broker.Subscribe(filter)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer ctrl.broker.Unsubscribe(subscription)
+
+		ticker := time.NewTicker(eventStreamKeepAlive)
+		defer ticker.Stop()
+
+		// Send the headers right away, so the client knows that the stream is open
+		fmt.Fprint(w, ": connected\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-subscription.Events():
+				if !ok {
+					return
+				}
+				if err := events.Write(w, event); err != nil {
+					return
+				}
+			case <-ticker.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+			}
+
+			// Flush fails after the client disconnected
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
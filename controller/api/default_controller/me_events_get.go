@@ -0,0 +1,19 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		Stream your live events
+// @Description	Stream the events concerning you as server-sent events, e.g. the creation steps of the projects of your teams and changes of the sync with GitLab.
+// @Id				GetMeEvents
+// @Tags			auth
+// @Produce		text/event-stream
+// @Success		200	{object}	events.Event
+// @Failure		401	{object}	HTTPError
+// @Router			/api/v1/me/events [get]
+func (ctrl *DefaultController) GetMeEvents(c *fiber.Ctx) error {
+	return ctrl.streamEvents(c, events.ForUser(context.Get(c).GetUserID()))
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		Show your notification preferences
+// @Description	Get the notification mode for every category. Categories without a preference are sent immediately.
+// @Id				GetMeNotifications
+// @Tags			auth
+// @Produce		json
+// @Success		200	{array}		database.NotificationPreference
+// @Failure		401	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/me/notifications [get]
+func (ctrl *DefaultController) GetMeNotifications(c *fiber.Ctx) error {
+	queryPreference := query.NotificationPreference
+	preferences, err := queryPreference.
+		WithContext(c.Context()).
+		Where(queryPreference.UserID.Eq(context.Get(c).GetUserID())).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	modes := make(map[database.NotificationCategory]database.NotificationMode, len(preferences))
+	for _, preference := range preferences {
+		modes[preference.Category] = preference.Mode
+	}
+
+	response := make([]*database.NotificationPreference, len(database.NotificationCategories))
+	for i, category := range database.NotificationCategories {
+		mode, ok := modes[category]
+		if !ok {
+			mode = database.ImmediateMode
+		}
+		response[i] = &database.NotificationPreference{Category: category, Mode: mode}
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gorm.io/gorm/clause"
+)
+
+type notificationPreferenceRequest struct {
+	Category database.NotificationCategory `json:"category"`
+	Mode     database.NotificationMode     `json:"mode"`
+} //@Name NotificationPreferenceRequest
+
+type updateNotificationPreferencesRequest struct {
+	Preferences []notificationPreferenceRequest `json:"preferences"`
+} //@Name UpdateNotificationPreferencesRequest
+
+func (r updateNotificationPreferencesRequest) isValid() bool {
+	seen := make(map[database.NotificationCategory]bool, len(r.Preferences))
+	for _, preference := range r.Preferences {
+		if !slices.Contains(database.NotificationCategories, preference.Category) {
+			return false
+		}
+		// A category can only be upserted once per statement
+		if seen[preference.Category] {
+			return false
+		}
+		seen[preference.Category] = true
+		if preference.Mode != database.ImmediateMode &&
+			preference.Mode != database.DigestMode &&
+			preference.Mode != database.OffMode {
+			return false
+		}
+	}
+	return len(r.Preferences) > 0
+}
+
+// @Summary		Update your notification preferences
+// @Description	Set the notification mode for the given categories. Categories which are not part of the request keep their mode.
+// @Id				UpdateMeNotifications
+// @Tags			auth
+// @Accept			json
+// @Param			preferences		body	api.updateNotificationPreferencesRequest	true	"Notification preferences"
+// @Param			X-Csrf-Token	header	string										true	"Csrf-Token"
+// @Success		202
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/me/notifications [put]
+func (ctrl *DefaultController) UpdateMeNotifications(c *fiber.Ctx) (err error) {
+	userID := context.Get(c).GetUserID()
+
+	var requestBody updateNotificationPreferencesRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	preferences := make([]*database.NotificationPreference, len(requestBody.Preferences))
+	for i, preference := range requestBody.Preferences {
+		preferences[i] = &database.NotificationPreference{
+			UserID:   userID,
+			Category: preference.Category,
+			Mode:     preference.Mode,
+		}
+	}
+
+	queryPreference := query.NotificationPreference
+	err = queryPreference.
+		WithContext(c.Context()).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: queryPreference.UserID.ColumnName().String()}, {Name: queryPreference.Category.ColumnName().String()}},
+			DoUpdates: clause.AssignmentColumns([]string{queryPreference.Mode.ColumnName().String(), queryPreference.UpdatedAt.ColumnName().String()}),
+		}).
+		Create(preferences...)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestMeNotifications(t *testing.T) {
+	restoreDatabase(t)
+
+	user := factory.User()
+
+	app, _, _ := setupApp(t, user)
+	route := "/api/v1/me/notifications"
+
+	getPreferences := func(t *testing.T) map[database.NotificationCategory]database.NotificationMode {
+		req := httptest.NewRequest("GET", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var preferences []*database.NotificationPreference
+		err = json.NewDecoder(resp.Body).Decode(&preferences)
+		assert.NoError(t, err)
+
+		modes := make(map[database.NotificationCategory]database.NotificationMode)
+		for _, preference := range preferences {
+			modes[preference.Category] = preference.Mode
+		}
+		return modes
+	}
+
+	t.Run("defaults to immediate", func(t *testing.T) {
+		modes := getPreferences(t)
+
+		assert.Len(t, modes, len(database.NotificationCategories))
+		for _, category := range database.NotificationCategories {
+			assert.Equal(t, database.ImmediateMode, modes[category])
+		}
+	})
+
+	t.Run("updates preferences", func(t *testing.T) {
+		requestBody := updateNotificationPreferencesRequest{
+			Preferences: []notificationPreferenceRequest{
+				{Category: database.NewAssignmentsCategory, Mode: database.DigestMode},
+				{Category: database.TeamChangesCategory, Mode: database.OffMode},
+			},
+		}
+
+		req := newPutJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		requestBody = updateNotificationPreferencesRequest{
+			Preferences: []notificationPreferenceRequest{
+				{Category: database.NewAssignmentsCategory, Mode: database.ImmediateMode},
+			},
+		}
+
+		req = newPutJsonRequest(route, requestBody)
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		modes := getPreferences(t)
+		assert.Equal(t, database.ImmediateMode, modes[database.NewAssignmentsCategory])
+		assert.Equal(t, database.OffMode, modes[database.TeamChangesCategory])
+		assert.Equal(t, database.ImmediateMode, modes[database.InvitationsCategory])
+	})
+
+	t.Run("rejects unknown category", func(t *testing.T) {
+		requestBody := updateNotificationPreferencesRequest{
+			Preferences: []notificationPreferenceRequest{
+				{Category: "unknown", Mode: database.OffMode},
+			},
+		}
+
+		req := newPutJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects duplicate category", func(t *testing.T) {
+		requestBody := updateNotificationPreferencesRequest{
+			Preferences: []notificationPreferenceRequest{
+				{Category: database.InvitationsCategory, Mode: database.OffMode},
+				{Category: database.InvitationsCategory, Mode: database.DigestMode},
+			},
+		}
+
+		req := newPutJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects unknown mode", func(t *testing.T) {
+		requestBody := updateNotificationPreferencesRequest{
+			Preferences: []notificationPreferenceRequest{
+				{Category: database.InvitationsCategory, Mode: "weekly"},
+			},
+		}
+
+		req := newPutJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
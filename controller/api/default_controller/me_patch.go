@@ -0,0 +1,52 @@
+package api
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	mailRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+type updateMeRequest struct {
+	Language *string `json:"language" validate:"optional"`
+} //@Name UpdateMeRequest
+
+func (r updateMeRequest) isValid() bool {
+	return r.Language == nil || slices.Contains(mailRepo.SupportedLanguages, *r.Language)
+}
+
+// @Summary		Update your user account
+// @Description	Update the settings of your user account. The language is used for the mails sent to you, supported languages are "en" and "de".
+// @Id				UpdateMe
+// @Tags			auth
+// @Accept			json
+// @Param			user			body	api.updateMeRequest	true	"User settings"
+// @Param			X-Csrf-Token	header	string				true	"Csrf-Token"
+// @Success		202
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/me [patch]
+func (ctrl *DefaultController) UpdateMe(c *fiber.Ctx) (err error) {
+	var requestBody updateMeRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	queryUser := query.User
+	_, err = queryUser.
+		WithContext(c.Context()).
+		Where(queryUser.ID.Eq(context.Get(c).GetUserID())).
+		Update(queryUser.Language, requestBody.Language)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
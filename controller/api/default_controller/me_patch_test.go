@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestPatchMe(t *testing.T) {
+	restoreDatabase(t)
+
+	user := factory.User()
+
+	app, _, _ := setupApp(t, user)
+	route := "/api/v1/me"
+
+	t.Run("updates language", func(t *testing.T) {
+		language := "de"
+		req := newJsonRequest(route, updateMeRequest{Language: &language}, "PATCH")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		userAfter, err := query.User.WithContext(context.Background()).Where(query.User.ID.Eq(user.ID)).First()
+		assert.NoError(t, err)
+		assert.Equal(t, "de", *userAfter.Language)
+	})
+
+	t.Run("resets language", func(t *testing.T) {
+		req := newJsonRequest(route, updateMeRequest{}, "PATCH")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		userAfter, err := query.User.WithContext(context.Background()).Where(query.User.ID.Eq(user.ID)).First()
+		assert.NoError(t, err)
+		assert.Nil(t, userAfter.Language)
+	})
+
+	t.Run("rejects unsupported language", func(t *testing.T) {
+		language := "fr"
+		req := newJsonRequest(route, updateMeRequest{Language: &language}, "PATCH")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
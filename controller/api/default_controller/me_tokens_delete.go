@@ -0,0 +1,52 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	authController "gitlab.hs-flensburg.de/gitlab-classroom/controller/auth"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		Revoke an API token
+// @Description	Delete one of your personal API tokens, requests with the token are rejected afterwards. The stored GitLab token is removed with your last active API token.
+// @Id				DeleteMeToken
+// @Tags			auth
+// @Param			tokenId			path	string	true	"API Token ID"	Format(uuid)
+// @Param			X-Csrf-Token	header	string	true	"Csrf-Token"
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/me/tokens/{tokenId} [delete]
+func (ctrl *DefaultController) DeleteMeToken(c *fiber.Ctx) (err error) {
+	var params Params
+	if err = c.ParamsParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if params.TokenID == nil {
+		return fiber.ErrBadRequest
+	}
+
+	userID := context.Get(c).GetUserID()
+
+	queryToken := query.APIToken
+	result, err := queryToken.
+		WithContext(c.Context()).
+		Where(queryToken.ID.Eq(*params.TokenID)).
+		Where(queryToken.UserID.Eq(userID)).
+		Delete()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if result.RowsAffected == 0 {
+		return fiber.ErrNotFound
+	}
+
+	if err = authController.ClearUnusedGitlabToken(c.Context(), userID); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
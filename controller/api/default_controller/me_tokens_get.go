@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+// @Summary		Show your API tokens
+// @Description	Get your personal API tokens. The tokens themselves are only shown when they are created.
+// @Id				GetMeTokens
+// @Tags			auth
+// @Produce		json
+// @Success		200	{array}		database.APIToken
+// @Failure		401	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/api/v1/me/tokens [get]
+func (ctrl *DefaultController) GetMeTokens(c *fiber.Ctx) (err error) {
+	var tokens []*database.APIToken
+
+	queryToken := query.APIToken
+	tokens, err = queryToken.
+		WithContext(c.Context()).
+		Where(queryToken.UserID.Eq(context.Get(c).GetUserID())).
+		Order(queryToken.CreatedAt.Desc()).
+		Find()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(tokens)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	authController "gitlab.hs-flensburg.de/gitlab-classroom/controller/auth"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"gitlab.hs-flensburg.de/gitlab-classroom/wrapper/session"
+)
+
+// maxAPITokenLifetime limits the expiry of the API tokens
+const maxAPITokenLifetime = 365 * 24 * time.Hour
+
+type createAPITokenRequest struct {
+	Name   string                   `json:"name"`
+	Scopes []database.APITokenScope `json:"scopes"`
+	// ClassroomID restricts the token to a classroom, it is required for the classroomAdmin scope
+	ClassroomID *uuid.UUID `json:"classroomId" validate:"optional"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+} //@Name CreateAPITokenRequest
+
+func (r createAPITokenRequest) isValid() bool {
+	if r.Name == "" || len(r.Scopes) == 0 {
+		return false
+	}
+	for _, scope := range r.Scopes {
+		if !slices.Contains(database.APITokenScopes, scope) {
+			return false
+		}
+	}
+	if slices.Contains(r.Scopes, database.ClassroomAdminScope) && r.ClassroomID == nil {
+		return false
+	}
+	return r.ExpiresAt.After(time.Now()) && r.ExpiresAt.Before(time.Now().Add(maxAPITokenLifetime))
+}
+
+type CreateAPITokenResponse struct {
+	*database.APIToken
+	// Token is only shown once, send it as "Authorization: Bearer <token>" header
+	Token string `json:"token"`
+} //@Name CreateAPITokenResponse
+
+// @Summary		Create an API token
+// @Description	Create a personal API token to access the API from scripts. Requests with the header "Authorization: Bearer <token>" need no session and no csrf token and act as you with your GitLab account.
+// @Description	Every scope allows reading, the grading scope allows to change the gradings and to start the autograding, the classroomAdmin scope allows all requests to the classroom of the token. The token never grants more than your role in the classroom.
+// @Id				CreateMeToken
+// @Tags			auth
+// @Accept			json
+// @Produce		json
+// @Param			token			body		api.createAPITokenRequest	true	"API token"
+// @Param			X-Csrf-Token	header		string						true	"Csrf-Token"
+// @Success		201				{object}	api.CreateAPITokenResponse
+// @Failure		400				{object}	HTTPError
+// @Failure		401				{object}	HTTPError
+// @Failure		403				{object}	HTTPError
+// @Failure		500				{object}	HTTPError
+// @Router			/api/v1/me/tokens [post]
+func (ctrl *DefaultController) CreateMeToken(c *fiber.Ctx) (err error) {
+	userID := context.Get(c).GetUserID()
+
+	var requestBody createAPITokenRequest
+	if err = c.BodyParser(&requestBody); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if !requestBody.isValid() {
+		return fiber.ErrBadRequest
+	}
+
+	if requestBody.ClassroomID != nil {
+		queryUserClassroom := query.UserClassrooms
+		count, err := queryUserClassroom.
+			WithContext(c.Context()).
+			Where(queryUserClassroom.UserID.Eq(userID)).
+			Where(queryUserClassroom.ClassroomID.Eq(*requestBody.ClassroomID)).
+			Count()
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if count == 0 {
+			return fiber.NewError(fiber.StatusForbidden, "You are not a member of the classroom")
+		}
+	}
+
+	secret, err := utils.NewAPIToken()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	token := &database.APIToken{
+		UserID:      userID,
+		Name:        requestBody.Name,
+		TokenHash:   utils.HashAPIToken(secret),
+		Scopes:      requestBody.Scopes,
+		ClassroomID: requestBody.ClassroomID,
+		ExpiresAt:   requestBody.ExpiresAt,
+	}
+	if err = query.APIToken.WithContext(c.Context()).Create(token); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	// Requests with API tokens act with the GitLab token of the session, it is kept up to date on every sign in and refresh
+	if gitlabToken, err := session.Get(c).GetGitlabOauth2Token(); err == nil {
+		if err = authController.StoreGitlabToken(c.Context(), ctrl.config.Auth.GetTokenKey(), userID, gitlabToken); err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	c.Status(fiber.StatusCreated)
+	return c.JSON(CreateAPITokenResponse{APIToken: token, Token: secret})
+}
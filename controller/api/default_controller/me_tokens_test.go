@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+)
+
+func TestMeTokens(t *testing.T) {
+	restoreDatabase(t)
+
+	user := factory.User()
+	classroom := factory.Classroom(user.ID)
+	factory.UserClassroom(user.ID, classroom.ID, database.Owner)
+
+	app, _, _ := setupApp(t, user)
+	route := "/api/v1/me/tokens"
+
+	getTokens := func(t *testing.T) []*database.APIToken {
+		req := httptest.NewRequest("GET", route, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var tokens []*database.APIToken
+		err = json.NewDecoder(resp.Body).Decode(&tokens)
+		assert.NoError(t, err)
+		return tokens
+	}
+
+	t.Run("creates, lists and revokes a token", func(t *testing.T) {
+		requestBody := createAPITokenRequest{
+			Name:        "Nightly report",
+			Scopes:      []database.APITokenScope{database.ReadScope, database.ClassroomAdminScope},
+			ClassroomID: &classroom.ID,
+			ExpiresAt:   time.Now().Add(30 * 24 * time.Hour),
+		}
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var created CreateAPITokenResponse
+		err = json.NewDecoder(resp.Body).Decode(&created)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Token, utils.APITokenPrefix))
+		assert.Equal(t, "Nightly report", created.Name)
+
+		tokens := getTokens(t)
+		assert.Len(t, tokens, 1)
+		assert.Equal(t, created.ID, tokens[0].ID)
+		assert.Equal(t, requestBody.Scopes, tokens[0].Scopes)
+
+		queryUser := query.User
+		_, err = queryUser.
+			WithContext(context.Background()).
+			Where(queryUser.ID.Eq(user.ID)).
+			Updates(&database.User{GitlabAccessToken: utils.Ptr("access"), GitlabRefreshToken: utils.Ptr("refresh"), GitlabTokenExpiry: utils.Ptr(time.Now())})
+		assert.NoError(t, err)
+
+		req = httptest.NewRequest("DELETE", route+"/"+created.ID.String(), nil)
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+		assert.Empty(t, getTokens(t))
+
+		// The GitLab token is removed with the last API token
+		stored, err := queryUser.WithContext(context.Background()).Where(queryUser.ID.Eq(user.ID)).First()
+		assert.NoError(t, err)
+		assert.Nil(t, stored.GitlabAccessToken)
+		assert.Nil(t, stored.GitlabRefreshToken)
+		assert.Nil(t, stored.GitlabTokenExpiry)
+	})
+
+	t.Run("rejects unknown scope", func(t *testing.T) {
+		requestBody := createAPITokenRequest{
+			Name:      "Token",
+			Scopes:    []database.APITokenScope{"write"},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects classroom admin scope without classroom", func(t *testing.T) {
+		requestBody := createAPITokenRequest{
+			Name:      "Token",
+			Scopes:    []database.APITokenScope{database.ClassroomAdminScope},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects expired token", func(t *testing.T) {
+		requestBody := createAPITokenRequest{
+			Name:      "Token",
+			Scopes:    []database.APITokenScope{database.ReadScope},
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("rejects classroom of other users", func(t *testing.T) {
+		otherClassroom := factory.Classroom(factory.User().ID)
+
+		requestBody := createAPITokenRequest{
+			Name:        "Token",
+			Scopes:      []database.APITokenScope{database.ReadScope},
+			ClassroomID: &otherClassroom.ID,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+
+		req := newPostJsonRequest(route, requestBody)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("revoking unknown token", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", route+"/"+uuid.NewString(), nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+type paginationQuery struct {
+	Page    int `query:"page"`
+	PerPage int `query:"perPage"`
+}
+
+// isPaginated is false if neither the page nor the page size is requested, lists are returned completely then.
+func (q paginationQuery) isPaginated() bool {
+	return q.Page != 0 || q.PerPage != 0
+}
+
+// normalize applies the defaults and limits to the page and the page size.
+func (q *paginationQuery) normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PerPage < 1 {
+		q.PerPage = defaultPerPage
+	}
+	if q.PerPage > maxPerPage {
+		q.PerPage = maxPerPage
+	}
+}
+
+// setPaginationHeaders sets the X-Total header and the Link header with the first, prev, next and last page of the request.
+func setPaginationHeaders(c *fiber.Ctx, pagination paginationQuery, total int) {
+	c.Set("X-Total", strconv.Itoa(total))
+
+	lastPage := max(1, (total+pagination.PerPage-1)/pagination.PerPage)
+
+	requestURL, err := url.Parse(c.OriginalURL())
+	if err != nil {
+		return
+	}
+
+	pageURL := func(page int) string {
+		values := requestURL.Query()
+		values.Set("page", strconv.Itoa(page))
+		values.Set("perPage", strconv.Itoa(pagination.PerPage))
+		return fmt.Sprintf("<%s?%s>", requestURL.Path, values.Encode())
+	}
+
+	links := []string{pageURL(1) + `; rel="first"`}
+	if pagination.Page > 1 {
+		links = append(links, pageURL(min(pagination.Page-1, lastPage))+`; rel="prev"`)
+	}
+	if pagination.Page < lastPage {
+		links = append(links, pageURL(pagination.Page+1)+`; rel="next"`)
+	}
+	links = append(links, pageURL(lastPage)+`; rel="last"`)
+
+	c.Set(fiber.HeaderLink, strings.Join(links, ", "))
+}
+
+// listQuery are the query parameters shared by the list endpoints.
+type listQuery struct {
+	paginationQuery
+	// Sort is the field to sort by, prefixed with "-" for descending order
+	Sort   string `query:"sort"`
+	Search string `query:"search"`
+}
+
+// orderBy returns the order for the sort field of the query or the default order if no field is requested.
+// It returns false if the field is not one of the sortable columns.
+func (q listQuery) orderBy(columns map[string]field.OrderExpr, defaultOrder ...field.Expr) ([]field.Expr, bool) {
+	if q.Sort == "" {
+		return defaultOrder, true
+	}
+
+	column, ok := columns[strings.TrimPrefix(q.Sort, "-")]
+	if !ok {
+		return nil, false
+	}
+
+	if strings.HasPrefix(q.Sort, "-") {
+		return []field.Expr{column.Desc()}, true
+	}
+	return []field.Expr{column}, true
+}
+
+// containsIgnoreCase matches the rows where any of the columns contains the search term, ignoring the case.
+func containsIgnoreCase(search string, columns ...field.String) gen.Condition {
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(search)
+	pattern := "%" + escaped + "%"
+
+	expressions := make([]clause.Expression, len(columns))
+	for i, column := range columns {
+		expressions[i] = clause.Expr{SQL: "? ILIKE ?", Vars: []any{column.RawExpr(), pattern}}
+	}
+	return gen.Cond(clause.Or(expressions...))[0]
+}
+
+type pageFinder[T any] interface {
+	Find() ([]*T, error)
+	FindByPage(offset int, limit int) ([]*T, int64, error)
+}
+
+// findPage finds the requested page of the query and sets the pagination headers.
+// Without pagination all rows are returned and only the X-Total header is set.
+func findPage[T any](c *fiber.Ctx, dbQuery pageFinder[T], pagination paginationQuery) ([]*T, error) {
+	if !pagination.isPaginated() {
+		result, err := dbQuery.Find()
+		if err != nil {
+			return nil, err
+		}
+
+		c.Set("X-Total", strconv.Itoa(len(result)))
+		return result, nil
+	}
+
+	pagination.normalize()
+	result, total, err := dbQuery.FindByPage((pagination.Page-1)*pagination.PerPage, pagination.PerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	setPaginationHeaders(c, pagination, int(total))
+	return result, nil
+}
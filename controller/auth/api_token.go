@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	gitlabRepo "gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	fiberContext "gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+// tokenRefreshMargin is the time before the expiry of a GitLab token, in which the token is refreshed
+const tokenRefreshMargin = 20 * time.Minute
+
+func expiresSoon(token *oauth2.Token) bool {
+	return token.Expiry.Before(time.Now().Add(tokenRefreshMargin))
+}
+
+// apiTokenMiddleware authenticates a request with a personal API token. The request acts with the stored GitLab token of the user.
+func (ctrl *OAuthController) apiTokenMiddleware(c *fiber.Ctx, secret string) error {
+	queryToken := query.APIToken
+	token, err := queryToken.
+		WithContext(c.Context()).
+		Where(queryToken.TokenHash.Eq(utils.HashAPIToken(secret))).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusUnauthorized, "API token is not valid")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if token.ExpiresAt.Before(time.Now()) {
+		return fiber.NewError(fiber.StatusUnauthorized, "API token is expired")
+	}
+
+	gitlabToken, err := ctrl.userGitlabToken(c.Context(), token.UserID)
+	if err != nil {
+		return err
+	}
+
+	repo := gitlabRepo.NewGitlabRepo(ctrl.gitlabConfig)
+	if err := repo.Login(gitlabToken.AccessToken); err != nil {
+		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+	}
+
+	if _, err := queryToken.
+		WithContext(c.Context()).
+		Where(queryToken.ID.Eq(token.ID)).
+		Update(queryToken.LastUsedAt, time.Now()); err != nil {
+		log.Println(err)
+	}
+
+	ctx := fiberContext.Get(c)
+	ctx.SetGitlabRepository(repo)
+	ctx.SetUserID(token.UserID)
+	ctx.SetAPIToken(token)
+	return ctx.Next()
+}
+
+// APITokenScopeMiddleware checks the scopes of the API token against the matched route, requests with a session are always allowed.
+// It has to be registered as the first handler of every route, because only route handlers know the route, which the request matched.
+func (ctrl *OAuthController) APITokenScopeMiddleware(c *fiber.Ctx) error {
+	ctx := fiberContext.Get(c)
+	token := ctx.GetAPIToken()
+	if token == nil {
+		return c.Next()
+	}
+
+	if !apiTokenAllows(token, c.Method(), c.Route().Path, c.Params("classroomId")) {
+		return fiber.NewError(fiber.StatusForbidden, "The scopes of the API token do not allow this request")
+	}
+	return c.Next()
+}
+
+// gradingRoutes are the routes, which change the grading of projects and are allowed by GradingScope
+var gradingRoutes = []string{
+	"/classrooms/:classroomId/assignments/:assignmentId/grading/auto",
+	"/classrooms/:classroomId/assignments/:assignmentId/grading/runs/:runId/cancel",
+	"/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading",
+	"/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading/auto",
+	"/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading/comments",
+}
+
+// apiTokenAllows checks if the scopes of the token allow the request.
+// Every scope allows reading, GradingScope allows the gradingRoutes and ClassroomAdminScope allows all requests to its classroom.
+// A token restricted to a classroom can not access other classrooms and the tokens can never manage API tokens.
+// The route is the registered path of the matched route and classroomID the value of its classroomId parameter.
+func apiTokenAllows(token *database.APIToken, method string, route string, classroomID string) bool {
+	route = strings.TrimPrefix(route, "/api/v1")
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+
+	if len(segments) >= 2 && segments[0] == "me" && segments[1] == "tokens" {
+		return false
+	}
+
+	classroomPath := len(segments) >= 2 && segments[0] == "classrooms" && segments[1] == ":classroomId"
+	if token.ClassroomID != nil {
+		ownClassroom := classroomPath && classroomID == token.ClassroomID.String()
+		if !ownClassroom && segments[0] != "me" && segments[0] != "auth" {
+			return false
+		}
+	}
+
+	if method == fiber.MethodGet || method == fiber.MethodHead {
+		return len(token.Scopes) > 0
+	}
+
+	if slices.Contains(token.Scopes, database.ClassroomAdminScope) && token.ClassroomID != nil && classroomPath {
+		return true
+	}
+
+	return slices.Contains(token.Scopes, database.GradingScope) && slices.Contains(gradingRoutes, route)
+}
+
+// userGitlabToken returns the stored GitLab token of the user and refreshes it if it expires soon.
+func (ctrl *OAuthController) userGitlabToken(ctx context.Context, userID int) (*oauth2.Token, error) {
+	token, err := storedGitlabToken(ctx, ctrl.tokenKey, userID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if token == nil {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "The user has to sign in again before using API tokens")
+	}
+	if !expiresSoon(token) {
+		return token, nil
+	}
+
+	// The same key as the refresh of the session, so the refresh token is used only once
+	_, err, _ = ctrl.g.Do(fmt.Sprintf("%d", userID), func() (interface{}, error) {
+		// Another request may have refreshed the token in the meantime
+		token, err := storedGitlabToken(ctx, ctrl.tokenKey, userID)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if token == nil || !expiresSoon(token) {
+			return nil, nil
+		}
+
+		// Set expiry to past to force refresh
+		token.Expiry = time.Now().Add(-1 * time.Minute)
+
+		newToken, err := ctrl.authConfig.TokenSource(ctx, token).Token()
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusUnauthorized, err.Error())
+		}
+
+		if err = StoreGitlabToken(ctx, ctrl.tokenKey, userID, newToken); err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token, err = storedGitlabToken(ctx, ctrl.tokenKey, userID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if token == nil {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "The user has to sign in again before using API tokens")
+	}
+	return token, nil
+}
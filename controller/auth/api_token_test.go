@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	fiberContext "gitlab.hs-flensburg.de/gitlab-classroom/wrapper/context"
+)
+
+func TestApiTokenAllows(t *testing.T) {
+	classroomID := uuid.New()
+
+	gradingRoute := "/api/v1/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading"
+
+	tests := []struct {
+		name        string
+		token       *database.APIToken
+		method      string
+		route       string
+		classroomID uuid.UUID
+		allowed     bool
+	}{
+		{
+			name:    "read scope reads",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.ReadScope}},
+			method:  fiber.MethodGet,
+			route:   "/api/v1/classrooms/:classroomId/grading/report",
+			allowed: true,
+		},
+		{
+			name:    "read scope can not write",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.ReadScope}},
+			method:  fiber.MethodPut,
+			route:   gradingRoute,
+			allowed: false,
+		},
+		{
+			name:    "grading scope grades",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.GradingScope}},
+			method:  fiber.MethodPut,
+			route:   gradingRoute,
+			allowed: true,
+		},
+		{
+			name:    "grading scope starts the autograding",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.GradingScope}},
+			method:  fiber.MethodPost,
+			route:   "/api/v1/classrooms/:classroomId/assignments/:assignmentId/grading/auto",
+			allowed: true,
+		},
+		{
+			name:    "grading scope can not change the classroom",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.GradingScope}},
+			method:  fiber.MethodPut,
+			route:   "/api/v1/classrooms/:classroomId",
+			allowed: false,
+		},
+		{
+			name:    "grading scope can not change the rubrics",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.GradingScope}},
+			method:  fiber.MethodPut,
+			route:   "/api/v1/classrooms/:classroomId/assignments/:assignmentId/grading",
+			allowed: false,
+		},
+		{
+			name:        "classroom admin scope changes its classroom",
+			token:       &database.APIToken{Scopes: []database.APITokenScope{database.ClassroomAdminScope}, ClassroomID: &classroomID},
+			method:      fiber.MethodPost,
+			route:       "/api/v1/classrooms/:classroomId/invitations",
+			classroomID: classroomID,
+			allowed:     true,
+		},
+		{
+			name:        "classroom admin scope can not access other classrooms",
+			token:       &database.APIToken{Scopes: []database.APITokenScope{database.ClassroomAdminScope}, ClassroomID: &classroomID},
+			method:      fiber.MethodGet,
+			route:       "/api/v1/classrooms/:classroomId",
+			classroomID: uuid.New(),
+			allowed:     false,
+		},
+		{
+			name:    "classroom admin scope can not create classrooms",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.ClassroomAdminScope}, ClassroomID: &classroomID},
+			method:  fiber.MethodPost,
+			route:   "/api/v1/classrooms",
+			allowed: false,
+		},
+		{
+			name:    "restricted token reads the user",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.ReadScope}, ClassroomID: &classroomID},
+			method:  fiber.MethodGet,
+			route:   "/api/v1/me",
+			allowed: true,
+		},
+		{
+			name:    "tokens can not manage tokens",
+			token:   &database.APIToken{Scopes: []database.APITokenScope{database.ReadScope}},
+			method:  fiber.MethodGet,
+			route:   "/api/v1/me/tokens",
+			allowed: false,
+		},
+		{
+			name:    "token without scopes",
+			token:   &database.APIToken{},
+			method:  fiber.MethodGet,
+			route:   "/api/v1/me",
+			allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, apiTokenAllows(tt.token, tt.method, tt.route, tt.classroomID.String()))
+		})
+	}
+}
+
+func TestAPITokenScopeMiddleware(t *testing.T) {
+	ctrl := &OAuthController{}
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		fiberContext.Get(c).SetAPIToken(&database.APIToken{Scopes: []database.APITokenScope{database.ReadScope}})
+		return c.Next()
+	})
+	app.Get("/api/v1/me/tokens", ctrl.APITokenScopeMiddleware, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/api/v1/me", ctrl.APITokenScopeMiddleware, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{path: "/api/v1/me", status: fiber.StatusOK},
+		{path: "/api/v1/me/tokens", status: fiber.StatusForbidden},
+		{path: "/api/v1/ME/TOKENS", status: fiber.StatusForbidden},
+		{path: "/api/v1/me/tokens/", status: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+	"golang.org/x/oauth2"
+)
+
+// StoreGitlabToken saves the latest GitLab token of the user encrypted with the key, which is used by the requests with API tokens.
+// The token is only stored while the user has active API tokens, otherwise a stored token is removed.
+func StoreGitlabToken(ctx context.Context, key string, userID int, token *oauth2.Token) error {
+	active, err := hasActiveAPITokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return clearGitlabToken(ctx, userID)
+	}
+
+	accessToken, err := utils.EncryptToken(key, token.AccessToken)
+	if err != nil {
+		return err
+	}
+	refreshToken, err := utils.EncryptToken(key, token.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	queryUser := query.User
+	_, err = queryUser.
+		WithContext(ctx).
+		Where(queryUser.ID.Eq(userID)).
+		Updates(&database.User{
+			GitlabAccessToken:  &accessToken,
+			GitlabRefreshToken: &refreshToken,
+			GitlabTokenExpiry:  &token.Expiry,
+		})
+	return err
+}
+
+// ClearUnusedGitlabToken removes the stored GitLab token of the user, if the user has no active API tokens anymore.
+func ClearUnusedGitlabToken(ctx context.Context, userID int) error {
+	active, err := hasActiveAPITokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if active {
+		return nil
+	}
+	return clearGitlabToken(ctx, userID)
+}
+
+// storedGitlabToken returns the decrypted GitLab token stored for the user or nil if no token is stored.
+func storedGitlabToken(ctx context.Context, key string, userID int) (*oauth2.Token, error) {
+	queryUser := query.User
+	user, err := queryUser.
+		WithContext(ctx).
+		Select(queryUser.GitlabAccessToken, queryUser.GitlabRefreshToken, queryUser.GitlabTokenExpiry).
+		Where(queryUser.ID.Eq(userID)).
+		First()
+	if err != nil {
+		return nil, err
+	}
+
+	if user.GitlabAccessToken == nil || user.GitlabRefreshToken == nil || user.GitlabTokenExpiry == nil {
+		return nil, nil
+	}
+
+	accessToken, err := utils.DecryptToken(key, *user.GitlabAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := utils.DecryptToken(key, *user.GitlabRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Expiry:       *user.GitlabTokenExpiry,
+		TokenType:    "Bearer",
+	}, nil
+}
+
+func hasActiveAPITokens(ctx context.Context, userID int) (bool, error) {
+	queryToken := query.APIToken
+	count, err := queryToken.
+		WithContext(ctx).
+		Where(queryToken.UserID.Eq(userID)).
+		Where(queryToken.ExpiresAt.Gt(time.Now())).
+		Count()
+	return count > 0, err
+}
+
+func clearGitlabToken(ctx context.Context, userID int) error {
+	queryUser := query.User
+	_, err := queryUser.
+		WithContext(ctx).
+		Where(queryUser.ID.Eq(userID)).
+		UpdateSimple(queryUser.GitlabAccessToken.Null(), queryUser.GitlabRefreshToken.Null(), queryUser.GitlabTokenExpiry.Null())
+	return err
+}
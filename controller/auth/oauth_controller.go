@@ -24,6 +24,7 @@ import (
 type OAuthController struct {
 	authConfig   *oauth2.Config
 	gitlabConfig gitlabConfig.Config
+	tokenKey     string
 	g            *singleflight.Group
 }
 
@@ -32,6 +33,7 @@ func NewOAuthController(authConfig authConfig.Config, gitlabConfig gitlabConfig.
 	return &OAuthController{
 		authConfig:   authConfig.GetOAuthConfig(),
 		gitlabConfig: gitlabConfig,
+		tokenKey:     authConfig.GetTokenKey(),
 		g:            g,
 	}
 }
@@ -118,6 +120,11 @@ func (ctrl *OAuthController) Callback(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, "Internal Server Error")
 	}
 
+	if err = StoreGitlabToken(c.Context(), ctrl.tokenKey, user.ID, token); err != nil {
+		log.Println(err)
+		return fiber.NewError(fiber.StatusInternalServerError, "Internal Server Error")
+	}
+
 	s := session.Get(c)
 
 	// Save GitLab session in local user session
@@ -163,8 +170,12 @@ func (ctrl *OAuthController) GetAuth(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusOK)
 }
 
-// AuthMiddleware to check session for Gitlab Tokens
+// AuthMiddleware to check session for Gitlab Tokens, requests with an API token are authenticated by the token instead
 func (ctrl *OAuthController) AuthMiddleware(c *fiber.Ctx) error {
+	if secret, ok := session.BearerToken(c); ok {
+		return ctrl.apiTokenMiddleware(c, secret)
+	}
+
 	sess := session.Get(c)
 
 	userId, err := sess.GetUserID()
@@ -177,14 +188,12 @@ func (ctrl *OAuthController) AuthMiddleware(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
 	}
 
-	// exp.Add(-20 * time.Minute).After(time.Now())
-	// If
-	if token.Expiry.Before(time.Now().Add(20 * time.Minute)) {
+	if expiresSoon(token) {
 		// this added to prevent multiple requests from refreshing the token at the same time
 		// If 2 refresh requests are sent at the same time, the first one will refresh the token
 		// and the second would get an error because the refresh token was already used
 		_, err, _ := ctrl.g.Do(fmt.Sprintf("%d", userId), func() (interface{}, error) {
-			return nil, ctrl.refreshSession(c.Context(), sess)
+			return nil, ctrl.refreshSession(c.Context(), sess, userId)
 		})
 		if err != nil {
 			return err
@@ -231,12 +240,25 @@ func (ctrl *OAuthController) GetCsrf(c *fiber.Ctx) error {
 	return c.JSON(response{Csrf: token})
 }
 
-func (ctrl *OAuthController) refreshSession(c context.Context, sess *session.ClassroomSession) error {
+func (ctrl *OAuthController) refreshSession(c context.Context, sess *session.ClassroomSession, userId int) error {
 	token, err := sess.GetGitlabOauth2Token()
 	if err != nil {
 		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
 	}
 
+	// A request with an API token may have refreshed the token already, which invalidates the refresh token of the session
+	storedToken, err := storedGitlabToken(c, ctrl.tokenKey, userId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if storedToken != nil && storedToken.Expiry.After(token.Expiry) && !expiresSoon(storedToken) {
+		sess.SetGitlabOauth2Token(storedToken)
+		if err = sess.Save(); err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		return nil
+	}
+
 	// Set expiry to past to force refresh
 	token.Expiry = time.Now().Add(-1 * time.Minute)
 
@@ -251,6 +273,10 @@ func (ctrl *OAuthController) refreshSession(c context.Context, sess *session.Cla
 		return fiber.NewError(fiber.StatusUnauthorized, oldError.Error())
 	}
 
+	if err = StoreGitlabToken(c, ctrl.tokenKey, userId, newToken); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
 	// Save refreshed token to session
 	sess.SetGitlabOauth2Token(newToken)
 	if err = sess.Save(); err != nil {
@@ -61,6 +61,10 @@ func (ctrl *TestAuthController) AuthMiddleware(c *fiber.Ctx) error { ctx := fibe
 	return c.Next()
 }
 
+func (ctrl *TestAuthController) APITokenScopeMiddleware(c *fiber.Ctx) error {
+	return c.Next()
+}
+
 func (ctrl *TestAuthController) GetCsrf(c *fiber.Ctx) error {
 	type response struct {
 		Csrf string `json:"csrf"`
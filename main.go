@@ -21,7 +21,9 @@ import (
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/httputil"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
 	"gitlab.hs-flensburg.de/gitlab-classroom/router"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 	"gitlab.hs-flensburg.de/gitlab-classroom/worker"
@@ -51,10 +53,11 @@ func main() {
 
 	log.Printf("Starting GitClassrooms %s", version)
 
-	mailRepo, err := mail.NewMailRepository(appConfig.PublicURL, appConfig.Mail)
+	goMailRepo, err := mail.NewMailRepository(appConfig.PublicURL, appConfig.Mail)
 	if err != nil {
 		log.Fatal("failed to create mail repository", err)
 	}
+	mailRepo := mail.NewPreferenceRepository(goMailRepo)
 
 	db, err := gorm.Open(postgres.Open(appConfig.Database.Dsn()), &gorm.Config{})
 	if err != nil {
@@ -76,6 +79,15 @@ func main() {
 	// Set db for gorm-gen
 	query.SetDefault(db)
 
+	// Grading runs are processed in memory, so the runs of a previous process can not be continued
+	queryGradingRun := query.GradingRun
+	if _, err = queryGradingRun.
+		WithContext(context.Background()).
+		Where(queryGradingRun.Status.Eq(string(database.GradingRunRunning))).
+		Updates(&database.GradingRun{Status: database.GradingRunInterrupted, FinishedAt: utils.Ptr(time.Now())}); err != nil {
+		log.Println("failed to interrupt grading runs", err)
+	}
+
 	app := fiber.New(fiber.Config{
 		EnableTrustedProxyCheck: true,
 		TrustedProxies:          appConfig.TrustedProxies,
@@ -94,7 +106,16 @@ func main() {
 	})
 
 	authCtrl := authController.NewOAuthController(appConfig.Auth, appConfig.GitLab)
-	apiController := api.NewApiV1Controller(mailRepo, *appConfig)
+	// The mails of all users are readable at the dev endpoints, so they are only enabled in dev mode
+	mailbox, ok := goMailRepo.Mailbox()
+	if ok && appConfig.DevMode {
+		log.Println("Mails are kept in memory and can be read at /api/v1/dev/mails")
+	} else if ok {
+		log.Println("Mails are kept in memory, set DEV_MODE=true to read them at /api/v1/dev/mails")
+		mailbox = nil
+	}
+	broker := events.NewBroker()
+	apiController := api.NewApiV1Controller(*appConfig, mailbox, broker)
 
 	router.Routes(app, authCtrl, apiController, appConfig.FrontendPath, appConfig.Auth)
 
@@ -104,6 +125,8 @@ func main() {
 	go func() {
 		<-ctx.Done()
 		log.Println("Shutting down server...")
+		// End the open event streams, the shutdown waits for them otherwise
+		broker.Close()
 		if err := app.Shutdown(); err != nil {
 			log.Println(err)
 		}
@@ -132,10 +155,46 @@ func main() {
 	go func() {
 		defer wg.Done()
 
-		syncGitlabDbWork := worker.NewSyncGitlabDbWork(appConfig.GitLab, appConfig.PublicURL)
+		syncGitlabDbWork := worker.NewSyncGitlabDbWork(appConfig.GitLab, appConfig.PublicURL, broker)
 		syncGitlabDbWorker := worker.NewWorker(syncGitlabDbWork)
 		syncGitlabDbWorker.Start(ctx, appConfig.GitLab.SyncInterval)
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		assignmentReminderWork := worker.NewAssignmentReminderWork(appConfig.Reminder)
+		assignmentReminderWorker := worker.NewWorker(assignmentReminderWork)
+		assignmentReminderWorker.Start(ctx, appConfig.Reminder.Interval)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		notificationDigestWork := worker.NewNotificationDigestWork()
+		notificationDigestWorker := worker.NewWorker(notificationDigestWork)
+		notificationDigestWorker.Start(ctx, 1*time.Hour)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		mailDeliveryWork := worker.NewMailDeliveryWork(mailRepo)
+		mailDeliveryWorker := worker.NewWorker(mailDeliveryWork)
+		mailDeliveryWorker.Start(ctx, 30*time.Second)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		webhookDeliveryWork := worker.NewWebhookDeliveryWork(webhook.NewSender())
+		webhookDeliveryWorker := worker.NewWorker(webhookDeliveryWork)
+		webhookDeliveryWorker.Start(ctx, 30*time.Second)
+	}()
+
 	wg.Wait()
 }
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APITokenScope string //@Name APITokenScope
+
+const (
+	// ReadScope allows all reading requests
+	ReadScope APITokenScope = "read"
+	// GradingScope allows to change the grading results of projects and to start the autograding, but not to change the rubrics
+	GradingScope APITokenScope = "grading"
+	// ClassroomAdminScope allows all requests to the classroom the token is restricted to
+	ClassroomAdminScope APITokenScope = "classroomAdmin"
+)
+
+// APITokenScopes contains all scopes a token can be issued with.
+var APITokenScopes = []APITokenScope{
+	ReadScope,
+	GradingScope,
+	ClassroomAdminScope,
+}
+
+// APIToken is a personal token to access the API from scripts. The requests act as the user, limited by the scopes of the token.
+// Only the hash of the token is stored, the token itself is shown once when it is created.
+type APIToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"-"`
+
+	UserID int  `gorm:"not null;index" json:"-"`
+	User   User `json:"-"`
+
+	Name      string          `gorm:"not null" json:"name"`
+	TokenHash string          `gorm:"not null;unique" json:"-"`
+	Scopes    []APITokenScope `gorm:"serializer:json;not null" json:"scopes"`
+
+	// ClassroomID restricts the token to the requests of a single classroom
+	ClassroomID *uuid.UUID `gorm:"type:uuid" json:"classroomId" validate:"optional"`
+	Classroom   *Classroom `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+
+	ExpiresAt  time.Time  `gorm:"not null" json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt" validate:"optional"`
+} //@Name APIToken
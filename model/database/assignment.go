@@ -27,4 +27,9 @@ type Assignment struct {
 	JUnitTests                    []*AssignmentJunitTest `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
 
 	GradingManualRubrics []*ManualGradingRubric `gorm:"many2many:assignment_manual_grading_rubrics;constraint:OnDelete:CASCADE;" json:"-"`
+
+	TemplateVariants     []*AssignmentTemplateVariant `gorm:"constraint:OnDelete:CASCADE;" json:"templateVariants"`
+	TemplateDistribution TemplateDistribution         `gorm:"not null;default:random" json:"templateDistribution"`
+
+	GradingRuns []*GradingRun `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
 } //@Name Assignment
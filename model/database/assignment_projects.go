@@ -34,8 +34,18 @@ type AssignmentProjects struct {
 	ProjectStatus status `gorm:"not null;default:pending" json:"projectStatus"`
 	ProjectID     int    `json:"projectId"`
 
+	// TemplateProjectID is the template variant the project was forked from
+	TemplateProjectID *int `json:"templateProjectId" validate:"optional"`
+
+	// FeedbackNoteID is the comment with the grading summary on the feedback merge request
+	FeedbackNoteID *int `json:"-"`
+
 	GradingJUnitTestResult *JUnitTestResult       `gorm:"type:jsonb;" json:"gradingJUnitTestResult" validate:"optional"`
 	GradingManualResults   []*ManualGradingResult `gorm:"foreignKey:AssignmentProjectID;constraint:OnDelete:CASCADE;" json:"gradingManualResults"`
+
+	GradingComments []*GradingComment `gorm:"foreignKey:AssignmentProjectID;constraint:OnDelete:CASCADE;" json:"-"`
+
+	Reminders []*AssignmentReminder `gorm:"foreignKey:AssignmentProjectID;constraint:OnDelete:CASCADE;" json:"-"`
 } //@Name AssignmentProjects
 
 type JUnitTestResult struct {
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReminderKind string //@Name ReminderKind
+
+const (
+	// DeadlineReminder reminds the members of an accepted project of the upcoming due date.
+	DeadlineReminder ReminderKind = "deadline"
+	// AcceptanceReminder reminds the members of a team that has not accepted the assignment yet.
+	AcceptanceReminder ReminderKind = "acceptance"
+)
+
+// AssignmentReminder is a struct that represents a reminder mail sent to a member of an assignment project in the database
+type AssignmentReminder struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	AssignmentProjectID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_unique_assignment_reminder" json:"-"`
+	UserID              int       `gorm:"not null;uniqueIndex:idx_unique_assignment_reminder" json:"userId"`
+
+	Kind ReminderKind `gorm:"not null;uniqueIndex:idx_unique_assignment_reminder" json:"kind"`
+	// RemindBefore is the configured offset before the due date the reminder was sent for
+	RemindBefore time.Duration `gorm:"not null;uniqueIndex:idx_unique_assignment_reminder" json:"remindBefore"`
+} //@Name AssignmentReminder
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TemplateDistribution string //@Name TemplateDistribution
+
+const (
+	// RandomDistribution assigns a random template variant to each team.
+	RandomDistribution TemplateDistribution = "random"
+	// RoundRobinDistribution assigns the template variants to the teams in turn, ordered by the creation of the teams.
+	RoundRobinDistribution TemplateDistribution = "roundRobin"
+)
+
+// AssignmentTemplateVariant is a struct that represents one of multiple template projects of an assignment in the database
+type AssignmentTemplateVariant struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	AssignmentID uuid.UUID  `gorm:"not null;uniqueIndex:idx_unique_assignment_template_variant" json:"-"`
+	Assignment   Assignment `json:"-"`
+
+	TemplateProjectID int `gorm:"not null;uniqueIndex:idx_unique_assignment_template_variant" json:"templateProjectId"`
+	Position          int `gorm:"not null" json:"position"`
+} //@Name AssignmentTemplateVariant
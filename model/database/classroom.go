@@ -26,6 +26,8 @@ type Classroom struct {
 	GroupAccessTokenID        int       `gorm:"not null" json:"-"`
 	GroupAccessToken          string    `gorm:"not null" json:"-"`
 	GroupAccessTokenCreatedAt time.Time `gorm:"not null" json:"-"`
+	// TemplatesGroupID is the subgroup for the template projects, it is created on the first listing of the templates
+	TemplatesGroupID *int `json:"templatesGroupId"`
 
 	Member                  []*UserClassrooms      `gorm:"foreignKey:ClassroomID;constraint:OnDelete:CASCADE;" json:"-"`
 	Teams                   []*Team                `gorm:"foreignKey:ClassroomID;constraint:OnDelete:CASCADE;" json:"-"`
@@ -36,4 +38,7 @@ type Classroom struct {
 
 	Archived           bool `gorm:"not null;default:false" json:"archived"`
 	PotentiallyDeleted bool `gorm:"not null;default:false" json:"potentiallyDeleted"`
+
+	OutgoingMails []*OutgoingMail `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Webhooks      []*Webhook      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
 } //@Name Classroom
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GradingComment is a comment on a line of the feedback merge request, written while grading a project
+type GradingComment struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	AssignmentProjectID uuid.UUID          `gorm:"type:uuid;not null" json:"-"`
+	AssignmentProject   AssignmentProjects `json:"-"`
+
+	// RubricID links the comment to the rubric it justifies
+	RubricID *uuid.UUID           `gorm:"type:uuid" json:"rubricId" validate:"optional"`
+	Rubric   *ManualGradingRubric `gorm:"constraint:OnDelete:SET NULL;" json:"rubric" validate:"optional"`
+
+	AuthorID int  `gorm:"not null" json:"-"`
+	Author   User `gorm:"foreignKey:AuthorID" json:"author"`
+
+	// DiscussionID is the id of the discussion on the feedback merge request in GitLab
+	DiscussionID string `gorm:"not null" json:"discussionId"`
+	FilePath     string `gorm:"not null" json:"filePath"`
+	Line         int    `gorm:"not null" json:"line"`
+	Body         string `gorm:"not null" json:"body"`
+} //@Name GradingComment
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GradingRunStatus string //@Name GradingRunStatus
+
+const (
+	// GradingRunRunning runs are grading their projects in the background.
+	GradingRunRunning GradingRunStatus = "running"
+	// GradingRunCompleted runs have processed all of their projects.
+	GradingRunCompleted GradingRunStatus = "completed"
+	// GradingRunCanceled runs have been canceled or timed out before processing all projects.
+	GradingRunCanceled GradingRunStatus = "canceled"
+	// GradingRunInterrupted runs have been stopped by a restart of the server.
+	GradingRunInterrupted GradingRunStatus = "interrupted"
+)
+
+// GradingRun is a struct that represents a run of the autograding over the accepted projects of an assignment in the database.
+type GradingRun struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	AssignmentID uuid.UUID  `gorm:"type:uuid;not null;index" json:"assignmentId"`
+	Assignment   Assignment `json:"-"`
+
+	StartedByID int  `gorm:"not null" json:"startedById"`
+	StartedBy   User `json:"-"`
+
+	Status     GradingRunStatus `gorm:"not null;default:running;index" json:"status"`
+	FinishedAt *time.Time       `json:"finishedAt" validate:"optional"`
+
+	Results []*GradingRunResult `gorm:"foreignKey:GradingRunID;constraint:OnDelete:CASCADE;" json:"results,omitempty" validate:"optional"`
+} //@Name GradingRun
+
+type GradingRunResultStatus string //@Name GradingRunResultStatus
+
+const (
+	// GradingResultPending projects have not been graded by the run yet.
+	GradingResultPending GradingRunResultStatus = "pending"
+	// GradingResultGraded projects have a new test result.
+	GradingResultGraded GradingRunResultStatus = "graded"
+	// GradingResultSkipped projects have no executed pipeline to grade.
+	GradingResultSkipped GradingRunResultStatus = "skipped"
+	// GradingResultFailed projects could not be graded, see the error of the result.
+	GradingResultFailed GradingRunResultStatus = "failed"
+	// GradingResultCanceled projects were not graded, because the run has been canceled.
+	GradingResultCanceled GradingRunResultStatus = "canceled"
+)
+
+// GradingRunResult is a struct that represents the outcome of a grading run for a single project in the database.
+type GradingRunResult struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	GradingRunID uuid.UUID  `gorm:"type:uuid;not null;index" json:"-"`
+	GradingRun   GradingRun `json:"-"`
+
+	ProjectID uuid.UUID          `gorm:"type:uuid;not null" json:"projectId"`
+	Project   AssignmentProjects `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+
+	Status GradingRunResultStatus `gorm:"not null;default:pending" json:"status"`
+	Error  *string                `json:"error" validate:"optional"`
+} //@Name GradingRunResult
@@ -0,0 +1,22 @@
+-- +goose Up
+ALTER TABLE "public"."assignments" ADD COLUMN "template_distribution" TEXT NOT NULL DEFAULT 'random'::TEXT;
+
+ALTER TABLE "public"."assignment_projects" ADD COLUMN "template_project_id" BIGINT;
+
+CREATE TABLE "public"."assignment_template_variants" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "assignment_id" UUID NOT NULL,
+    "template_project_id" BIGINT NOT NULL,
+    "position" BIGINT NOT NULL,
+    CONSTRAINT "fk_assignments_template_variants" FOREIGN KEY ("assignment_id") REFERENCES "public"."assignments"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "idx_unique_assignment_template_variant" ON "public"."assignment_template_variants" USING btree ("assignment_id", "template_project_id");
+
+-- +goose Down
+DROP TABLE "public"."assignment_template_variants";
+
+ALTER TABLE "public"."assignment_projects" DROP COLUMN "template_project_id";
+
+ALTER TABLE "public"."assignments" DROP COLUMN "template_distribution";
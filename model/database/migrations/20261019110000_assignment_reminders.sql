@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE "public"."assignment_reminders" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "assignment_project_id" UUID NOT NULL,
+    "user_id" BIGINT NOT NULL,
+    "kind" TEXT NOT NULL,
+    "remind_before" BIGINT NOT NULL,
+    CONSTRAINT "fk_assignment_projects_reminders" FOREIGN KEY ("assignment_project_id") REFERENCES "public"."assignment_projects"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "idx_unique_assignment_reminder" ON "public"."assignment_reminders" USING btree ("assignment_project_id", "user_id", "kind", "remind_before");
+
+-- +goose Down
+DROP TABLE "public"."assignment_reminders";
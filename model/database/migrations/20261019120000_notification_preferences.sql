@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE "public"."notification_preferences" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "user_id" BIGINT NOT NULL,
+    "category" TEXT NOT NULL,
+    "mode" TEXT NOT NULL DEFAULT 'immediate'::TEXT,
+    CONSTRAINT "fk_users_notification_preferences" FOREIGN KEY ("user_id") REFERENCES "public"."users"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "idx_unique_notification_preference" ON "public"."notification_preferences" USING btree ("user_id", "category");
+
+CREATE TABLE "public"."pending_notifications" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "user_id" BIGINT NOT NULL,
+    "category" TEXT NOT NULL,
+    "subject" TEXT NOT NULL,
+    "path" TEXT,
+    CONSTRAINT "fk_users_pending_notifications" FOREIGN KEY ("user_id") REFERENCES "public"."users"("id") ON DELETE CASCADE
+);
+CREATE INDEX "idx_pending_notifications_user_id" ON "public"."pending_notifications" USING btree ("user_id");
+
+-- +goose Down
+DROP TABLE "public"."pending_notifications";
+DROP TABLE "public"."notification_preferences";
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE "public"."users" ADD COLUMN "language" TEXT;
+
+-- +goose Down
+ALTER TABLE "public"."users" DROP COLUMN "language";
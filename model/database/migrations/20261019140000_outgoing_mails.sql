@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE "public"."outgoing_mails" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "classroom_id" UUID,
+    "classroom_invitation_id" UUID,
+    "kind" TEXT NOT NULL,
+    "recipient" TEXT NOT NULL,
+    "subject" TEXT NOT NULL,
+    "data" JSONB NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'pending'::TEXT,
+    "next_attempt_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "attempts" BIGINT NOT NULL DEFAULT 0,
+    "last_error" TEXT,
+    "sent_at" TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT "fk_classrooms_outgoing_mails" FOREIGN KEY ("classroom_id") REFERENCES "public"."classrooms"("id") ON DELETE CASCADE
+);
+CREATE INDEX "idx_outgoing_mails_delivery" ON "public"."outgoing_mails" USING btree ("status", "next_attempt_at");
+
+-- +goose Down
+DROP TABLE "public"."outgoing_mails";
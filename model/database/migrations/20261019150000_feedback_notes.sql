@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE "public"."assignment_projects" ADD COLUMN "feedback_note_id" BIGINT;
+
+-- +goose Down
+ALTER TABLE "public"."assignment_projects" DROP COLUMN "feedback_note_id";
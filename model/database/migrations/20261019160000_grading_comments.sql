@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE "public"."grading_comments" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "assignment_project_id" UUID NOT NULL,
+    "rubric_id" UUID,
+    "author_id" BIGINT NOT NULL,
+    "discussion_id" TEXT NOT NULL,
+    "file_path" TEXT NOT NULL,
+    "line" BIGINT NOT NULL,
+    "body" TEXT NOT NULL,
+    CONSTRAINT "fk_assignment_projects_grading_comments" FOREIGN KEY ("assignment_project_id") REFERENCES "public"."assignment_projects"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_grading_comments_rubric" FOREIGN KEY ("rubric_id") REFERENCES "public"."manual_grading_rubrics"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_grading_comments_author" FOREIGN KEY ("author_id") REFERENCES "public"."users"("id")
+);
+
+-- +goose Down
+DROP TABLE "public"."grading_comments";
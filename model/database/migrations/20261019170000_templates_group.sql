@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE "public"."classrooms" ADD COLUMN "templates_group_id" BIGINT;
+
+-- +goose Down
+ALTER TABLE "public"."classrooms" DROP COLUMN "templates_group_id";
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE "public"."api_tokens" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "user_id" BIGINT NOT NULL,
+    "name" TEXT NOT NULL,
+    "token_hash" TEXT NOT NULL UNIQUE,
+    "scopes" TEXT NOT NULL,
+    "classroom_id" UUID,
+    "expires_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "last_used_at" TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT "fk_users_api_tokens" FOREIGN KEY ("user_id") REFERENCES "public"."users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_api_tokens_classroom" FOREIGN KEY ("classroom_id") REFERENCES "public"."classrooms"("id") ON DELETE CASCADE
+);
+CREATE INDEX "idx_api_tokens_user_id" ON "public"."api_tokens" USING btree ("user_id");
+
+ALTER TABLE "public"."users" ADD COLUMN "gitlab_access_token" TEXT;
+ALTER TABLE "public"."users" ADD COLUMN "gitlab_refresh_token" TEXT;
+ALTER TABLE "public"."users" ADD COLUMN "gitlab_token_expiry" TIMESTAMP WITH TIME ZONE;
+
+-- +goose Down
+ALTER TABLE "public"."users" DROP COLUMN "gitlab_token_expiry";
+ALTER TABLE "public"."users" DROP COLUMN "gitlab_refresh_token";
+ALTER TABLE "public"."users" DROP COLUMN "gitlab_access_token";
+
+DROP TABLE "public"."api_tokens";
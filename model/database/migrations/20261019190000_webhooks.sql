@@ -0,0 +1,35 @@
+-- +goose Up
+CREATE TABLE "public"."webhooks" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "classroom_id" UUID NOT NULL,
+    "url" TEXT NOT NULL,
+    "secret" TEXT NOT NULL,
+    "events" TEXT NOT NULL,
+    "active" BOOLEAN NOT NULL DEFAULT true,
+    CONSTRAINT "fk_webhooks_classroom" FOREIGN KEY ("classroom_id") REFERENCES "public"."classrooms"("id") ON DELETE CASCADE
+);
+CREATE INDEX "idx_webhooks_classroom_id" ON "public"."webhooks" USING btree ("classroom_id");
+
+CREATE TABLE "public"."webhook_deliveries" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "webhook_id" UUID NOT NULL,
+    "event" TEXT NOT NULL,
+    "payload" JSONB NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'pending'::TEXT,
+    "next_attempt_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "attempts" BIGINT NOT NULL DEFAULT 0,
+    "response_status" BIGINT,
+    "last_error" TEXT,
+    "delivered_at" TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT "fk_webhooks_deliveries" FOREIGN KEY ("webhook_id") REFERENCES "public"."webhooks"("id") ON DELETE CASCADE
+);
+CREATE INDEX "idx_webhook_deliveries_webhook_id" ON "public"."webhook_deliveries" USING btree ("webhook_id");
+CREATE INDEX "idx_webhook_deliveries_delivery" ON "public"."webhook_deliveries" USING btree ("status", "next_attempt_at");
+
+-- +goose Down
+DROP TABLE "public"."webhook_deliveries";
+DROP TABLE "public"."webhooks";
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE "public"."grading_runs" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "assignment_id" UUID NOT NULL,
+    "started_by_id" BIGINT NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'running'::TEXT,
+    "finished_at" TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT "fk_assignments_grading_runs" FOREIGN KEY ("assignment_id") REFERENCES "public"."assignments"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_grading_runs_started_by" FOREIGN KEY ("started_by_id") REFERENCES "public"."users"("id") ON DELETE CASCADE
+);
+CREATE INDEX "idx_grading_runs_assignment_id" ON "public"."grading_runs" USING btree ("assignment_id");
+CREATE INDEX "idx_grading_runs_status" ON "public"."grading_runs" USING btree ("status");
+
+CREATE TABLE "public"."grading_run_results" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "created_at" TIMESTAMP WITH TIME ZONE,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    "grading_run_id" UUID NOT NULL,
+    "project_id" UUID NOT NULL,
+    "status" TEXT NOT NULL DEFAULT 'pending'::TEXT,
+    "error" TEXT,
+    CONSTRAINT "fk_grading_runs_results" FOREIGN KEY ("grading_run_id") REFERENCES "public"."grading_runs"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_grading_run_results_project" FOREIGN KEY ("project_id") REFERENCES "public"."assignment_projects"("id") ON DELETE CASCADE
+);
+CREATE INDEX "idx_grading_run_results_grading_run_id" ON "public"."grading_run_results" USING btree ("grading_run_id");
+
+-- +goose Down
+DROP TABLE "public"."grading_run_results";
+DROP TABLE "public"."grading_runs";
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationCategory string //@Name NotificationCategory
+
+const (
+	InvitationsCategory       NotificationCategory = "invitations"
+	NewAssignmentsCategory    NotificationCategory = "newAssignments"
+	DeadlineRemindersCategory NotificationCategory = "deadlineReminders"
+	TeamChangesCategory       NotificationCategory = "teamChanges"
+	GradesReleasedCategory    NotificationCategory = "gradesReleased"
+)
+
+// NotificationCategories contains all categories a user can configure notifications for.
+var NotificationCategories = []NotificationCategory{
+	InvitationsCategory,
+	NewAssignmentsCategory,
+	DeadlineRemindersCategory,
+	TeamChangesCategory,
+	GradesReleasedCategory,
+}
+
+type NotificationMode string //@Name NotificationMode
+
+const (
+	// ImmediateMode sends a mail for every notification.
+	ImmediateMode NotificationMode = "immediate"
+	// DigestMode collects the notifications and sends them once a day in a single mail.
+	DigestMode NotificationMode = "digest"
+	// OffMode drops the notifications.
+	OffMode NotificationMode = "off"
+)
+
+// NotificationPreference is a struct that represents the notification mode a user has chosen for a category in the database.
+// Categories without a preference are sent immediately.
+type NotificationPreference struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"-"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	UserID   int                  `gorm:"not null;uniqueIndex:idx_unique_notification_preference" json:"-"`
+	Category NotificationCategory `gorm:"not null;uniqueIndex:idx_unique_notification_preference" json:"category"`
+	Mode     NotificationMode     `gorm:"not null;default:immediate" json:"mode"`
+} //@Name NotificationPreference
+
+// PendingNotification is a struct that represents a notification waiting for the next digest mail of a user in the database.
+type PendingNotification struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	UserID   int                  `gorm:"not null;index" json:"-"`
+	Category NotificationCategory `gorm:"not null" json:"category"`
+	Subject  string               `gorm:"not null" json:"subject"`
+	Path     string               `json:"path"`
+} //@Name PendingNotification
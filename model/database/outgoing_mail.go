@@ -0,0 +1,58 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MailKind string //@Name MailKind
+
+const (
+	ClassroomInvitationMail    MailKind = "classroomInvitation"
+	AssignmentNotificationMail MailKind = "assignmentNotification"
+	ClassroomRemovalMail       MailKind = "classroomRemoval"
+	TeamChangeMail             MailKind = "teamChange"
+	GradesReleasedMail         MailKind = "gradesReleased"
+	DeadlineReminderMail       MailKind = "deadlineReminder"
+	AcceptanceReminderMail     MailKind = "acceptanceReminder"
+	NotificationDigestMail     MailKind = "notificationDigest"
+)
+
+type OutgoingMailStatus string //@Name OutgoingMailStatus
+
+const (
+	// MailPending mails are waiting for their next delivery attempt.
+	MailPending OutgoingMailStatus = "pending"
+	// MailSent mails have been accepted by the mail server.
+	MailSent OutgoingMailStatus = "sent"
+	// MailFailed mails could not be delivered after all attempts.
+	MailFailed OutgoingMailStatus = "failed"
+	// MailBounced mails have been rejected permanently by the mail server, e.g. for an unknown recipient.
+	MailBounced OutgoingMailStatus = "bounced"
+	// MailSuppressed mails have not been sent, as the recipient turned off this kind of notification.
+	MailSuppressed OutgoingMailStatus = "suppressed"
+)
+
+// OutgoingMail is a struct that represents a mail in the outbox in the database.
+// Mails are written to the outbox in the same transaction as the change they notify about and sent by a worker.
+type OutgoingMail struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	ClassroomID           *uuid.UUID `gorm:"type:uuid" json:"-"`
+	ClassroomInvitationID *uuid.UUID `gorm:"type:uuid" json:"classroomInvitationId" validate:"optional"`
+
+	Kind      MailKind `gorm:"not null" json:"kind"`
+	Recipient string   `gorm:"not null" json:"recipient"`
+	Subject   string   `gorm:"not null" json:"subject"`
+	// Data contains the JSON encoded template data of the mail
+	Data []byte `gorm:"type:jsonb;not null" json:"-"`
+
+	Status        OutgoingMailStatus `gorm:"not null;default:pending;index:idx_outgoing_mails_delivery" json:"status"`
+	NextAttemptAt time.Time          `gorm:"not null;index:idx_outgoing_mails_delivery" json:"nextAttemptAt"`
+	Attempts      int                `gorm:"not null;default:0" json:"attempts"`
+	LastError     *string            `json:"lastError" validate:"optional"`
+	SentAt        *time.Time         `json:"sentAt" validate:"optional"`
+} //@Name OutgoingMail
@@ -14,10 +14,24 @@ type User struct {
 	AvatarURL         *string `json:"avatarURL"`
 	FallbackAvatarURL *string `json:"fallbackAvatarURL"`
 
+	// Language of the mails sent to the user, the default language of the mail configuration is used if not set
+	Language *string `json:"language" validate:"optional"`
+
+	// GitlabAccessToken, GitlabRefreshToken and GitlabTokenExpiry are the latest OAuth token of the user, requests with API tokens act with it.
+	// The tokens are encrypted and only stored while the user has active API tokens.
+	GitlabAccessToken  *string    `json:"-"`
+	GitlabRefreshToken *string    `json:"-"`
+	GitlabTokenExpiry  *time.Time `json:"-"`
+
 	Name      string    `gorm:"not null" json:"name"`
 	CreatedAt time.Time `json:"-"`
 	UpdatedAt time.Time `json:"-"`
 
 	OwnedClassrooms []*Classroom      `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE;" json:"-"`
 	Classrooms      []*UserClassrooms `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+
+	NotificationPreferences []*NotificationPreference `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	PendingNotifications    []*PendingNotification    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+
+	APITokens []*APIToken `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
 } //@Name User
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookEvent string //@Name WebhookEvent
+
+const (
+	MemberJoinedEvent      WebhookEvent = "memberJoined"
+	TeamCreatedEvent       WebhookEvent = "teamCreated"
+	AssignmentCreatedEvent WebhookEvent = "assignmentCreated"
+	AssignmentClosedEvent  WebhookEvent = "assignmentClosed"
+	ProjectAcceptedEvent   WebhookEvent = "projectAccepted"
+	ProjectFailedEvent     WebhookEvent = "projectFailed"
+	GradesUpdatedEvent     WebhookEvent = "gradesUpdated"
+)
+
+// WebhookEvents contains all events a webhook can subscribe to.
+var WebhookEvents = []WebhookEvent{
+	MemberJoinedEvent,
+	TeamCreatedEvent,
+	AssignmentCreatedEvent,
+	AssignmentClosedEvent,
+	ProjectAcceptedEvent,
+	ProjectFailedEvent,
+	GradesUpdatedEvent,
+}
+
+// Webhook is a struct that represents an outgoing webhook of a classroom in the database.
+// The events the webhook subscribed to are posted to its URL, signed with its secret.
+type Webhook struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	ClassroomID uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	Classroom   Classroom `json:"-"`
+
+	URL string `gorm:"not null" json:"url"`
+	// Secret is the key of the HMAC signature of the deliveries
+	Secret string         `gorm:"not null" json:"-"`
+	Events []WebhookEvent `gorm:"serializer:json;not null" json:"events"`
+	Active bool           `gorm:"not null" json:"active"`
+
+	Deliveries []*WebhookDelivery `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE;" json:"-"`
+} //@Name Webhook
+
+type WebhookDeliveryStatus string //@Name WebhookDeliveryStatus
+
+const (
+	// WebhookPending deliveries are waiting for their next attempt.
+	WebhookPending WebhookDeliveryStatus = "pending"
+	// WebhookDelivered deliveries have been answered with a 2xx status code.
+	WebhookDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookFailed deliveries could not be delivered after all attempts.
+	WebhookFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a struct that represents an event posted to a webhook in the database.
+// Deliveries are written in the same transaction as the change they notify about and sent by a worker, which keeps them as delivery log.
+type WebhookDelivery struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	WebhookID uuid.UUID `gorm:"type:uuid;not null;index" json:"webhookId"`
+	Webhook   Webhook   `json:"-"`
+
+	Event WebhookEvent `gorm:"not null" json:"event"`
+	// Payload is the JSON body posted to the webhook
+	Payload []byte `gorm:"type:jsonb;not null" json:"-"`
+
+	Status         WebhookDeliveryStatus `gorm:"not null;default:pending;index:idx_webhook_deliveries_delivery" json:"status"`
+	NextAttemptAt  time.Time             `gorm:"not null;index:idx_webhook_deliveries_delivery" json:"nextAttemptAt"`
+	Attempts       int                   `gorm:"not null;default:0" json:"attempts"`
+	ResponseStatus *int                  `json:"responseStatus" validate:"optional"`
+	LastError      *string               `json:"lastError" validate:"optional"`
+	DeliveredAt    *time.Time            `json:"deliveredAt" validate:"optional"`
+} //@Name WebhookDelivery
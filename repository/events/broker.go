@@ -0,0 +1,116 @@
+package events
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// subscriptionBuffer is the number of events a subscriber may fall behind before it loses events
+const subscriptionBuffer = 64
+
+// Filter selects the events of a subscription.
+type Filter func(event Event) bool
+
+// ForUser selects the events of the user.
+func ForUser(userID int) Filter {
+	return func(event Event) bool {
+		return slices.Contains(event.UserIDs, userID)
+	}
+}
+
+// ForClassroom selects all events of the classroom.
+func ForClassroom(classroomID uuid.UUID) Filter {
+	return func(event Event) bool {
+		return event.ClassroomID == classroomID
+	}
+}
+
+// Subscription receives the published events matching its filter until it is unsubscribed.
+type Subscription struct {
+	events chan Event
+	filter Filter
+}
+
+// Events returns the channel of the subscription, which is closed after unsubscribing or closing the broker.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Broker distributes the published events to the subscriptions of this instance.
+// The events are only kept in memory and publishing never blocks, a subscriber that does not keep up loses events.
+type Broker struct {
+	mu            sync.Mutex
+	lastID        uint64
+	closed        bool
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewBroker creates a broker without subscriptions.
+func NewBroker() *Broker {
+	return &Broker{subscriptions: map[*Subscription]struct{}{}}
+}
+
+// Subscribe creates a subscription for the events matching the filter.
+func (b *Broker) Subscribe(filter Filter) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription := &Subscription{events: make(chan Event, subscriptionBuffer), filter: filter}
+	if b.closed {
+		close(subscription.events)
+		return subscription
+	}
+
+	b.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+// Unsubscribe removes the subscription and closes its channel.
+func (b *Broker) Unsubscribe(subscription *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscriptions[subscription]; !ok {
+		return
+	}
+	delete(b.subscriptions, subscription)
+	close(subscription.events)
+}
+
+// Publish sends the event to all matching subscriptions.
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.lastID++
+	event.ID = b.lastID
+
+	for subscription := range b.subscriptions {
+		if !subscription.filter(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default: // The subscriber is too slow, drop the event instead of blocking the publisher
+		}
+	}
+}
+
+// Close ends all subscriptions, so open streams do not block the shutdown of the server.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for subscription := range b.subscriptions {
+		close(subscription.events)
+	}
+	b.subscriptions = map[*Subscription]struct{}{}
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	classroomID := uuid.New()
+
+	t.Run("filters the events", func(t *testing.T) {
+		broker := NewBroker()
+		user := broker.Subscribe(ForUser(1))
+		classroom := broker.Subscribe(ForClassroom(classroomID))
+
+		broker.Publish(Event{Type: SyncEvent, ClassroomID: classroomID, UserIDs: []int{1}})
+		broker.Publish(Event{Type: AutoGradingEvent, ClassroomID: classroomID})
+		broker.Publish(Event{Type: SyncEvent, ClassroomID: uuid.New(), UserIDs: []int{2}})
+
+		assert.Equal(t, []Type{SyncEvent}, receivedTypes(user))
+		assert.Equal(t, []Type{SyncEvent, AutoGradingEvent}, receivedTypes(classroom))
+	})
+
+	t.Run("numbers the events", func(t *testing.T) {
+		broker := NewBroker()
+		subscription := broker.Subscribe(ForClassroom(classroomID))
+
+		broker.Publish(Event{ClassroomID: classroomID})
+		broker.Publish(Event{ClassroomID: classroomID})
+
+		assert.Equal(t, uint64(1), (<-subscription.Events()).ID)
+		assert.Equal(t, uint64(2), (<-subscription.Events()).ID)
+	})
+
+	t.Run("drops events of slow subscribers", func(t *testing.T) {
+		broker := NewBroker()
+		subscription := broker.Subscribe(ForClassroom(classroomID))
+
+		for range subscriptionBuffer + 1 {
+			broker.Publish(Event{ClassroomID: classroomID})
+		}
+
+		assert.Len(t, subscription.Events(), subscriptionBuffer)
+	})
+
+	t.Run("closes the subscriptions", func(t *testing.T) {
+		broker := NewBroker()
+		unsubscribed := broker.Subscribe(ForClassroom(classroomID))
+		open := broker.Subscribe(ForClassroom(classroomID))
+
+		broker.Unsubscribe(unsubscribed)
+		broker.Unsubscribe(unsubscribed)
+		_, ok := <-unsubscribed.Events()
+		assert.False(t, ok)
+
+		broker.Close()
+		_, ok = <-open.Events()
+		assert.False(t, ok)
+
+		_, ok = <-broker.Subscribe(ForClassroom(classroomID)).Events()
+		assert.False(t, ok)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	classroomID := uuid.MustParse("7a0d2c6e-0f0b-4a5e-9a4c-4b1d0f3a2e11")
+	var buf bytes.Buffer
+
+	err := Write(&buf, Event{ID: 3, Type: SyncEvent, ClassroomID: classroomID, UserIDs: []int{1}, Data: SyncData{Change: ClassroomArchivedChange}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id: 3\nevent: sync\ndata: {\"id\":3,\"type\":\"sync\",\"classroomId\":\"7a0d2c6e-0f0b-4a5e-9a4c-4b1d0f3a2e11\",\"data\":{\"change\":\"classroomArchived\"}}\n\n", buf.String())
+}
+
+func receivedTypes(subscription *Subscription) []Type {
+	var types []Type
+	for len(subscription.Events()) > 0 {
+		types = append(types, (<-subscription.Events()).Type)
+	}
+	return types
+}
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+)
+
+type Type string //@Name LiveEventType
+
+const (
+	ProjectCreationEvent Type = "projectCreation"
+	AutoGradingEvent     Type = "autoGrading"
+	SyncEvent            Type = "sync"
+)
+
+// Event is a live update, which is streamed to the users of the classroom.
+type Event struct {
+	// ID is assigned by the broker and increases with every published event of this instance
+	ID          uint64    `json:"id"`
+	Type        Type      `json:"type"`
+	ClassroomID uuid.UUID `json:"classroomId"`
+	// UserIDs are the users, which receive the event in their own stream besides the stream of the classroom
+	UserIDs []int `json:"-"`
+	Data    any   `json:"data"`
+} //@Name LiveEvent
+
+type ProjectStep string //@Name ProjectStep
+
+const (
+	ForkedStep                ProjectStep = "forked"
+	MembersAddedStep          ProjectStep = "membersAdded"
+	FeedbackBranchCreatedStep ProjectStep = "feedbackBranchCreated"
+	MergeRequestCreatedStep   ProjectStep = "mergeRequestCreated"
+	BranchesProtectedStep     ProjectStep = "branchesProtected"
+	AcceptedStep              ProjectStep = "accepted"
+	FailedStep                ProjectStep = "failed"
+)
+
+type ProjectCreationData struct {
+	ProjectID    uuid.UUID   `json:"projectId"`
+	AssignmentID uuid.UUID   `json:"assignmentId"`
+	TeamID       uuid.UUID   `json:"teamId"`
+	Step         ProjectStep `json:"step"`
+} //@Name ProjectCreationEventData
+
+type AutoGradingData struct {
+	RunID        uuid.UUID                       `json:"runId"`
+	AssignmentID uuid.UUID                       `json:"assignmentId"`
+	ProjectID    uuid.UUID                       `json:"projectId"`
+	Status       database.GradingRunResultStatus `json:"status"`
+	// Completed is the number of processed projects of the run including this one
+	Completed int    `json:"completed"`
+	Total     int    `json:"total"`
+	Error     string `json:"error,omitempty"`
+} //@Name AutoGradingEventData
+
+type SyncChange string //@Name SyncChange
+
+const (
+	ClassroomDeletedChange            SyncChange = "classroomDeleted"
+	ClassroomArchivedChange           SyncChange = "classroomArchived"
+	ClassroomPotentiallyDeletedChange SyncChange = "classroomPotentiallyDeleted"
+	MemberRemovedChange               SyncChange = "memberRemoved"
+	TeamMemberRemovedChange           SyncChange = "teamMemberRemoved"
+	TeamDeletedChange                 SyncChange = "teamDeleted"
+	ProjectDeletedChange              SyncChange = "projectDeleted"
+)
+
+type SyncData struct {
+	Change    SyncChange `json:"change"`
+	UserID    *int       `json:"userId,omitempty"`
+	TeamID    *uuid.UUID `json:"teamId,omitempty"`
+	ProjectID *uuid.UUID `json:"projectId,omitempty"`
+} //@Name SyncEventData
+
+// Write writes the event in the format of server-sent events.
+func Write(w io.Writer, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
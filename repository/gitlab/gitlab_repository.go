@@ -2,20 +2,27 @@
 package gitlab
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	gitlabConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/gitlab"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 
 	goGitlab "github.com/xanzy/go-gitlab"
+	"golang.org/x/sync/errgroup"
 )
 
+// runnerDetailsConcurrency limits the number of runner details fetched from GitLab at the same time.
+const runnerDetailsConcurrency = 8
+
 // GitlabRepo manages interactions with the GitLab API.
 type GitlabRepo struct {
 	client      *goGitlab.Client
@@ -82,6 +89,27 @@ func (repo *GitlabRepo) CreateProject(name string, visibility model.Visibility,
 	return repo.AddProjectMembers(gitlabProject.ID, members)
 }
 
+// CreateGroupProject creates an empty private project in a group. The first branch which is pushed becomes the default branch.
+func (repo *GitlabRepo) CreateGroupProject(groupId int, name string, description string, topics []string) (*model.Project, error) {
+	repo.assertIsConnected()
+
+	opts := &goGitlab.CreateProjectOptions{
+		Name:        goGitlab.String(name),
+		Path:        goGitlab.String(convertToGitLabPath(name)),
+		NamespaceID: goGitlab.Int(groupId),
+		Visibility:  goGitlab.Visibility(goGitlab.PrivateVisibility),
+		Description: goGitlab.String(description),
+		Topics:      &topics,
+	}
+
+	gitlabProject, _, err := repo.client.Projects.CreateProject(opts)
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return ProjectFromGoGitlab(*gitlabProject), nil
+}
+
 // ForkProject forks an existing project with the specified parameters.
 func (repo *GitlabRepo) ForkProject(projectId int, visibility model.Visibility, namespaceId int, name string, description string) (*model.Project, error) {
 	repo.assertIsConnected()
@@ -192,6 +220,151 @@ func (repo *GitlabRepo) CreateMergeRequest(projectId int, sourceBranch string, t
 	return ErrorFromGoGitlab(err)
 }
 
+// GetMergeRequestByTargetBranch retrieves the oldest open merge request into the given branch.
+func (repo *GitlabRepo) GetMergeRequestByTargetBranch(projectId int, targetBranch string) (*model.MergeRequest, error) {
+	repo.assertIsConnected()
+
+	opts := &goGitlab.ListProjectMergeRequestsOptions{
+		State:        goGitlab.String("opened"),
+		TargetBranch: goGitlab.String(targetBranch),
+		OrderBy:      goGitlab.String("created_at"),
+		Sort:         goGitlab.String("asc"),
+	}
+
+	mergeRequests, _, err := repo.client.MergeRequests.ListProjectMergeRequests(projectId, opts)
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	if len(mergeRequests) == 0 {
+		return nil, fmt.Errorf("no open merge request into branch %s found", targetBranch)
+	}
+
+	return MergeRequestFromGoGitlab(*mergeRequests[0]), nil
+}
+
+// CreateMergeRequestNote adds a comment to a merge request.
+func (repo *GitlabRepo) CreateMergeRequestNote(projectId int, mergeRequestIID int, body string) (*model.Note, error) {
+	repo.assertIsConnected()
+
+	note, _, err := repo.client.Notes.CreateMergeRequestNote(projectId, mergeRequestIID, &goGitlab.CreateMergeRequestNoteOptions{
+		Body: goGitlab.String(body),
+	})
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return NoteFromGoGitlab(*note), nil
+}
+
+// UpdateMergeRequestNote replaces the body of a comment on a merge request.
+func (repo *GitlabRepo) UpdateMergeRequestNote(projectId int, mergeRequestIID int, noteId int, body string) (*model.Note, error) {
+	repo.assertIsConnected()
+
+	note, _, err := repo.client.Notes.UpdateMergeRequestNote(projectId, mergeRequestIID, noteId, &goGitlab.UpdateMergeRequestNoteOptions{
+		Body: goGitlab.String(body),
+	})
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return NoteFromGoGitlab(*note), nil
+}
+
+// CreateMergeRequestDiffDiscussion starts a discussion on a line of a file in the latest diff of a merge request.
+func (repo *GitlabRepo) CreateMergeRequestDiffDiscussion(projectId int, mergeRequestIID int, filePath string, line int, body string) (*model.Discussion, error) {
+	repo.assertIsConnected()
+
+	// The position has to reference the current diff of the merge request
+	mergeRequest, _, err := repo.client.MergeRequests.GetMergeRequest(projectId, mergeRequestIID, &goGitlab.GetMergeRequestsOptions{})
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	position := &goGitlab.PositionOptions{
+		BaseSHA:      goGitlab.String(mergeRequest.DiffRefs.BaseSha),
+		StartSHA:     goGitlab.String(mergeRequest.DiffRefs.StartSha),
+		HeadSHA:      goGitlab.String(mergeRequest.DiffRefs.HeadSha),
+		PositionType: goGitlab.String("text"),
+		OldPath:      goGitlab.String(filePath),
+		NewPath:      goGitlab.String(filePath),
+		NewLine:      goGitlab.Int(line),
+	}
+
+	// Unchanged lines need their line in the old file as well, only added lines are referenced by the new line
+	diff, err := repo.getMergeRequestFileDiff(projectId, mergeRequestIID, filePath)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case diff == nil:
+		position.OldLine = goGitlab.Int(line)
+	case !diff.NewFile:
+		position.OldPath = goGitlab.String(diff.OldPath)
+		if oldLine, ok := utils.DiffOldLine(diff.Diff, line); ok {
+			position.OldLine = goGitlab.Int(oldLine)
+		}
+	}
+
+	opts := &goGitlab.CreateMergeRequestDiscussionOptions{
+		Body:     goGitlab.String(body),
+		Position: position,
+	}
+
+	discussion, _, err := repo.client.Discussions.CreateMergeRequestDiscussion(projectId, mergeRequestIID, opts)
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return DiscussionFromGoGitlab(*discussion), nil
+}
+
+// getMergeRequestFileDiff returns the diff of the file in a merge request, which is nil if the file is unchanged.
+func (repo *GitlabRepo) getMergeRequestFileDiff(projectId int, mergeRequestIID int, filePath string) (*goGitlab.MergeRequestDiff, error) {
+	opts := &goGitlab.ListMergeRequestDiffsOptions{PerPage: 100, Page: 1}
+	for {
+		diffs, response, err := repo.client.MergeRequests.ListMergeRequestDiffs(projectId, mergeRequestIID, opts)
+		if err != nil {
+			return nil, ErrorFromGoGitlab(err)
+		}
+
+		for _, diff := range diffs {
+			if diff.NewPath == filePath {
+				return diff, nil
+			}
+		}
+
+		if response.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
+// GetMergeRequestDiscussions retrieves all discussions of a merge request.
+func (repo *GitlabRepo) GetMergeRequestDiscussions(projectId int, mergeRequestIID int) ([]*model.Discussion, error) {
+	repo.assertIsConnected()
+
+	opts := &goGitlab.ListMergeRequestDiscussionsOptions{PerPage: 100, Page: 1}
+
+	var discussions []*model.Discussion
+	for {
+		page, response, err := repo.client.Discussions.ListMergeRequestDiscussions(projectId, mergeRequestIID, opts)
+		if err != nil {
+			return nil, ErrorFromGoGitlab(err)
+		}
+
+		for _, discussion := range page {
+			discussions = append(discussions, DiscussionFromGoGitlab(*discussion))
+		}
+
+		if response.NextPage == 0 {
+			return discussions, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
 // ProtectedBranchExists checks if a branch is protected in a project.
 func (repo *GitlabRepo) ProtectedBranchExists(projectId int, branchName string) (bool, error) {
 	repo.assertIsConnected()
@@ -516,6 +689,38 @@ func (repo *GitlabRepo) GetProjectLatestPipelineTestReportSummary(projectId int,
 	return repo.GetProjectPipelineTestReportSummary(projectId, pipeline.ID)
 }
 
+// RetryPipeline retries the failed and canceled jobs of a pipeline.
+func (repo *GitlabRepo) RetryPipeline(projectId int, pipelineId int) (*model.Pipeline, error) {
+	repo.assertIsConnected()
+
+	pipeline, _, err := repo.client.Pipelines.RetryPipelineBuild(projectId, pipelineId)
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return PipelineFromGoGitlabPipeline(pipeline), nil
+}
+
+// CreatePipeline runs a new pipeline for a ref. If ref is nil, the default branch is used.
+func (repo *GitlabRepo) CreatePipeline(projectId int, ref *string) (*model.Pipeline, error) {
+	repo.assertIsConnected()
+
+	if ref == nil {
+		project, _, err := repo.client.Projects.GetProject(projectId, &goGitlab.GetProjectOptions{})
+		if err != nil {
+			return nil, ErrorFromGoGitlab(err)
+		}
+		ref = &project.DefaultBranch
+	}
+
+	pipeline, _, err := repo.client.Pipelines.CreatePipeline(projectId, &goGitlab.CreatePipelineOptions{Ref: ref})
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return PipelineFromGoGitlabPipeline(pipeline), nil
+}
+
 // AddUserToGroup adds a user to a group with the specified access level.
 func (repo *GitlabRepo) AddUserToGroup(groupId int, userId int, accessLevel model.AccessLevelValue) error {
 	repo.assertIsConnected()
@@ -684,6 +889,44 @@ func (repo *GitlabRepo) GetAllProjectsOfGroup(id int) ([]*model.Project, error)
 	return repo.convertGitlabProjects(gitlabProjects)
 }
 
+// GetProjectsOfGroupPage fetches a page of the not archived projects of a group and its subgroups, ordered by the last activity.
+// It returns the projects of the page and the total number of projects.
+func (repo *GitlabRepo) GetProjectsOfGroupPage(groupId int, search string, page int, perPage int) ([]*model.Project, int, error) {
+	repo.assertIsConnected()
+
+	gitlabProjects, response, err := repo.client.Groups.ListGroupProjects(groupId, &goGitlab.ListGroupProjectsOptions{
+		ListOptions:      goGitlab.ListOptions{Page: page, PerPage: perPage},
+		Archived:         goGitlab.Bool(false),
+		IncludeSubGroups: goGitlab.Bool(true),
+		OrderBy:          goGitlab.String("last_activity_at"),
+		Search:           goGitlab.String(search),
+	})
+	if err != nil {
+		return nil, 0, ErrorFromGoGitlab(err)
+	}
+
+	return ProjectsFromGoGitlab(gitlabProjects), response.TotalItems, nil
+}
+
+// GetProjectsWithTopicPage fetches a page of the not archived projects with a topic, ordered by the last activity.
+// It returns the projects of the page and the total number of projects.
+func (repo *GitlabRepo) GetProjectsWithTopicPage(topic string, search string, page int, perPage int) ([]*model.Project, int, error) {
+	repo.assertIsConnected()
+
+	gitlabProjects, response, err := repo.client.Projects.ListProjects(&goGitlab.ListProjectsOptions{
+		ListOptions: goGitlab.ListOptions{Page: page, PerPage: perPage},
+		Archived:    goGitlab.Bool(false),
+		Topic:       goGitlab.String(topic),
+		OrderBy:     goGitlab.String("last_activity_at"),
+		Search:      goGitlab.String(search),
+	})
+	if err != nil {
+		return nil, 0, ErrorFromGoGitlab(err)
+	}
+
+	return ProjectsFromGoGitlab(gitlabProjects), response.TotalItems, nil
+}
+
 // GetAllUsersOfGroup fetches all users of a group by its ID.
 func (repo *GitlabRepo) GetAllUsersOfGroup(id int) ([]*model.User, error) {
 	repo.assertIsConnected()
@@ -895,6 +1138,169 @@ func (repo *GitlabRepo) GetAvailableRunnersForGroup(groupId int) ([]*model.Runne
 	return convertRunners(availableRunners), nil
 }
 
+// GetRunnerDetailsForGroup fetches all runners of a group, its ancestors and the instance, regardless of their status.
+// The details include the tags of the runners, which the runner lists do not.
+func (repo *GitlabRepo) GetRunnerDetailsForGroup(groupId int) ([]*model.RunnerDetails, error) {
+	repo.assertIsConnected()
+
+	runners, err := repo.listGroupRunners(groupId, &goGitlab.ListGroupsRunnersOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := repo.getRunnerDetails(runners)
+	if err != nil {
+		return nil, err
+	}
+
+	runnerDetails := make([]*model.RunnerDetails, len(details))
+	for i, runner := range details {
+		runnerDetails[i] = RunnerDetailsFromGoGitlab(*runner)
+	}
+
+	return runnerDetails, nil
+}
+
+// GetRunnersOfGroup fetches the runners registered for the group itself, without the runners of its ancestors or the instance.
+func (repo *GitlabRepo) GetRunnersOfGroup(groupId int) ([]*model.RunnerDetails, error) {
+	repo.assertIsConnected()
+
+	runners, err := repo.listGroupRunners(groupId, &goGitlab.ListGroupsRunnersOptions{Type: goGitlab.String("group_type")})
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := repo.getRunnerDetails(runners)
+	if err != nil {
+		return nil, err
+	}
+
+	ownRunners := make([]*model.RunnerDetails, 0)
+	for _, runner := range details {
+		for _, group := range runner.Groups {
+			if group.ID == groupId {
+				ownRunners = append(ownRunners, RunnerDetailsFromGoGitlab(*runner))
+				break
+			}
+		}
+	}
+
+	return ownRunners, nil
+}
+
+// listGroupRunners fetches all pages of the runners available to a group.
+func (repo *GitlabRepo) listGroupRunners(groupId int, opts *goGitlab.ListGroupsRunnersOptions) ([]*goGitlab.Runner, error) {
+	opts.ListOptions = goGitlab.ListOptions{PerPage: 100, Page: 1}
+
+	var runners []*goGitlab.Runner
+	for {
+		page, response, err := repo.client.Runners.ListGroupsRunners(groupId, opts)
+		if err != nil {
+			return nil, ErrorFromGoGitlab(err)
+		}
+		runners = append(runners, page...)
+
+		if response.NextPage == 0 {
+			return runners, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
+// getRunnerDetails fetches the details of every runner.
+// The runner lists contain neither tags nor groups, so the details are fetched concurrently for each runner.
+func (repo *GitlabRepo) getRunnerDetails(runners []*goGitlab.Runner) ([]*goGitlab.RunnerDetails, error) {
+	details := make([]*goGitlab.RunnerDetails, len(runners))
+
+	var eg errgroup.Group
+	eg.SetLimit(runnerDetailsConcurrency)
+	for i, runner := range runners {
+		eg.Go(func() error {
+			runnerDetails, _, err := repo.client.Runners.GetRunnerDetails(runner.ID)
+			if err != nil {
+				return ErrorFromGoGitlab(err)
+			}
+			details[i] = runnerDetails
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// CreateGroupRunner creates a runner for a group and returns its authentication token, which is needed to register the runner.
+func (repo *GitlabRepo) CreateGroupRunner(groupId int, description string, tags []string, runUntagged bool) (*model.RunnerRegistration, error) {
+	repo.assertIsConnected()
+
+	runner, _, err := repo.client.Users.CreateUserRunner(&goGitlab.CreateUserRunnerOptions{
+		RunnerType:  goGitlab.String("group_type"),
+		GroupID:     goGitlab.Int(groupId),
+		Description: goGitlab.String(description),
+		TagList:     &tags,
+		RunUntagged: goGitlab.Bool(runUntagged),
+	})
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return &model.RunnerRegistration{
+		ID:             runner.ID,
+		Token:          runner.Token,
+		TokenExpiresAt: runner.TokenExpiresAt,
+	}, nil
+}
+
+// PauseRunner pauses or resumes a runner.
+func (repo *GitlabRepo) PauseRunner(runnerId int, paused bool) error {
+	repo.assertIsConnected()
+
+	_, _, err := repo.client.Runners.UpdateRunnerDetails(runnerId, &goGitlab.UpdateRunnerDetailsOptions{Paused: goGitlab.Bool(paused)})
+	return ErrorFromGoGitlab(err)
+}
+
+// DeleteRunner deletes a runner, it is unregistered on its next contact.
+func (repo *GitlabRepo) DeleteRunner(runnerId int) error {
+	repo.assertIsConnected()
+
+	_, err := repo.client.Runners.RemoveRunner(runnerId)
+	return ErrorFromGoGitlab(err)
+}
+
+// GetProjectJobs fetches the jobs of a project with the given statuses, e.g. pending or running.
+func (repo *GitlabRepo) GetProjectJobs(projectId int, statuses ...string) ([]*model.Job, error) {
+	repo.assertIsConnected()
+
+	scope := make([]goGitlab.BuildStateValue, len(statuses))
+	for i, status := range statuses {
+		scope[i] = goGitlab.BuildStateValue(status)
+	}
+
+	opts := &goGitlab.ListJobsOptions{
+		ListOptions: goGitlab.ListOptions{PerPage: 100, Page: 1},
+		Scope:       &scope,
+	}
+
+	var jobs []*model.Job
+	for {
+		page, response, err := repo.client.Jobs.ListProjectJobs(projectId, opts)
+		if err != nil {
+			return nil, ErrorFromGoGitlab(err)
+		}
+
+		for _, job := range page {
+			jobs = append(jobs, JobFromGoGitlab(*job))
+		}
+
+		if response.NextPage == 0 {
+			return jobs, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
 // CheckIfFileExistsInProject checks if a file exists in a project.
 func (repo *GitlabRepo) CheckIfFileExistsInProject(projectId int, filepath string) (bool, error) {
 	repo.assertIsConnected()
@@ -912,6 +1318,134 @@ func (repo *GitlabRepo) CheckIfFileExistsInProject(projectId int, filepath strin
 	return true, nil
 }
 
+// GetRepositoryTree lists the files and directories of a path in a project at the given ref.
+func (repo *GitlabRepo) GetRepositoryTree(projectId int, ref string, path string, recursive bool) ([]*model.TreeNode, error) {
+	repo.assertIsConnected()
+
+	opts := &goGitlab.ListTreeOptions{
+		ListOptions: goGitlab.ListOptions{PerPage: 100, Page: 1},
+		Ref:         goGitlab.String(ref),
+		Path:        goGitlab.String(path),
+		Recursive:   goGitlab.Bool(recursive),
+	}
+
+	var nodes []*model.TreeNode
+	for {
+		page, response, err := repo.client.Repositories.ListTree(projectId, opts)
+		if err != nil {
+			return nil, ErrorFromGoGitlab(err)
+		}
+
+		for _, node := range page {
+			nodes = append(nodes, TreeNodeFromGoGitlab(*node))
+		}
+
+		if response.NextPage == 0 {
+			return nodes, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
+// GetRepositoryFile retrieves a file of a project at the given ref with its decoded content.
+func (repo *GitlabRepo) GetRepositoryFile(projectId int, filePath string, ref string) (*model.File, error) {
+	repo.assertIsConnected()
+
+	file, _, err := repo.client.RepositoryFiles.GetFile(projectId, filePath, &goGitlab.GetFileOptions{Ref: goGitlab.String(ref)})
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	content := []byte(file.Content)
+	if file.Encoding == "base64" {
+		content, err = base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.File{
+		Name:     file.FileName,
+		Path:     file.FilePath,
+		Size:     file.Size,
+		Ref:      file.Ref,
+		CommitID: file.CommitID,
+		Content:  string(content),
+	}, nil
+}
+
+// GetLatestCommit retrieves the latest commit of a ref, optionally only considering commits until the given time.
+// Without a ref the default branch is used.
+func (repo *GitlabRepo) GetLatestCommit(projectId int, ref string, until *time.Time) (*model.Commit, error) {
+	repo.assertIsConnected()
+
+	opts := &goGitlab.ListCommitsOptions{
+		ListOptions: goGitlab.ListOptions{PerPage: 1},
+		Until:       until,
+	}
+	if ref != "" {
+		opts.RefName = goGitlab.String(ref)
+	}
+
+	commits, _, err := repo.client.Commits.ListCommits(projectId, opts)
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commit found in project %d", projectId)
+	}
+
+	return CommitFromGoGitlab(*commits[0]), nil
+}
+
+// CreateCommit commits the files, mapped by their path, to a branch. The files must not exist yet.
+// The branch is created if the repository is empty.
+func (repo *GitlabRepo) CreateCommit(projectId int, branch string, message string, files map[string]string) (*model.Commit, error) {
+	repo.assertIsConnected()
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	actions := make([]*goGitlab.CommitActionOptions, len(paths))
+	for i, path := range paths {
+		actions[i] = &goGitlab.CommitActionOptions{
+			Action:   goGitlab.FileAction(goGitlab.FileCreate),
+			FilePath: goGitlab.String(path),
+			Content:  goGitlab.String(files[path]),
+		}
+	}
+
+	commit, _, err := repo.client.Commits.CreateCommit(projectId, &goGitlab.CreateCommitOptions{
+		Branch:        goGitlab.String(branch),
+		CommitMessage: goGitlab.String(message),
+		Actions:       actions,
+	})
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return CommitFromGoGitlab(*commit), nil
+}
+
+// CompareRefs retrieves the commits and diffs between two refs of a project.
+func (repo *GitlabRepo) CompareRefs(projectId int, from string, to string) (*model.Compare, error) {
+	repo.assertIsConnected()
+
+	compare, _, err := repo.client.Repositories.Compare(projectId, &goGitlab.CompareOptions{
+		From: goGitlab.String(from),
+		To:   goGitlab.String(to),
+	})
+	if err != nil {
+		return nil, ErrorFromGoGitlab(err)
+	}
+
+	return CompareFromGoGitlab(*compare), nil
+}
+
 // GetProjectLanguages retrieves the languages used in a project.
 func (repo *GitlabRepo) GetProjectLanguages(projectId int) (map[string]float32, error) {
 	repo.assertIsConnected()
@@ -36,16 +36,29 @@ func ProjectFromGoGitlab(gitlabProject goGitlab.Project) *model.Project {
 	log.Printf("%s defaultBranch: %s", gitlabProject.Name, gitlabProject.DefaultBranch)
 
 	return &model.Project{
-		Name:          gitlabProject.Name,
-		ID:            gitlabProject.ID,
-		Visibility:    VisibilityFromGoGitlab(gitlabProject.Visibility),
-		WebUrl:        gitlabProject.WebURL,
-		Description:   gitlabProject.Description,
-		Owner:         owner,
-		DefaultBranch: gitlabProject.DefaultBranch,
+		Name:           gitlabProject.Name,
+		ID:             gitlabProject.ID,
+		Visibility:     VisibilityFromGoGitlab(gitlabProject.Visibility),
+		WebUrl:         gitlabProject.WebURL,
+		Description:    gitlabProject.Description,
+		Owner:          owner,
+		DefaultBranch:  gitlabProject.DefaultBranch,
+		Archived:       gitlabProject.Archived,
+		EmptyRepo:      gitlabProject.EmptyRepo,
+		ForkingEnabled: gitlabProject.ForkingAccessLevel != goGitlab.DisabledAccessControl,
+		LastActivityAt: gitlabProject.LastActivityAt,
 	}
 }
 
+// ProjectsFromGoGitlab converts the projects without fetching their members.
+func ProjectsFromGoGitlab(gitlabProjects []*goGitlab.Project) []*model.Project {
+	projects := make([]*model.Project, len(gitlabProjects))
+	for i, gitlabProject := range gitlabProjects {
+		projects[i] = ProjectFromGoGitlab(*gitlabProject)
+	}
+	return projects
+}
+
 func ProjectFromGoGitlabWithProjectMembers(gitlabProject goGitlab.Project, gitlabMembers []*goGitlab.ProjectMember) *model.Project {
 	var owner *model.User = nil
 	if gitlabProject.Owner != nil {
@@ -58,16 +71,20 @@ func ProjectFromGoGitlabWithProjectMembers(gitlabProject goGitlab.Project, gitla
 	}
 
 	return &model.Project{
-		Name:          gitlabProject.Name,
-		ID:            gitlabProject.ID,
-		Visibility:    VisibilityFromGoGitlab(gitlabProject.Visibility),
-		WebUrl:        gitlabProject.WebURL,
-		Description:   gitlabProject.Description,
-		Owner:         owner,
-		Members:       members,
-		DefaultBranch: gitlabProject.DefaultBranch,
-		HTTPURLToRepo: gitlabProject.HTTPURLToRepo,
-		SSHURLToRepo:  gitlabProject.SSHURLToRepo,
+		Name:           gitlabProject.Name,
+		ID:             gitlabProject.ID,
+		Visibility:     VisibilityFromGoGitlab(gitlabProject.Visibility),
+		WebUrl:         gitlabProject.WebURL,
+		Description:    gitlabProject.Description,
+		Owner:          owner,
+		Members:        members,
+		DefaultBranch:  gitlabProject.DefaultBranch,
+		HTTPURLToRepo:  gitlabProject.HTTPURLToRepo,
+		SSHURLToRepo:   gitlabProject.SSHURLToRepo,
+		Archived:       gitlabProject.Archived,
+		EmptyRepo:      gitlabProject.EmptyRepo,
+		ForkingEnabled: gitlabProject.ForkingAccessLevel != goGitlab.DisabledAccessControl,
+		LastActivityAt: gitlabProject.LastActivityAt,
 	}
 }
 
@@ -324,7 +341,119 @@ func GroupAccessTokenFromGoGitlabGroupAccessToken(input goGitlab.GroupAccessToke
 		Scopes:      input.Scopes,
 		CreatedAt:   time.Time(*input.CreatedAt),
 		ExpiresAt:   time.Time(*input.ExpiresAt),
+		Active:      input.Active,
+		Revoked:     input.Revoked,
 		Token:       input.Token,
 		AccessLevel: AccessLevelFromGoGitlab(input.AccessLevel),
 	}
 }
+
+func MergeRequestFromGoGitlab(input goGitlab.MergeRequest) *model.MergeRequest {
+	return &model.MergeRequest{
+		ID:           input.ID,
+		IID:          input.IID,
+		Title:        input.Title,
+		SourceBranch: input.SourceBranch,
+		TargetBranch: input.TargetBranch,
+		State:        input.State,
+		WebURL:       input.WebURL,
+	}
+}
+
+func NoteFromGoGitlab(input goGitlab.Note) *model.Note {
+	return &model.Note{
+		ID:        input.ID,
+		Body:      input.Body,
+		CreatedAt: input.CreatedAt,
+		UpdatedAt: input.UpdatedAt,
+	}
+}
+
+func DiscussionFromGoGitlab(input goGitlab.Discussion) *model.Discussion {
+	notes := make([]*model.Note, len(input.Notes))
+	resolvable, resolved := false, true
+	for i, note := range input.Notes {
+		notes[i] = NoteFromGoGitlab(*note)
+		if note.Resolvable {
+			resolvable = true
+			resolved = resolved && note.Resolved
+		}
+	}
+
+	return &model.Discussion{
+		ID:       input.ID,
+		Resolved: resolvable && resolved,
+		Notes:    notes,
+	}
+}
+
+func TreeNodeFromGoGitlab(input goGitlab.TreeNode) *model.TreeNode {
+	return &model.TreeNode{
+		ID:   input.ID,
+		Name: input.Name,
+		Type: model.TreeNodeType(input.Type),
+		Path: input.Path,
+	}
+}
+
+func CommitFromGoGitlab(input goGitlab.Commit) *model.Commit {
+	return &model.Commit{
+		ID:            input.ID,
+		ShortID:       input.ShortID,
+		Title:         input.Title,
+		AuthorName:    input.AuthorName,
+		CommittedDate: input.CommittedDate,
+		WebURL:        input.WebURL,
+	}
+}
+
+func CompareFromGoGitlab(input goGitlab.Compare) *model.Compare {
+	commits := make([]*model.Commit, len(input.Commits))
+	for i, commit := range input.Commits {
+		commits[i] = CommitFromGoGitlab(*commit)
+	}
+
+	diffs := make([]*model.Diff, len(input.Diffs))
+	for i, diff := range input.Diffs {
+		diffs[i] = &model.Diff{
+			OldPath:     diff.OldPath,
+			NewPath:     diff.NewPath,
+			Diff:        diff.Diff,
+			NewFile:     diff.NewFile,
+			RenamedFile: diff.RenamedFile,
+			DeletedFile: diff.DeletedFile,
+		}
+	}
+
+	return &model.Compare{Commits: commits, Diffs: diffs}
+}
+
+func RunnerDetailsFromGoGitlab(input goGitlab.RunnerDetails) *model.RunnerDetails {
+	return &model.RunnerDetails{
+		ID:          input.ID,
+		Description: input.Description,
+		Name:        input.Name,
+		RunnerType:  input.RunnerType,
+		Status:      input.Status,
+		Online:      input.Online,
+		Paused:      input.Paused,
+		TagList:     input.TagList,
+		RunUntagged: input.RunUntagged,
+		ContactedAt: input.ContactedAt,
+	}
+}
+
+func JobFromGoGitlab(input goGitlab.Job) *model.Job {
+	return &model.Job{
+		ID:             input.ID,
+		Name:           input.Name,
+		Stage:          input.Stage,
+		Status:         input.Status,
+		TagList:        input.TagList,
+		CreatedAt:      input.CreatedAt,
+		StartedAt:      input.StartedAt,
+		QueuedDuration: input.QueuedDuration,
+		PipelineID:     input.Pipeline.ID,
+		WebURL:         input.WebURL,
+	}
+}
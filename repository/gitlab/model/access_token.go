@@ -9,6 +9,8 @@ type GroupAccessToken struct {
 	Scopes      []string
 	CreatedAt   time.Time
 	ExpiresAt   time.Time
+	Active      bool
+	Revoked     bool
 	Token       string
 	AccessLevel AccessLevelValue
 }
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type Commit struct {
+	ID            string     `json:"id"`
+	ShortID       string     `json:"short_id"`
+	Title         string     `json:"title"`
+	AuthorName    string     `json:"author_name"`
+	CommittedDate *time.Time `json:"committed_date"`
+	WebURL        string     `json:"web_url"`
+} //@Name Commit
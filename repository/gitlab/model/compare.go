@@ -0,0 +1,15 @@
+package model
+
+type Compare struct {
+	Commits []*Commit `json:"commits"`
+	Diffs   []*Diff   `json:"diffs"`
+} //@Name Compare
+
+type Diff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	Diff        string `json:"diff"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+} //@Name Diff
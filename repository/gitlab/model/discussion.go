@@ -0,0 +1,8 @@
+package model
+
+type Discussion struct {
+	ID string
+	// Resolved is true if all resolvable notes of the discussion are resolved
+	Resolved bool
+	Notes    []*Note
+}
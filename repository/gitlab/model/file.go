@@ -0,0 +1,10 @@
+package model
+
+type File struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Size     int    `json:"size"`
+	Ref      string `json:"ref"`
+	CommitID string `json:"commit_id"`
+	Content  string `json:"content"`
+} //@Name RepositoryFile
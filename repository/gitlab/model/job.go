@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type Job struct {
+	ID             int        `json:"id"`
+	Name           string     `json:"name"`
+	Stage          string     `json:"stage"`
+	Status         string     `json:"status"`
+	TagList        []string   `json:"tag_list"`
+	CreatedAt      *time.Time `json:"created_at"`
+	StartedAt      *time.Time `json:"started_at"`
+	QueuedDuration float64    `json:"queued_duration"`
+	PipelineID     int        `json:"pipeline_id"`
+	WebURL         string     `json:"web_url"`
+} //@Name Job
@@ -0,0 +1,11 @@
+package model
+
+type MergeRequest struct {
+	ID           int
+	IID          int
+	Title        string
+	SourceBranch string
+	TargetBranch string
+	State        string
+	WebURL       string
+}
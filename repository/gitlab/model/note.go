@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type Note struct {
+	ID        int
+	Body      string
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
@@ -3,14 +3,14 @@ package model
 import "time"
 
 type Pipeline struct {
-	ID          int
-	Status      string
-	Ref         string
-	UpdatedAt   *time.Time
-	CreatedAt   *time.Time
-	StartedAt   *time.Time
-	FinishedAt  *time.Time
-	CommittedAt *time.Time
-	Duration    int
-	WebURL      string
-}
+	ID          int        `json:"id"`
+	Status      string     `json:"status"`
+	Ref         string     `json:"ref"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CreatedAt   *time.Time `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	CommittedAt *time.Time `json:"committed_at"`
+	Duration    int        `json:"duration"`
+	WebURL      string     `json:"web_url"`
+} //@Name Pipeline
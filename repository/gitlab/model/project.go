@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type Visibility int
 
 const (
@@ -19,4 +21,9 @@ type Project struct {
 	Members       []User
 	HTTPURLToRepo string
 	SSHURLToRepo  string
+	Archived      bool
+	EmptyRepo     bool
+	// ForkingEnabled is false if the forking of the project is disabled for everyone
+	ForkingEnabled bool
+	LastActivityAt *time.Time
 }
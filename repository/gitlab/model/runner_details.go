@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type RunnerDetails struct {
+	ID          int        `json:"id"`
+	Description string     `json:"description"`
+	Name        string     `json:"name"`
+	RunnerType  string     `json:"runner_type"`
+	Status      string     `json:"status"`
+	Online      bool       `json:"online"`
+	Paused      bool       `json:"paused"`
+	TagList     []string   `json:"tag_list"`
+	RunUntagged bool       `json:"run_untagged"`
+	ContactedAt *time.Time `json:"contacted_at"`
+} //@Name RunnerDetails
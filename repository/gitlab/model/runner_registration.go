@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+type RunnerRegistration struct {
+	ID             int        `json:"id"`
+	Token          string     `json:"token"`
+	TokenExpiresAt *time.Time `json:"token_expires_at"`
+} //@Name RunnerRegistration
@@ -0,0 +1,15 @@
+package model
+
+type TreeNodeType string
+
+const (
+	TreeNodeBlob TreeNodeType = "blob"
+	TreeNodeTree TreeNodeType = "tree"
+)
+
+type TreeNode struct {
+	ID   string       `json:"id"`
+	Name string       `json:"name"`
+	Type TreeNodeType `json:"type"`
+	Path string       `json:"path"`
+} //@Name TreeNode
@@ -43,10 +43,13 @@ type Repository interface {
 
 	// Project
 	CreateProject(name string, visibility model.Visibility, description string, member []model.User) (*model.Project, error)
+	CreateGroupProject(groupId int, name string, description string, topics []string) (*model.Project, error)
 	DeleteProject(id int) error
 	GetAllProjects(search string) ([]*model.Project, error)
 	GetProjectById(id int) (*model.Project, error)
 	GetAllProjectsOfGroup(id int) ([]*model.Project, error)
+	GetProjectsOfGroupPage(groupId int, search string, page int, perPage int) ([]*model.Project, int, error)
+	GetProjectsWithTopicPage(topic string, search string, page int, perPage int) ([]*model.Project, int, error)
 	SearchProjectByExpression(expression string) ([]*model.Project, error)
 	CreateProjectInvite(projectId int, email string) error
 	GetPendingProjectInvitations(projectId int) ([]*model.PendingInvite, error)
@@ -65,6 +68,8 @@ type Repository interface {
 	GetProjectLatestPipeline(projectId int, ref *string) (*model.Pipeline, error)
 	GetProjectPipelineTestReportSummary(projectId, pipelineId int) (*model.TestReport, error)
 	GetProjectLatestPipelineTestReportSummary(projectId int, ref *string) (*model.TestReport, error)
+	RetryPipeline(projectId int, pipelineId int) (*model.Pipeline, error)
+	CreatePipeline(projectId int, ref *string) (*model.Pipeline, error)
 
 	// Branches
 	CreateBranch(projectId int, branchName string, fromBranch string) (*model.Branch, error)
@@ -74,9 +79,29 @@ type Repository interface {
 	ProtectedBranchExists(projectId int, branchName string) (bool, error)
 	BranchExists(projectId int, branchName string) (bool, error)
 
+	// Merge requests
+	GetMergeRequestByTargetBranch(projectId int, targetBranch string) (*model.MergeRequest, error)
+	CreateMergeRequestNote(projectId int, mergeRequestIID int, body string) (*model.Note, error)
+	UpdateMergeRequestNote(projectId int, mergeRequestIID int, noteId int, body string) (*model.Note, error)
+	CreateMergeRequestDiffDiscussion(projectId int, mergeRequestIID int, filePath string, line int, body string) (*model.Discussion, error)
+	GetMergeRequestDiscussions(projectId int, mergeRequestIID int) ([]*model.Discussion, error)
+
+	// Repository
+	GetRepositoryTree(projectId int, ref string, path string, recursive bool) ([]*model.TreeNode, error)
+	GetRepositoryFile(projectId int, filePath string, ref string) (*model.File, error)
+	GetLatestCommit(projectId int, ref string, until *time.Time) (*model.Commit, error)
+	CreateCommit(projectId int, branch string, message string, files map[string]string) (*model.Commit, error)
+	CompareRefs(projectId int, from string, to string) (*model.Compare, error)
+
 	// Runners
 	GetAvailableRunnersForGitLab() ([]*model.Runner, error)
 	GetAvailableRunnersForGroup(groupId int) ([]*model.Runner, error)
+	GetRunnerDetailsForGroup(groupId int) ([]*model.RunnerDetails, error)
+	GetProjectJobs(projectId int, statuses ...string) ([]*model.Job, error)
+	CreateGroupRunner(groupId int, description string, tags []string, runUntagged bool) (*model.RunnerRegistration, error)
+	GetRunnersOfGroup(groupId int) ([]*model.RunnerDetails, error)
+	PauseRunner(runnerId int, paused bool) error
+	DeleteRunner(runnerId int) error
 	CheckIfFileExistsInProject(projectId int, filePath string) (bool, error)
 	GetProjectLanguages(projectId int) (map[string]float32, error)
 }
@@ -2,43 +2,86 @@ package mail
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"embed"
+	"errors"
+	"html"
 	"html/template"
+	"io/fs"
+	"log"
+	netMail "net/mail"
 	"net/url"
+	"os"
+	"slices"
+	"time"
 
 	mailConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/mail"
-	"gopkg.in/gomail.v2"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gorm.io/gorm"
 )
 
 //go:embed templates
 var mailTemplates embed.FS
 
-// GoMailRepository is a repository that manages sending emails using the gomail package.
-// It stores the public URL and a configured mail dialer.
+// SupportedLanguages contains the languages the mail templates are available in.
+var SupportedLanguages = []string{"en", "de"}
+
+// GoMailRepository is a repository that renders emails and delivers them with the configured transport.
+// It stores the public URL, the sender settings and the sender of the transport.
 type GoMailRepository struct {
-	publicURL *url.URL
-	dialer    *gomail.Dialer
+	publicURL       *url.URL
+	sender          sender
+	templates       fs.FS
+	fromName        string
+	fromAddress     string
+	replyTo         string
+	logoURL         string
+	defaultLanguage string
 }
 
 // NewMailRepository creates a new instance of GoMailRepository.
+// If a template directory is configured, templates found there take precedence over the embedded ones.
+// Depending on the configured transport, the mails are sent via SMTP, written to .eml files or kept in memory.
 func NewMailRepository(publicURL *url.URL, config mailConfig.Config) (*GoMailRepository, error) {
-	dialer := gomail.NewDialer(config.GetHost(), config.GetPort(), config.GetUser(), config.GetPassword())
-	dialer.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	sender, err := newSender(config)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, err := fs.Sub(mailTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	var templates fs.FS = embedded
+	if dir := config.GetTemplateDir(); dir != "" {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, err
+		}
+		templates = overlayFS{primary: os.DirFS(dir), fallback: embedded}
+	}
+
+	defaultLanguage := config.GetDefaultLanguage()
+	if !slices.Contains(SupportedLanguages, defaultLanguage) {
+		defaultLanguage = SupportedLanguages[0]
+	}
+
 	return &GoMailRepository{
-		publicURL: publicURL,
-		dialer:    dialer,
+		publicURL:       publicURL,
+		sender:          sender,
+		templates:       templates,
+		fromName:        config.GetFromName(),
+		fromAddress:     config.GetFromAddress(),
+		replyTo:         config.GetReplyTo(),
+		logoURL:         config.GetLogoURL(),
+		defaultLanguage: defaultLanguage,
 	}, nil
 }
 
 // SendClassroomInvitation sends an email invitation for a classroom to the recipient.
 // The email is rendered from the 'invitation' template and includes dynamic data.
 func (m *GoMailRepository) SendClassroomInvitation(to string, subject string, data ClassroomInvitationData) error {
-	t, err := template.ParseFS(
-		mailTemplates,
-		"templates/base.tmpl.html",
-		"templates/invitation.tmpl.html",
-	)
+	t, err := m.parseTemplate(to, "invitation")
 	if err != nil {
 		return err
 	}
@@ -54,12 +97,7 @@ func (m *GoMailRepository) SendClassroomInvitation(to string, subject string, da
 // SendAssignmentNotification sends an email notification about an assignment to the recipient.
 // The email is rendered from the 'assignmentNotification' template and includes dynamic data.
 func (m *GoMailRepository) SendAssignmentNotification(to string, subject string, data AssignmentNotificationData) error {
-	t, err := template.ParseFS(
-		mailTemplates,
-		"templates/base.tmpl.html",
-		"templates/assignmentNotification.tmpl.html",
-	)
-
+	t, err := m.parseTemplate(to, "assignmentNotification")
 	if err != nil {
 		return err
 	}
@@ -72,21 +110,175 @@ func (m *GoMailRepository) SendAssignmentNotification(to string, subject string,
 	return m.sendMail(to, subject, t, data)
 }
 
+// SendClassroomRemovalNotification notifies the recipient that they have been removed from a classroom.
+// The email is rendered from the 'removalNotification' template.
+func (m *GoMailRepository) SendClassroomRemovalNotification(to string, subject string, data ClassroomRemovalData) error {
+	t, err := m.parseTemplate(to, "removalNotification")
+	if err != nil {
+		return err
+	}
+
+	return m.sendMail(to, subject, t, data)
+}
+
+// SendTeamChangeNotification notifies the recipient that they have been moved to another team or removed from their team.
+// The email is rendered from the 'teamChangeNotification' template.
+func (m *GoMailRepository) SendTeamChangeNotification(to string, subject string, data TeamChangeData) error {
+	t, err := m.parseTemplate(to, "teamChangeNotification")
+	if err != nil {
+		return err
+	}
+	publicURL, err := m.generateExternalURL(data.Path)
+	if err != nil {
+		return err
+	}
+	data.Path = publicURL.String()
+
+	return m.sendMail(to, subject, t, data)
+}
+
+// SendGradesReleasedNotification notifies the recipient that the project of their team has been graded.
+// The email is rendered from the 'gradesReleasedNotification' template.
+func (m *GoMailRepository) SendGradesReleasedNotification(to string, subject string, data GradesReleasedData) error {
+	t, err := m.parseTemplate(to, "gradesReleasedNotification")
+	if err != nil {
+		return err
+	}
+	publicURL, err := m.generateExternalURL(data.Path)
+	if err != nil {
+		return err
+	}
+	data.Path = publicURL.String()
+
+	return m.sendMail(to, subject, t, data)
+}
+
+// SendDeadlineReminder reminds the recipient of the upcoming due date of an accepted assignment.
+// The email is rendered from the 'deadlineReminder' template.
+func (m *GoMailRepository) SendDeadlineReminder(to string, subject string, data AssignmentReminderData) error {
+	return m.sendAssignmentReminder(to, subject, "deadlineReminder", data)
+}
+
+// SendAcceptanceReminder reminds the recipient to accept an assignment before its due date.
+// The email is rendered from the 'acceptanceReminder' template.
+func (m *GoMailRepository) SendAcceptanceReminder(to string, subject string, data AssignmentReminderData) error {
+	return m.sendAssignmentReminder(to, subject, "acceptanceReminder", data)
+}
+
+// SendNotificationDigest sends the collected notifications of the recipient in one email.
+// The email is rendered from the 'notificationDigest' template.
+func (m *GoMailRepository) SendNotificationDigest(to string, subject string, data NotificationDigestData) error {
+	t, err := m.parseTemplate(to, "notificationDigest")
+	if err != nil {
+		return err
+	}
+	for i, notification := range data.Notifications {
+		if notification.Path == "" {
+			continue
+		}
+		publicURL, err := m.generateExternalURL(notification.Path)
+		if err != nil {
+			return err
+		}
+		data.Notifications[i].Path = publicURL.String()
+	}
+
+	return m.sendMail(to, subject, t, data)
+}
+
+func (m *GoMailRepository) sendAssignmentReminder(to string, subject string, templateName string, data AssignmentReminderData) error {
+	t, err := m.parseTemplate(to, templateName)
+	if err != nil {
+		return err
+	}
+	publicURL, err := m.generateExternalURL(data.Path)
+	if err != nil {
+		return err
+	}
+	data.Path = publicURL.String()
+
+	return m.sendMail(to, subject, t, data)
+}
+
+// parseTemplate parses the base template together with the named template in the language of the recipient.
+// If the template is not available in this language, the default language is used.
+func (m *GoMailRepository) parseTemplate(to string, name string) (*template.Template, error) {
+	return m.parseLanguageTemplate(m.recipientLanguage(to), name)
+}
+
+func (m *GoMailRepository) parseLanguageTemplate(language string, name string) (*template.Template, error) {
+	path := ""
+	for _, candidate := range []string{language, m.defaultLanguage, SupportedLanguages[0]} {
+		path = candidate + "/" + name + ".tmpl.html"
+		if _, err := fs.Stat(m.templates, path); err == nil {
+			language = candidate
+			break
+		}
+	}
+
+	return template.New(name).
+		Funcs(template.FuncMap{
+			"lang":       func() string { return language },
+			"logoURL":    func() string { return m.logoURL },
+			"senderName": func() string { return m.fromName },
+		}).
+		ParseFS(m.templates, "base.tmpl.html", path)
+}
+
+// recipientLanguage returns the language chosen by the user with the given email address.
+// Recipients without an account or a chosen language get the default language.
+func (m *GoMailRepository) recipientLanguage(to string) string {
+	queryUser := query.User
+	user, err := queryUser.WithContext(context.Background()).Where(queryUser.GitlabEmail.Eq(to)).First()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Could not determine language of %s: %s", to, err.Error())
+		}
+		return m.defaultLanguage
+	}
+
+	if user.Language == nil || !slices.Contains(SupportedLanguages, *user.Language) {
+		return m.defaultLanguage
+	}
+	return *user.Language
+}
+
+// sendMail renders the template and sends it as HTML with a plain text alternative.
+// A "subject" defined by the template replaces the given subject, so that it can be localized.
 func (m *GoMailRepository) sendMail(to string, subject string, t *template.Template, data interface{}) error {
 	var tpl bytes.Buffer
 	if err := t.ExecuteTemplate(&tpl, "base", data); err != nil {
 		return err
 	}
 
-	result := tpl.String()
+	var content bytes.Buffer
+	if err := t.ExecuteTemplate(&content, "content", data); err != nil {
+		return err
+	}
 
-	mail := gomail.NewMessage()
-	mail.SetHeader("From", m.dialer.Username)
-	mail.SetHeader("To", to)
-	mail.SetHeader("Subject", subject)
-	mail.SetBody("text/html", result)
+	if t.Lookup("subject") != nil {
+		var localizedSubject bytes.Buffer
+		if err := t.ExecuteTemplate(&localizedSubject, "subject", data); err != nil {
+			return err
+		}
+		subject = html.UnescapeString(localizedSubject.String())
+	}
+
+	return m.sender.send(&Message{
+		From:    (&netMail.Address{Name: m.fromName, Address: m.fromAddress}).String(),
+		ReplyTo: m.replyTo,
+		To:      to,
+		Subject: subject,
+		Text:    htmlToText(content.String()),
+		HTML:    tpl.String(),
+		SentAt:  time.Now(),
+	})
+}
 
-	return m.dialer.DialAndSend(mail)
+// Mailbox returns the mailbox keeping the mails, if the memory transport is configured.
+func (m *GoMailRepository) Mailbox() (*MemoryMailbox, bool) {
+	mailbox, ok := m.sender.(*MemoryMailbox)
+	return mailbox, ok
 }
 
 func (m *GoMailRepository) generateExternalURL(path string) (*url.URL, error) {
@@ -96,3 +288,21 @@ func (m *GoMailRepository) generateExternalURL(path string) (*url.URL, error) {
 	}
 	return url.Parse(newPath)
 }
+
+// overlayFS opens files from the primary file system and falls back to the second one,
+// if they don't exist there.
+type overlayFS struct {
+	primary  fs.FS
+	fallback fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	file, err := o.primary.Open(name)
+	if err == nil {
+		return file, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return o.fallback.Open(name)
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	mailConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/mail"
+)
+
+func TestTemplates(t *testing.T) {
+	publicURL, _ := url.Parse("https://classroom.example.com")
+
+	templates := map[string]any{
+		"invitation":                 ClassroomInvitationData{ClassroomName: "Classroom", InvitationPath: "/invite", ExpireDate: time.Now()},
+		"assignmentNotification":     AssignmentNotificationData{ClassroomName: "Classroom", AssignmentName: "Assignment", JoinPath: "/join"},
+		"removalNotification":        ClassroomRemovalData{ClassroomName: "Classroom"},
+		"teamChangeNotification":     TeamChangeData{ClassroomName: "Classroom", TeamName: "Team", Path: "/classroom"},
+		"gradesReleasedNotification": GradesReleasedData{ClassroomName: "Classroom", AssignmentName: "Assignment", Path: "/classroom"},
+		"deadlineReminder":           AssignmentReminderData{AssignmentName: "Assignment", DueDate: time.Now(), Path: "/classroom"},
+		"acceptanceReminder":         AssignmentReminderData{AssignmentName: "Assignment", DueDate: time.Now(), Path: "/accept"},
+		"notificationDigest":         NotificationDigestData{Notifications: []DigestNotification{{Subject: "Subject", CreatedAt: time.Now()}}},
+	}
+
+	t.Run("renders all templates in all languages", func(t *testing.T) {
+		repo, err := NewMailRepository(publicURL, &mailConfig.MailConfig{FromName: "University", LogoURL: "https://example.com/logo.png"})
+		assert.NoError(t, err)
+
+		for _, language := range SupportedLanguages {
+			for name, data := range templates {
+				tpl, err := repo.parseLanguageTemplate(language, name)
+				assert.NoError(t, err, "%s/%s", language, name)
+
+				var buf bytes.Buffer
+				assert.NoError(t, tpl.ExecuteTemplate(&buf, "base", data), "%s/%s", language, name)
+				assert.Contains(t, buf.String(), `lang="`+language+`"`)
+				assert.Contains(t, buf.String(), "https://example.com/logo.png")
+			}
+		}
+	})
+
+	t.Run("falls back to the default language", func(t *testing.T) {
+		repo, err := NewMailRepository(publicURL, &mailConfig.MailConfig{FromName: "GitClassrooms", DefaultLanguage: "de"})
+		assert.NoError(t, err)
+
+		tpl, err := repo.parseLanguageTemplate("fr", "invitation")
+		assert.NoError(t, err)
+		assert.NotNil(t, tpl.Lookup("subject"))
+	})
+
+	t.Run("prefers templates from the template directory", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.Mkdir(filepath.Join(dir, "en"), 0o755))
+		err := os.WriteFile(filepath.Join(dir, "en", "invitation.tmpl.html"), []byte(`{{define "title"}}{{end}}{{define "preheader"}}{{end}}{{define "content"}}Custom invitation{{end}}`), 0o644)
+		assert.NoError(t, err)
+
+		repo, err := NewMailRepository(publicURL, &mailConfig.MailConfig{FromName: "GitClassrooms", TemplateDir: dir})
+		assert.NoError(t, err)
+
+		tpl, err := repo.parseLanguageTemplate("en", "invitation")
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		assert.NoError(t, tpl.ExecuteTemplate(&buf, "base", templates["invitation"]))
+		assert.Contains(t, buf.String(), "Custom invitation")
+		assert.Contains(t, buf.String(), "<h1>GitClassrooms</h1>")
+	})
+}
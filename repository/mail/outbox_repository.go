@@ -0,0 +1,131 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+)
+
+// OutboxRepository implements Repository by writing the mails to the outbox instead of sending them.
+// Created with the query of a transaction, the mails are only sent if the transaction is committed.
+type OutboxRepository struct {
+	ctx         context.Context
+	tx          *query.Query
+	classroomID *uuid.UUID
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository writing to the outbox with the given query.
+// The mails are listed in the outbox of the classroom, if a classroom ID is given.
+func NewOutboxRepository(ctx context.Context, tx *query.Query, classroomID *uuid.UUID) *OutboxRepository {
+	return &OutboxRepository{ctx: ctx, tx: tx, classroomID: classroomID}
+}
+
+// SendClassroomInvitation writes a classroom invitation to the outbox.
+func (r *OutboxRepository) SendClassroomInvitation(to string, subject string, data ClassroomInvitationData) error {
+	return r.enqueue(database.ClassroomInvitationMail, to, subject, data, &data.InvitationID)
+}
+
+// SendAssignmentNotification writes an assignment notification to the outbox.
+func (r *OutboxRepository) SendAssignmentNotification(to string, subject string, data AssignmentNotificationData) error {
+	return r.enqueue(database.AssignmentNotificationMail, to, subject, data, nil)
+}
+
+// SendClassroomRemovalNotification writes a classroom removal notification to the outbox.
+func (r *OutboxRepository) SendClassroomRemovalNotification(to string, subject string, data ClassroomRemovalData) error {
+	return r.enqueue(database.ClassroomRemovalMail, to, subject, data, nil)
+}
+
+// SendTeamChangeNotification writes a team change notification to the outbox.
+func (r *OutboxRepository) SendTeamChangeNotification(to string, subject string, data TeamChangeData) error {
+	return r.enqueue(database.TeamChangeMail, to, subject, data, nil)
+}
+
+// SendGradesReleasedNotification writes a grades released notification to the outbox.
+func (r *OutboxRepository) SendGradesReleasedNotification(to string, subject string, data GradesReleasedData) error {
+	return r.enqueue(database.GradesReleasedMail, to, subject, data, nil)
+}
+
+// SendDeadlineReminder writes a deadline reminder to the outbox.
+func (r *OutboxRepository) SendDeadlineReminder(to string, subject string, data AssignmentReminderData) error {
+	return r.enqueue(database.DeadlineReminderMail, to, subject, data, nil)
+}
+
+// SendAcceptanceReminder writes an acceptance reminder to the outbox.
+func (r *OutboxRepository) SendAcceptanceReminder(to string, subject string, data AssignmentReminderData) error {
+	return r.enqueue(database.AcceptanceReminderMail, to, subject, data, nil)
+}
+
+// SendNotificationDigest writes a notification digest to the outbox.
+func (r *OutboxRepository) SendNotificationDigest(to string, subject string, data NotificationDigestData) error {
+	return r.enqueue(database.NotificationDigestMail, to, subject, data, nil)
+}
+
+func (r *OutboxRepository) enqueue(kind database.MailKind, to string, subject string, data any, invitationID *uuid.UUID) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return r.tx.OutgoingMail.WithContext(r.ctx).Create(&database.OutgoingMail{
+		ClassroomID:           r.classroomID,
+		ClassroomInvitationID: invitationID,
+		Kind:                  kind,
+		Recipient:             to,
+		Subject:               subject,
+		Data:                  encoded,
+		Status:                database.MailPending,
+		NextAttemptAt:         time.Now(),
+	})
+}
+
+// DeliverOutgoingMail sends a mail of the outbox with the given repository.
+func DeliverOutgoingMail(repo Repository, mail *database.OutgoingMail) error {
+	switch mail.Kind {
+	case database.ClassroomInvitationMail:
+		return deliver(mail, repo.SendClassroomInvitation)
+	case database.AssignmentNotificationMail:
+		return deliver(mail, repo.SendAssignmentNotification)
+	case database.ClassroomRemovalMail:
+		return deliver(mail, repo.SendClassroomRemovalNotification)
+	case database.TeamChangeMail:
+		return deliver(mail, repo.SendTeamChangeNotification)
+	case database.GradesReleasedMail:
+		return deliver(mail, repo.SendGradesReleasedNotification)
+	case database.DeadlineReminderMail:
+		return deliver(mail, repo.SendDeadlineReminder)
+	case database.AcceptanceReminderMail:
+		return deliver(mail, repo.SendAcceptanceReminder)
+	case database.NotificationDigestMail:
+		return deliver(mail, repo.SendNotificationDigest)
+	default:
+		return fmt.Errorf("unknown mail kind %q", mail.Kind)
+	}
+}
+
+func deliver[T any](mail *database.OutgoingMail, send func(to string, subject string, data T) error) error {
+	var data T
+	if err := json.Unmarshal(mail.Data, &data); err != nil {
+		return err
+	}
+	return send(mail.Recipient, mail.Subject, data)
+}
+
+// permanentErrorPattern matches SMTP replies with a 5xx status code, as gomail only returns their text.
+var permanentErrorPattern = regexp.MustCompile(`(^|: )5\d\d `)
+
+// IsPermanentError reports whether the mail server rejected the mail permanently, so it must not be sent again.
+func IsPermanentError(err error) bool {
+	var protocolError *textproto.Error
+	if errors.As(err, &protocolError) {
+		return protocolError.Code >= 500
+	}
+	return permanentErrorPattern.MatchString(err.Error())
+}
@@ -0,0 +1,124 @@
+package mail
+
+import (
+	"context"
+	"errors"
+
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gorm.io/gorm"
+)
+
+// ErrNotificationSuppressed is returned for notifications the recipient has turned off.
+var ErrNotificationSuppressed = errors.New("the recipient has turned off this notification")
+
+// PreferenceRepository wraps a Repository and applies the notification preferences of the recipient.
+// Notifications are sent immediately, collected for the digest of the recipient or dropped with ErrNotificationSuppressed.
+// Recipients without an account, e.g. invited email addresses, always receive their notifications immediately.
+type PreferenceRepository struct {
+	Repository
+}
+
+// NewPreferenceRepository creates a new instance of PreferenceRepository sending the mails with the given repository.
+func NewPreferenceRepository(repo Repository) *PreferenceRepository {
+	return &PreferenceRepository{Repository: repo}
+}
+
+// SendClassroomInvitation sends the invitation according to the invitations preference of the recipient.
+func (r *PreferenceRepository) SendClassroomInvitation(to string, subject string, data ClassroomInvitationData) error {
+	return r.notify(to, database.InvitationsCategory, subject, data.InvitationPath, func() error {
+		return r.Repository.SendClassroomInvitation(to, subject, data)
+	})
+}
+
+// SendAssignmentNotification sends the notification according to the new assignments preference of the recipient.
+func (r *PreferenceRepository) SendAssignmentNotification(to string, subject string, data AssignmentNotificationData) error {
+	return r.notify(to, database.NewAssignmentsCategory, subject, data.JoinPath, func() error {
+		return r.Repository.SendAssignmentNotification(to, subject, data)
+	})
+}
+
+// SendClassroomRemovalNotification sends the notification according to the team changes preference of the recipient.
+func (r *PreferenceRepository) SendClassroomRemovalNotification(to string, subject string, data ClassroomRemovalData) error {
+	return r.notify(to, database.TeamChangesCategory, subject, "", func() error {
+		return r.Repository.SendClassroomRemovalNotification(to, subject, data)
+	})
+}
+
+// SendTeamChangeNotification sends the notification according to the team changes preference of the recipient.
+func (r *PreferenceRepository) SendTeamChangeNotification(to string, subject string, data TeamChangeData) error {
+	return r.notify(to, database.TeamChangesCategory, subject, data.Path, func() error {
+		return r.Repository.SendTeamChangeNotification(to, subject, data)
+	})
+}
+
+// SendGradesReleasedNotification sends the notification according to the grades released preference of the recipient.
+func (r *PreferenceRepository) SendGradesReleasedNotification(to string, subject string, data GradesReleasedData) error {
+	return r.notify(to, database.GradesReleasedCategory, subject, data.Path, func() error {
+		return r.Repository.SendGradesReleasedNotification(to, subject, data)
+	})
+}
+
+// SendDeadlineReminder sends the reminder according to the deadline reminders preference of the recipient.
+func (r *PreferenceRepository) SendDeadlineReminder(to string, subject string, data AssignmentReminderData) error {
+	return r.notify(to, database.DeadlineRemindersCategory, subject, data.Path, func() error {
+		return r.Repository.SendDeadlineReminder(to, subject, data)
+	})
+}
+
+// SendAcceptanceReminder sends the reminder according to the deadline reminders preference of the recipient.
+func (r *PreferenceRepository) SendAcceptanceReminder(to string, subject string, data AssignmentReminderData) error {
+	return r.notify(to, database.DeadlineRemindersCategory, subject, data.Path, func() error {
+		return r.Repository.SendAcceptanceReminder(to, subject, data)
+	})
+}
+
+func (r *PreferenceRepository) notify(to string, category database.NotificationCategory, subject string, path string, send func() error) error {
+	ctx := context.Background()
+
+	queryUser := query.User
+	user, err := queryUser.WithContext(ctx).Where(queryUser.GitlabEmail.Eq(to)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return send()
+	}
+	if err != nil {
+		return err
+	}
+
+	mode, err := getNotificationMode(ctx, user.ID, category)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case database.OffMode:
+		return ErrNotificationSuppressed
+	case database.DigestMode:
+		return query.PendingNotification.WithContext(ctx).Create(&database.PendingNotification{
+			UserID:   user.ID,
+			Category: category,
+			Subject:  subject,
+			Path:     path,
+		})
+	default:
+		return send()
+	}
+}
+
+// getNotificationMode returns the mode the user has chosen for the category, which defaults to database.ImmediateMode.
+func getNotificationMode(ctx context.Context, userID int, category database.NotificationCategory) (database.NotificationMode, error) {
+	queryPreference := query.NotificationPreference
+	preference, err := queryPreference.
+		WithContext(ctx).
+		Where(queryPreference.UserID.Eq(userID)).
+		Where(queryPreference.Category.Eq(string(category))).
+		First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return database.ImmediateMode, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return preference.Mode, nil
+}
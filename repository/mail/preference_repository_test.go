@@ -0,0 +1,125 @@
+package mail
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	mailConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+	db_tests "gitlab.hs-flensburg.de/gitlab-classroom/utils/tests"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPreferenceRepository(t *testing.T) {
+	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+
+	pg, err := db_tests.StartPostgres()
+	if err != nil {
+		t.Fatalf("Failed to start postgres container: %s", err.Error())
+	}
+
+	dbURL, err := pg.ConnectionString(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to obtain connection string: %s", err.Error())
+	}
+
+	db, err := gorm.Open(postgres.Open(dbURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("could not get database connection: %s", err.Error())
+	}
+
+	err = database.MigrateDatabase(sqlDB)
+	if err != nil {
+		t.Fatalf("could not migrate database: %s", err.Error())
+	}
+
+	query.SetDefault(db)
+
+	publicURL, _ := url.Parse("https://classroom.example.com")
+	goMailRepo, err := NewMailRepository(publicURL, &mailConfig.MailConfig{FromName: "GitClassrooms", Transport: mailConfig.MemoryTransport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mailbox, _ := goMailRepo.Mailbox()
+	repo := NewPreferenceRepository(goMailRepo)
+
+	user := factory.User()
+	err = query.NotificationPreference.WithContext(context.Background()).Create(
+		&database.NotificationPreference{UserID: user.ID, Category: database.NewAssignmentsCategory, Mode: database.DigestMode},
+		&database.NotificationPreference{UserID: user.ID, Category: database.TeamChangesCategory, Mode: database.OffMode},
+		&database.NotificationPreference{UserID: user.ID, Category: database.GradesReleasedCategory, Mode: database.OffMode},
+	)
+	if err != nil {
+		t.Fatalf("could not insert notification preferences: %s", err.Error())
+	}
+
+	pendingNotifications := func(t *testing.T) []*database.PendingNotification {
+		notifications, err := query.PendingNotification.
+			WithContext(context.Background()).
+			Where(query.PendingNotification.UserID.Eq(user.ID)).
+			Find()
+		assert.NoError(t, err)
+		return notifications
+	}
+
+	t.Run("sends notifications without preference immediately", func(t *testing.T) {
+		mailbox.Clear()
+
+		err := repo.SendDeadlineReminder(user.GitlabEmail, "Reminder", AssignmentReminderData{AssignmentName: "Assignment", Path: "/classroom"})
+		assert.NoError(t, err)
+
+		messages := mailbox.Messages()
+		assert.Len(t, messages, 1)
+		assert.Equal(t, user.GitlabEmail, messages[0].To)
+		assert.Empty(t, pendingNotifications(t))
+	})
+
+	t.Run("collects digest notifications", func(t *testing.T) {
+		mailbox.Clear()
+
+		err := repo.SendAssignmentNotification(user.GitlabEmail, "New assignment", AssignmentNotificationData{JoinPath: "/join"})
+		assert.NoError(t, err)
+
+		assert.Empty(t, mailbox.Messages())
+		notifications := pendingNotifications(t)
+		assert.Len(t, notifications, 1)
+		assert.Equal(t, database.NewAssignmentsCategory, notifications[0].Category)
+		assert.Equal(t, "New assignment", notifications[0].Subject)
+		assert.Equal(t, "/join", notifications[0].Path)
+	})
+
+	t.Run("drops disabled notifications", func(t *testing.T) {
+		mailbox.Clear()
+
+		err := repo.SendTeamChangeNotification(user.GitlabEmail, "Team changed", TeamChangeData{TeamName: "Team", Path: "/classroom"})
+		assert.ErrorIs(t, err, ErrNotificationSuppressed)
+		err = repo.SendClassroomRemovalNotification(user.GitlabEmail, "Removed", ClassroomRemovalData{})
+		assert.ErrorIs(t, err, ErrNotificationSuppressed)
+		err = repo.SendGradesReleasedNotification(user.GitlabEmail, "Graded", GradesReleasedData{AssignmentName: "Assignment", Path: "/classroom"})
+		assert.ErrorIs(t, err, ErrNotificationSuppressed)
+
+		assert.Empty(t, mailbox.Messages())
+		assert.Len(t, pendingNotifications(t), 1)
+	})
+
+	t.Run("sends notifications to recipients without account immediately", func(t *testing.T) {
+		mailbox.Clear()
+
+		err := repo.SendTeamChangeNotification("unknown@example.com", "Team changed", TeamChangeData{TeamName: "Team", Path: "/classroom"})
+		assert.NoError(t, err)
+
+		messages := mailbox.Messages()
+		assert.Len(t, messages, 1)
+		assert.Equal(t, "unknown@example.com", messages[0].To)
+	})
+}
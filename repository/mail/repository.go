@@ -2,10 +2,15 @@
 // and assignment notifications. It uses templates to generate dynamic emails.
 package mail
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // ClassroomInvitationData holds the information required for sending a classroom invitation email.
 type ClassroomInvitationData struct {
+	InvitationID       uuid.UUID
 	ClassroomName      string
 	ClassroomOwnerName string
 	RecipientEmail     string
@@ -22,8 +27,60 @@ type AssignmentNotificationData struct {
 	JoinPath           string
 }
 
+// ClassroomRemovalData holds the information required for notifying a user about being removed from a classroom.
+type ClassroomRemovalData struct {
+	ClassroomName      string
+	ClassroomOwnerName string
+	RecipientName      string
+}
+
+// TeamChangeData holds the information required for notifying a user about a change of their team.
+// The team name is empty, if the user has been removed from their team.
+type TeamChangeData struct {
+	ClassroomName string
+	RecipientName string
+	TeamName      string
+	Path          string
+}
+
+// GradesReleasedData holds the information required for notifying a team member about new grades of their project.
+type GradesReleasedData struct {
+	ClassroomName  string
+	RecipientName  string
+	AssignmentName string
+	Path           string
+}
+
+// AssignmentReminderData holds the information required for reminding a team member of an upcoming due date.
+type AssignmentReminderData struct {
+	ClassroomName  string
+	RecipientName  string
+	AssignmentName string
+	DueDate        time.Time
+	Path           string
+}
+
+// DigestNotification is a single notification listed in a digest email.
+type DigestNotification struct {
+	Subject   string
+	Path      string
+	CreatedAt time.Time
+}
+
+// NotificationDigestData holds the information required for sending the collected notifications of a user in one email.
+type NotificationDigestData struct {
+	RecipientName string
+	Notifications []DigestNotification
+}
+
 // Repository is an interface that defines the contract for sending email notifications.
 type Repository interface {
 	SendClassroomInvitation(to string, subject string, data ClassroomInvitationData) error
 	SendAssignmentNotification(to string, subject string, data AssignmentNotificationData) error
+	SendClassroomRemovalNotification(to string, subject string, data ClassroomRemovalData) error
+	SendTeamChangeNotification(to string, subject string, data TeamChangeData) error
+	SendGradesReleasedNotification(to string, subject string, data GradesReleasedData) error
+	SendDeadlineReminder(to string, subject string, data AssignmentReminderData) error
+	SendAcceptanceReminder(to string, subject string, data AssignmentReminderData) error
+	SendNotificationDigest(to string, subject string, data NotificationDigestData) error
 }
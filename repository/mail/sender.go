@@ -0,0 +1,238 @@
+package mail
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	netMail "net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	mailConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/mail"
+	"gopkg.in/gomail.v2"
+)
+
+// Message is a rendered mail.
+type Message struct {
+	ID      int       `json:"id"`
+	From    string    `json:"from"`
+	ReplyTo string    `json:"replyTo"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Text    string    `json:"text"`
+	HTML    string    `json:"html"`
+	SentAt  time.Time `json:"sentAt"`
+} //@Name MailMessage
+
+// mimeMessage builds the MIME message with the HTML part as alternative to the plain text.
+func (m *Message) mimeMessage() *gomail.Message {
+	message := gomail.NewMessage()
+	message.SetHeader("From", m.From)
+	if m.ReplyTo != "" {
+		message.SetHeader("Reply-To", m.ReplyTo)
+	}
+	message.SetHeader("To", m.To)
+	message.SetHeader("Subject", m.Subject)
+	message.SetDateHeader("Date", m.SentAt)
+	message.SetBody("text/plain", m.Text)
+	message.AddAlternative("text/html", m.HTML)
+	return message
+}
+
+// sender delivers rendered mails.
+type sender interface {
+	send(message *Message) error
+}
+
+// newSender creates the sender for the configured transport.
+func newSender(config mailConfig.Config) (sender, error) {
+	switch config.GetTransport() {
+	case mailConfig.SMTPTransport:
+		return newSMTPSender(config)
+	case mailConfig.FileTransport:
+		if err := os.MkdirAll(config.GetMailDir(), 0o755); err != nil {
+			return nil, err
+		}
+		return &fileSender{dir: config.GetMailDir()}, nil
+	case mailConfig.MemoryTransport:
+		return NewMemoryMailbox(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", config.GetTransport())
+	}
+}
+
+// smtpSender sends the mails to an SMTP server.
+type smtpSender struct {
+	host      string
+	port      int
+	user      string
+	password  string
+	tlsMode   mailConfig.TLSMode
+	tlsConfig *tls.Config
+}
+
+func newSMTPSender(config mailConfig.Config) (*smtpSender, error) {
+	switch config.GetTLSMode() {
+	case mailConfig.NoTLS, mailConfig.StartTLS, mailConfig.ImplicitTLS:
+	default:
+		return nil, fmt.Errorf("unknown TLS mode %q", config.GetTLSMode())
+	}
+
+	return &smtpSender{
+		host:     config.GetHost(),
+		port:     config.GetPort(),
+		user:     config.GetUser(),
+		password: config.GetPassword(),
+		tlsMode:  config.GetTLSMode(),
+		tlsConfig: &tls.Config{
+			ServerName:         config.GetHost(),
+			InsecureSkipVerify: config.GetTLSSkipVerify(),
+		},
+	}, nil
+}
+
+func (s *smtpSender) send(message *Message) error {
+	address := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+
+	var conn net.Conn
+	var err error
+	if s.tlsMode == mailConfig.ImplicitTLS {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", address, s.tlsConfig)
+	} else {
+		conn, err = net.DialTimeout("tcp", address, 10*time.Second)
+	}
+	if err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if s.tlsMode == mailConfig.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", address)
+		}
+		if err := client.StartTLS(s.tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if ok, _ := client.Extension("AUTH"); ok && s.user != "" {
+		var auth smtp.Auth = smtp.PlainAuth("", s.user, s.password, s.host)
+		if s.tlsMode == mailConfig.NoTLS {
+			auth = unencryptedAuth{auth}
+		}
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	from, err := netMail.ParseAddress(message.From)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := message.mimeMessage().WriteTo(writer); err != nil {
+		writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// unencryptedAuth allows plain authentication without TLS, which net/smtp only permits for localhost.
+// It is used for NoTLS, which has to be chosen explicitly.
+type unencryptedAuth struct {
+	smtp.Auth
+}
+
+func (a unencryptedAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	info := *server
+	info.TLS = true
+	return a.Auth.Start(&info)
+}
+
+// fileSender writes every mail as .eml file to a directory.
+type fileSender struct {
+	dir string
+}
+
+// unsafeFileNameChars matches the characters which are replaced in the names of the written files.
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]`)
+
+func (s *fileSender) send(message *Message) error {
+	name := fmt.Sprintf("%s-%s.eml", message.SentAt.Format("20060102T150405.000000000"), unsafeFileNameChars.ReplaceAllString(message.To, "_"))
+	file, err := os.Create(filepath.Join(s.dir, name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = message.mimeMessage().WriteTo(file)
+	return err
+}
+
+// MemoryMailbox keeps the mails in memory instead of delivering them.
+// It is meant for development and tests, where the mails are inspected via the dev endpoint.
+type MemoryMailbox struct {
+	mu       sync.Mutex
+	messages []*Message
+	nextID   int
+}
+
+// NewMemoryMailbox creates a new empty MemoryMailbox.
+func NewMemoryMailbox() *MemoryMailbox {
+	return &MemoryMailbox{messages: []*Message{}, nextID: 1}
+}
+
+func (b *MemoryMailbox) send(message *Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stored := *message
+	stored.ID = b.nextID
+	b.nextID++
+	b.messages = append(b.messages, &stored)
+	return nil
+}
+
+// Messages returns the received mails, oldest first.
+func (b *MemoryMailbox) Messages() []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	messages := make([]*Message, len(b.messages))
+	copy(messages, b.messages)
+	return messages
+}
+
+// Clear removes all received mails.
+func (b *MemoryMailbox) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.messages = []*Message{}
+}
@@ -0,0 +1,142 @@
+package mail
+
+import (
+	"bufio"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	mailConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/mail"
+)
+
+// startSMTPServer starts a minimal SMTP server without STARTTLS, which sends the received data to the channel.
+func startSMTPServer(t *testing.T) (int, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch command := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(command, "EHLO"):
+				reply("250 localhost")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				received <- data.String()
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSenders(t *testing.T) {
+	publicURL, _ := url.Parse("https://classroom.example.com")
+
+	// send renders the mail without looking up the language of the recipient in the database
+	send := func(t *testing.T, repo *GoMailRepository) error {
+		tpl, err := repo.parseLanguageTemplate("en", "removalNotification")
+		assert.NoError(t, err)
+		return repo.sendMail("student@example.com", "Removed", tpl, ClassroomRemovalData{ClassroomName: "Classroom"})
+	}
+
+	t.Run("keeps mails in memory", func(t *testing.T) {
+		repo, err := NewMailRepository(publicURL, &mailConfig.MailConfig{FromName: "GitClassrooms", FromAddress: "classroom@example.com", Transport: mailConfig.MemoryTransport})
+		assert.NoError(t, err)
+
+		mailbox, ok := repo.Mailbox()
+		assert.True(t, ok)
+
+		assert.NoError(t, send(t, repo))
+
+		messages := mailbox.Messages()
+		assert.Len(t, messages, 1)
+		assert.Equal(t, 1, messages[0].ID)
+		assert.Equal(t, "student@example.com", messages[0].To)
+		assert.Equal(t, `"GitClassrooms" <classroom@example.com>`, messages[0].From)
+		assert.Contains(t, messages[0].Text, "Classroom")
+
+		mailbox.Clear()
+		assert.Empty(t, mailbox.Messages())
+	})
+
+	t.Run("writes eml files", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "mails")
+		repo, err := NewMailRepository(publicURL, &mailConfig.MailConfig{FromName: "GitClassrooms", FromAddress: "classroom@example.com", Transport: mailConfig.FileTransport, MailDir: dir})
+		assert.NoError(t, err)
+
+		_, ok := repo.Mailbox()
+		assert.False(t, ok)
+
+		assert.NoError(t, send(t, repo))
+
+		files, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		assert.True(t, strings.HasSuffix(files[0].Name(), "-student@example.com.eml"))
+
+		content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "To: student@example.com")
+		assert.Contains(t, string(content), "Subject: Removed")
+	})
+
+	t.Run("sends mails via smtp without tls", func(t *testing.T) {
+		port, received := startSMTPServer(t)
+		repo, err := NewMailRepository(publicURL, &mailConfig.MailConfig{Host: "127.0.0.1", Port: port, FromAddress: "classroom@example.com", TLSMode: mailConfig.NoTLS})
+		assert.NoError(t, err)
+
+		assert.NoError(t, send(t, repo))
+		assert.Contains(t, <-received, "Subject: Removed")
+	})
+
+	t.Run("requires starttls by default", func(t *testing.T) {
+		port, _ := startSMTPServer(t)
+		repo, err := NewMailRepository(publicURL, &mailConfig.MailConfig{Host: "127.0.0.1", Port: port, FromAddress: "classroom@example.com"})
+		assert.NoError(t, err)
+
+		err = send(t, repo)
+		assert.ErrorContains(t, err, "does not support STARTTLS")
+	})
+
+	t.Run("rejects unknown transports", func(t *testing.T) {
+		_, err := NewMailRepository(publicURL, &mailConfig.MailConfig{Transport: "pigeon"})
+		assert.Error(t, err)
+
+		_, err = NewMailRepository(publicURL, &mailConfig.MailConfig{TLSMode: "ssl"})
+		assert.Error(t, err)
+	})
+}
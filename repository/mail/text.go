@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	linkPattern       = regexp.MustCompile(`(?is)<a\s[^>]*href=['"]([^'"]*)['"][^>]*>(.*?)</a>`)
+	listItemPattern   = regexp.MustCompile(`(?i)<li[^>]*>`)
+	lineBreakPattern  = regexp.MustCompile(`(?i)<br\s*/?>|<hr\s*/?>|</(p|h[1-6]|li|ul|ol|div|center)>`)
+	tagPattern        = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	blankLinePattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText converts the rendered content of a mail template into plain text for the text/plain part.
+// Links are kept as URLs, block elements are separated by line breaks and all other markup is removed.
+func htmlToText(content string) string {
+	content = whitespacePattern.ReplaceAllString(content, " ")
+	content = linkPattern.ReplaceAllStringFunc(content, func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		href, text := match[1], strings.TrimSpace(tagPattern.ReplaceAllString(match[2], ""))
+		if text == "" || text == href {
+			return href
+		}
+		return text + " (" + href + ")"
+	})
+	content = listItemPattern.ReplaceAllString(content, "- ")
+	content = lineBreakPattern.ReplaceAllString(content, "\n")
+	content = tagPattern.ReplaceAllString(content, "")
+	content = html.UnescapeString(content)
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	content = strings.Join(lines, "\n")
+
+	return strings.TrimSpace(blankLinePattern.ReplaceAllString(content, "\n\n"))
+}
@@ -0,0 +1,24 @@
+package mail
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHtmlToText(t *testing.T) {
+	t.Run("removes markup", func(t *testing.T) {
+		text := htmlToText(`<h2>Invited to &raquo;Classroom&laquo;</h2><hr><p>Hello <i>Jane</i>.</p>`)
+		assert.Equal(t, "Invited to »Classroom«\n\nHello Jane.", text)
+	})
+
+	t.Run("keeps links", func(t *testing.T) {
+		text := htmlToText(`<p><a href='https://example.com/join'>https://example.com/join</a></p><p><a href="https://example.com">Open</a></p>`)
+		assert.Equal(t, "https://example.com/join\nOpen (https://example.com)", text)
+	})
+
+	t.Run("formats lists", func(t *testing.T) {
+		text := htmlToText("<ul>\n  <li>\n    <b>First</b>\n  </li>\n  <li>Second</li>\n</ul>")
+		assert.Equal(t, "- First\n- Second", text)
+	})
+}
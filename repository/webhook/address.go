@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+// blockedPrefixes are the networks besides the private, loopback and link-local ones, which are not reachable from the internet.
+// The shared address space of carrier-grade NAT contains the metadata services of some cloud providers.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// ErrAddressNotAllowed is returned for webhooks, which point to an internal address of the server.
+var ErrAddressNotAllowed = errors.New("webhooks can only be sent to public addresses")
+
+// CheckURL resolves the host of the webhook URL and checks that all of its addresses are public.
+// The deliveries check the address again when connecting, because the DNS records may change in the meantime.
+func CheckURL(ctx context.Context, webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := net.DefaultResolver.LookupNetIP(ctx, "ip", parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("the host of the webhook can not be resolved: %w", err)
+	}
+
+	for _, address := range addresses {
+		if !isPublic(address) {
+			return ErrAddressNotAllowed
+		}
+	}
+	return nil
+}
+
+// publicAddressControl is the control function of the dialer, which rejects the connection to addresses, which are not public.
+func publicAddressControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublic(addrPort.Addr()) {
+		return ErrAddressNotAllowed
+	}
+	return nil
+}
+
+func isPublic(address netip.Addr) bool {
+	address = address.Unmap()
+	if !address.IsGlobalUnicast() || address.IsPrivate() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(address) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{url: "https://203.0.113.10/hooks", allowed: true},
+		{url: "https://[2001:db8::1]/hooks", allowed: true},
+		{url: "http://127.0.0.1:8080/hooks", allowed: false},
+		{url: "http://localhost/hooks", allowed: false},
+		{url: "http://10.0.0.1/hooks", allowed: false},
+		{url: "http://192.168.1.1/hooks", allowed: false},
+		{url: "http://169.254.169.254/latest/meta-data", allowed: false},
+		{url: "http://100.100.100.200/latest/meta-data", allowed: false},
+		{url: "http://0.0.0.0/hooks", allowed: false},
+		{url: "http://[::1]/hooks", allowed: false},
+		{url: "http://[::ffff:127.0.0.1]/hooks", allowed: false},
+		{url: "http://[fd00:ec2::254]/hooks", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := CheckURL(context.Background(), tt.url)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrAddressNotAllowed)
+			}
+		})
+	}
+}
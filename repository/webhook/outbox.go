@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+)
+
+// Payload is the JSON body posted to the webhooks.
+type Payload struct {
+	Event       database.WebhookEvent `json:"event"`
+	ClassroomID uuid.UUID             `json:"classroomId"`
+	OccurredAt  time.Time             `json:"occurredAt"`
+	Data        any                   `json:"data"`
+}
+
+type MemberData struct {
+	UserID   int           `json:"userId"`
+	Name     string        `json:"name"`
+	Username string        `json:"username"`
+	Role     database.Role `json:"role"`
+}
+
+type TeamData struct {
+	TeamID uuid.UUID `json:"teamId"`
+	Name   string    `json:"name"`
+}
+
+type AssignmentData struct {
+	AssignmentID uuid.UUID  `json:"assignmentId"`
+	Name         string     `json:"name"`
+	DueDate      *time.Time `json:"dueDate"`
+}
+
+type ProjectData struct {
+	ProjectID       uuid.UUID `json:"projectId"`
+	AssignmentID    uuid.UUID `json:"assignmentId"`
+	TeamID          uuid.UUID `json:"teamId"`
+	GitlabProjectID int       `json:"gitlabProjectId,omitempty"`
+}
+
+// NewProjectData creates the data of the project events of the assignment project.
+func NewProjectData(project *database.AssignmentProjects) ProjectData {
+	return ProjectData{
+		ProjectID:       project.ID,
+		AssignmentID:    project.AssignmentID,
+		TeamID:          project.TeamID,
+		GitlabProjectID: project.ProjectID,
+	}
+}
+
+// Outbox writes the deliveries of the events to the webhooks of a classroom.
+// Created with the query of a transaction, the events are only delivered if the transaction is committed.
+type Outbox struct {
+	ctx         context.Context
+	tx          *query.Query
+	classroomID uuid.UUID
+}
+
+// NewOutbox creates a new instance of Outbox writing the deliveries for the webhooks of the classroom with the given query.
+func NewOutbox(ctx context.Context, tx *query.Query, classroomID uuid.UUID) *Outbox {
+	return &Outbox{ctx: ctx, tx: tx, classroomID: classroomID}
+}
+
+// Emit writes a delivery of the event for every active webhook of the classroom, which subscribed to the event.
+func (o *Outbox) Emit(event database.WebhookEvent, data any) error {
+	queryWebhook := o.tx.Webhook
+	webhooks, err := queryWebhook.
+		WithContext(o.ctx).
+		Where(queryWebhook.ClassroomID.Eq(o.classroomID)).
+		Where(queryWebhook.Active.Is(true)).
+		Find()
+	if err != nil {
+		return err
+	}
+
+	webhooks = slices.DeleteFunc(webhooks, func(webhook *database.Webhook) bool {
+		return !slices.Contains(webhook.Events, event)
+	})
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(Payload{
+		Event:       event,
+		ClassroomID: o.classroomID,
+		OccurredAt:  time.Now(),
+		Data:        data,
+	})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*database.WebhookDelivery, len(webhooks))
+	for i, webhook := range webhooks {
+		deliveries[i] = &database.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       payload,
+			Status:        database.WebhookPending,
+			NextAttemptAt: time.Now(),
+		}
+	}
+
+	return o.tx.WebhookDelivery.WithContext(o.ctx).Create(deliveries...)
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+)
+
+const (
+	EventHeader     = "X-GitClassrooms-Event"
+	DeliveryHeader  = "X-GitClassrooms-Delivery"
+	SignatureHeader = "X-GitClassrooms-Signature"
+
+	// deliveryTimeout limits the time a webhook may take to answer
+	deliveryTimeout = 10 * time.Second
+)
+
+// Sender posts the deliveries to the webhooks.
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender creates a new instance of Sender, which only connects to public addresses and does not follow redirects.
+func NewSender() *Sender {
+	dialer := &net.Dialer{Timeout: deliveryTimeout, Control: publicAddressControl}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would connect to the webhook instead of the dialer, which checks the address
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Sender{client: &http.Client{
+		Timeout:   deliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// NewSenderWithClient creates a new instance of Sender posting with the given client, which is not restricted to public addresses.
+func NewSenderWithClient(client *http.Client) *Sender {
+	return &Sender{client: client}
+}
+
+// Deliver posts the payload of the delivery to the webhook. The status code is 0 if the webhook did not answer.
+// Answers with another status than 2xx are returned as error, the body of the answer is not kept.
+func (s *Sender) Deliver(webhook *database.Webhook, delivery *database.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GitClassrooms-Webhook")
+	req.Header.Set(EventHeader, string(delivery.Event))
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook answered with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header of the payload, the hex encoded HMAC-SHA256 of the payload prefixed by "sha256=".
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret creates a random secret for the signatures of a webhook.
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+)
+
+func TestSender_Deliver(t *testing.T) {
+	webhook := &database.Webhook{Secret: "secret"}
+	delivery := &database.WebhookDelivery{
+		ID:      uuid.New(),
+		Event:   database.TeamCreatedEvent,
+		Payload: []byte(`{"event":"teamCreated"}`),
+	}
+
+	t.Run("signs the payload", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, delivery.Payload, body)
+			assert.Equal(t, "teamCreated", r.Header.Get(EventHeader))
+			assert.Equal(t, delivery.ID.String(), r.Header.Get(DeliveryHeader))
+			assert.True(t, hmac.Equal([]byte(Sign("secret", body)), []byte(r.Header.Get(SignatureHeader))))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		webhook.URL = server.URL
+
+		status, err := newLoopbackSender().Deliver(webhook, delivery)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+	})
+
+	t.Run("returns error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "broken", http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		webhook.URL = server.URL
+
+		status, err := newLoopbackSender().Deliver(webhook, delivery)
+		assert.EqualError(t, err, "webhook answered with status 500")
+		assert.Equal(t, http.StatusInternalServerError, status)
+	})
+
+	t.Run("does not follow redirects", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+		}))
+		defer server.Close()
+		webhook.URL = server.URL
+
+		status, err := newLoopbackSender().Deliver(webhook, delivery)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusFound, status)
+	})
+
+	t.Run("rejects internal addresses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		webhook.URL = server.URL
+
+		status, err := NewSender().Deliver(webhook, delivery)
+		assert.ErrorIs(t, err, ErrAddressNotAllowed)
+		assert.Equal(t, 0, status)
+	})
+}
+
+// newLoopbackSender creates a sender, which connects to the test servers on the loopback address.
+func newLoopbackSender() *Sender {
+	sender := NewSender()
+	sender.client.Transport.(*http.Transport).DialContext = (&net.Dialer{}).DialContext
+	return sender
+}
+
+func TestSign(t *testing.T) {
+	// echo -n 'payload' | openssl dgst -sha256 -hmac 'secret'
+	assert.Equal(t, "sha256=b82fcb791acec57859b989b430a826488ce2e479fdf92326bd0a2e8375a42ba4", Sign("secret", []byte("payload")))
+	assert.NotEqual(t, Sign("secret", []byte("payload")), Sign("other", []byte("payload")))
+}
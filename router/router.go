@@ -27,6 +27,11 @@ func Routes(
 ) {
 	// Init session on every request if not present
 	app.Use(func(c *fiber.Ctx) error {
+		// Requests with an API token never use the session
+		if _, ok := session.BearerToken(c); ok {
+			return c.Next()
+		}
+
 		sess := session.Get(c)
 		if sess.Session.Fresh() {
 			err := sess.Save()
@@ -58,15 +63,28 @@ func setupRoutes(api *fiber.Router, config authConfig.Config, authController aut
 	v1.Get(strings.Replace(config.GetRedirectUrl().Path, "/api/v1", "", 1), authController.Callback)
 	v1.Get("/auth/csrf", authController.GetCsrf)
 	v1.Use(authController.AuthMiddleware)
+	// The scopes of API tokens are checked against the matched route, so every following route starts with the check
+	v1 = routeMiddleware{Router: v1, handler: authController.APITokenScopeMiddleware}
 	v1.Get("/auth", authController.GetAuth)
 
 	v1.Get("/me", apiController.GetMe)
+	v1.Patch("/me", apiController.UpdateMe)
 	v1.Get("/me/gitlab", apiController.GetMeGitlab)
+	v1.Get("/me/notifications", apiController.GetMeNotifications)
+	v1.Put("/me/notifications", apiController.UpdateMeNotifications)
+	v1.Get("/me/tokens", apiController.GetMeTokens)
+	v1.Post("/me/tokens", apiController.CreateMeToken)
+	v1.Delete("/me/tokens/:tokenId", apiController.DeleteMeToken)
+	v1.Get("/me/events", apiController.GetMeEvents)
 
 	v1.Get("/assignments", apiController.GetActiveAssignments)
 
+	v1.Get("/dev/mails", apiController.GetDevMails)
+	v1.Delete("/dev/mails", apiController.DeleteDevMails)
+
 	v1.Get("/classrooms", apiController.GetClassrooms)
 	v1.Post("/classrooms", apiController.CreateClassroom)
+	v1.Post("/classrooms/import", apiController.ImportClassroom)
 
 	v1.Get("/classrooms/:classroomId/invitations/:invitationId", apiController.GetClassroomInvitation)
 	v1.Post("/classrooms/:classroomId/join", apiController.JoinClassroom) // with invitation id in the body
@@ -75,13 +93,20 @@ func setupRoutes(api *fiber.Router, config authConfig.Config, authController aut
 	v1.Get("/classrooms/:classroomId", apiController.GetClassroom)
 	v1.Put("/classrooms/:classroomId", apiController.CreatorMiddleware(), apiController.UpdateClassroom)
 	v1.Patch("/classrooms/:classroomId/archive", apiController.CreatorMiddleware(), apiController.ArchiveClassroom)
+	v1.Patch("/classrooms/:classroomId/unarchive", apiController.CreatorMiddleware(), apiController.UnarchiveClassroom)
+	v1.Post("/classrooms/:classroomId/duplicate", apiController.RoleMiddleware(database.Owner), apiController.DuplicateClassroom)
+	v1.Get("/classrooms/:classroomId/export", apiController.RoleMiddleware(database.Owner), apiController.ExportClassroom)
 	v1.Get("/classrooms/:classroomId/gitlab", apiController.RedirectGroupGitlab)
 
 	v1.Get("/classrooms/:classroomId/grading", apiController.RoleMiddleware(database.Owner), apiController.GetGradingRubrics)
 	v1.Put("/classrooms/:classroomId/grading", apiController.RoleMiddleware(database.Owner), apiController.UpdateGradingRubrics)
 	v1.Get("/classrooms/:classroomId/grading/report", apiController.RoleMiddleware(database.Owner), apiController.GetClassroomReport)
+	v1.Get("/classrooms/:classroomId/report", apiController.RoleMiddleware(database.Student), apiController.GetStudentReport)
 
 	v1.Get("/classrooms/:classroomId/templateProjects", apiController.RoleMiddleware(database.Owner), apiController.GetClassroomTemplates)
+	v1.Post("/classrooms/:classroomId/templateProjects", apiController.RoleMiddleware(database.Owner), apiController.CreateClassroomTemplate)
+	v1.Get("/classrooms/:classroomId/templateProjects/starterKits", apiController.RoleMiddleware(database.Owner), apiController.GetTemplateStarterKits)
+	v1.Get("/classrooms/:classroomId/templateProjects/:templateProjectId/validation", apiController.RoleMiddleware(database.Owner), apiController.ValidateTemplateProject)
 
 	v1.Use("/classrooms/:classroomId/assignments", apiController.ViewableClassroomMiddleware())
 	v1.Get("/classrooms/:classroomId/assignments", apiController.GetClassroomAssignments)
@@ -95,7 +120,12 @@ func setupRoutes(api *fiber.Router, config authConfig.Config, authController aut
 	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/grading", apiController.RoleMiddleware(database.Owner), apiController.GetAssignmentGradingRubrics)
 	v1.Put("/classrooms/:classroomId/assignments/:assignmentId/grading", apiController.RoleMiddleware(database.Owner), apiController.UpdateAssignmentGradingRubrics)
 	v1.Post("/classrooms/:classroomId/assignments/:assignmentId/grading/auto", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.StartAutoGrading)
+	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/grading/runs", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetAssignmentGradingRuns)
+	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/grading/runs/:runId", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetAssignmentGradingRun)
+	v1.Post("/classrooms/:classroomId/assignments/:assignmentId/grading/runs/:runId/cancel", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.CancelAssignmentGradingRun)
 	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/grading/report", apiController.RoleMiddleware(database.Owner), apiController.GetClassroomAssignmentReport)
+	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/pipelines", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetAssignmentPipelines)
+	v1.Post("/classrooms/:classroomId/assignments/:assignmentId/pipelines", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.RunAssignmentPipelines)
 
 	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/repos", apiController.GetMultipleProjectCloneUrls)
 	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects", apiController.GetClassroomAssignmentProjects)
@@ -106,10 +136,15 @@ func setupRoutes(api *fiber.Router, config authConfig.Config, authController aut
 	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetGradingResults)
 	v1.Put("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.UpdateGradingResults)
 	v1.Post("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading/auto", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.StartAutoGradingForProject)
+	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading/comments", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetGradingComments)
+	v1.Post("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/grading/comments", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.CreateGradingComment)
 
 	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/gitlab", apiController.RedirectProjectGitlab)
 	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/report/gitlab", apiController.RedirectReportGitlab)
 	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/repo", apiController.GetProjectCloneUrls)
+	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/repository/tree", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetProjectRepositoryTree)
+	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/repository/file", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetProjectRepositoryFile)
+	v1.Get("/classrooms/:classroomId/assignments/:assignmentId/projects/:projectId/repository/diff", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetProjectRepositoryDiff)
 
 	v1.Use("/classrooms/:classroomId/projects", apiController.RoleMiddleware(database.Student))
 	v1.Get("/classrooms/:classroomId/projects", apiController.GetClassroomProjects)
@@ -126,16 +161,35 @@ func setupRoutes(api *fiber.Router, config authConfig.Config, authController aut
 	v1.Post("/classrooms/:classroomId/invitations", apiController.InviteToClassroom)
 	v1.Delete("/classrooms/:classroomId/invitations/:invitationId", apiController.RevokeClassroomInvitation)
 
+	v1.Use("/classrooms/:classroomId/mails", apiController.RoleMiddleware(database.Owner))
+	v1.Get("/classrooms/:classroomId/mails", apiController.GetClassroomMails)
+	v1.Post("/classrooms/:classroomId/mails/:mailId/resend", apiController.ResendClassroomMail)
+
+	v1.Use("/classrooms/:classroomId/webhooks", apiController.RoleMiddleware(database.Owner))
+	v1.Get("/classrooms/:classroomId/webhooks", apiController.GetClassroomWebhooks)
+	v1.Post("/classrooms/:classroomId/webhooks", apiController.CreateClassroomWebhook)
+	v1.Put("/classrooms/:classroomId/webhooks/:webhookId", apiController.UpdateClassroomWebhook)
+	v1.Delete("/classrooms/:classroomId/webhooks/:webhookId", apiController.DeleteClassroomWebhook)
+	v1.Get("/classrooms/:classroomId/webhooks/:webhookId/deliveries", apiController.GetClassroomWebhookDeliveries)
+	v1.Post("/classrooms/:classroomId/webhooks/:webhookId/deliveries/:deliveryId/redeliver", apiController.RedeliverClassroomWebhookDelivery)
+
+	v1.Get("/classrooms/:classroomId/events", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetClassroomEvents)
+
 	v1.Get("/classrooms/:classroomId/members", apiController.GetClassroomMembers)
 	v1.Use("/classrooms/:classroomId/members/:memberId", apiController.ClassroomMemberMiddleware)
 	v1.Get("/classrooms/:classroomId/members/:memberId", apiController.GetClassroomMember)
 	v1.Patch("/classrooms/:classroomId/members/:memberId/team", apiController.RoleMiddleware(database.Moderator, database.Owner), apiController.UpdateMemberTeam)
 	v1.Patch("/classrooms/:classroomId/members/:memberId/role", apiController.RoleMiddleware(database.Owner), apiController.UpdateMemberRole)
-	// v1.Delete("/classrooms/:classroomId/members/:memberId", apiController.RoleMiddleware(database.Moderator, database.Owner), apiController.RemoveClassroomMember)
+	v1.Delete("/classrooms/:classroomId/members/:memberId", apiController.RoleMiddleware(database.Moderator, database.Owner), apiController.RemoveClassroomMember)
 	v1.Get("/classrooms/:classroomId/members/:memberId/gitlab", apiController.RedirectUserGitlab)
 
 	v1.Get("/classrooms/:classroomId/runners", apiController.GetClassroomRunners)
 	v1.Get("/classrooms/:classroomId/runners/available", apiController.GetClassroomRunnersAreAvailable)
+	v1.Get("/classrooms/:classroomId/runners/overview", apiController.RoleMiddleware(database.Owner, database.Moderator), apiController.GetClassroomRunnerOverview)
+	v1.Get("/classrooms/:classroomId/runners/group", apiController.RoleMiddleware(database.Owner), apiController.GetClassroomGroupRunners)
+	v1.Post("/classrooms/:classroomId/runners/group", apiController.RoleMiddleware(database.Owner), apiController.CreateClassroomGroupRunner)
+	v1.Patch("/classrooms/:classroomId/runners/group/:runnerId", apiController.RoleMiddleware(database.Owner), apiController.UpdateClassroomGroupRunner)
+	v1.Delete("/classrooms/:classroomId/runners/group/:runnerId", apiController.RoleMiddleware(database.Owner), apiController.DeleteClassroomGroupRunner)
 
 	v1.Get("/classrooms/:classroomId/teams", apiController.GetClassroomTeams)
 	v1.Post("/classrooms/:classroomId/teams", apiController.CreateTeam)
@@ -161,6 +215,33 @@ func setupRoutes(api *fiber.Router, config authConfig.Config, authController aut
 	v1.Get("/classrooms/:classroomId/teams/:teamId/grading/report", apiController.RoleMiddleware(database.Owner), apiController.GetClassroomTeamReport)
 }
 
+// routeMiddleware registers the handler in front of the handlers of every route.
+// Unlike middlewares registered with Use, the handler runs as part of the matched route and knows its path and parameters.
+type routeMiddleware struct {
+	fiber.Router
+	handler fiber.Handler
+}
+
+func (r routeMiddleware) Get(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Get(path, append([]fiber.Handler{r.handler}, handlers...)...)
+}
+
+func (r routeMiddleware) Post(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Post(path, append([]fiber.Handler{r.handler}, handlers...)...)
+}
+
+func (r routeMiddleware) Put(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Put(path, append([]fiber.Handler{r.handler}, handlers...)...)
+}
+
+func (r routeMiddleware) Patch(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Patch(path, append([]fiber.Handler{r.handler}, handlers...)...)
+}
+
+func (r routeMiddleware) Delete(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Delete(path, append([]fiber.Handler{r.handler}, handlers...)...)
+}
+
 func setupFrontend(app *fiber.App, frontendPath string) {
 	app.Static("/", frontendPath)
 
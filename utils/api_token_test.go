@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIToken(t *testing.T) {
+	token, err := NewAPIToken()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, APITokenPrefix))
+	assert.Len(t, token, len(APITokenPrefix)+64)
+
+	other, err := NewAPIToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestHashAPIToken(t *testing.T) {
+	assert.Equal(t, HashAPIToken("token"), HashAPIToken("token"))
+	assert.NotEqual(t, HashAPIToken("token"), HashAPIToken("other"))
+	assert.NotContains(t, HashAPIToken("token"), "token")
+}
+
+func TestEncryptToken(t *testing.T) {
+	encrypted, err := EncryptToken("secret", "gitlab-token")
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, "gitlab-token")
+
+	other, err := EncryptToken("secret", "gitlab-token")
+	assert.NoError(t, err)
+	assert.NotEqual(t, encrypted, other)
+
+	token, err := DecryptToken("secret", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "gitlab-token", token)
+
+	_, err = DecryptToken("other-secret", encrypted)
+	assert.Error(t, err)
+
+	_, err = DecryptToken("secret", "gitlab-token")
+	assert.Error(t, err)
+}
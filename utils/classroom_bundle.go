@@ -0,0 +1,257 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+)
+
+// ClassroomBundleVersion is the version of the classroom bundle format.
+// It has to be increased on every breaking change of the format.
+const ClassroomBundleVersion = 1
+
+// classroomBundleFileName is the name of the JSON file inside a ZIP bundle.
+const classroomBundleFileName = "classroom.json"
+
+// maxClassroomBundleSize limits the size of the JSON file inside a ZIP bundle, as it is decompressed into memory.
+const maxClassroomBundleSize = 32 << 20
+
+// ErrUnsupportedBundleVersion is returned if a bundle was created with an unknown format version.
+var ErrUnsupportedBundleVersion = errors.New("unsupported classroom bundle version")
+
+// ErrClassroomBundleTooLarge is returned if the JSON file inside a ZIP bundle exceeds maxClassroomBundleSize.
+var ErrClassroomBundleTooLarge = errors.New("classroom bundle is too large")
+
+// ClassroomBundle represents the portable export of a classroom.
+// Relations inside the bundle reference the IDs of the exporting instance.
+type ClassroomBundle struct {
+	Version     int                `json:"version"`
+	ExportedAt  time.Time          `json:"exportedAt"`
+	Classroom   BundleClassroom    `json:"classroom"`
+	Rubrics     []BundleRubric     `json:"rubrics"`
+	Assignments []BundleAssignment `json:"assignments"`
+	Teams       []BundleTeam       `json:"teams"`
+	Members     []BundleMember     `json:"members"`
+} //@Name ClassroomBundle
+
+// BundleClassroom contains the settings of the exported classroom.
+type BundleClassroom struct {
+	ID                      uuid.UUID `json:"id"`
+	CreatedAt               time.Time `json:"createdAt"`
+	Name                    string    `json:"name"`
+	Description             string    `json:"description"`
+	OwnerID                 int       `json:"ownerId"`
+	CreateTeams             bool      `json:"createTeams"`
+	MaxTeamSize             int       `json:"maxTeamSize"`
+	MaxTeams                int       `json:"maxTeams"`
+	StudentsViewAllProjects bool      `json:"studentsViewAllProjects"`
+	GroupID                 int       `json:"groupId"`
+	Archived                bool      `json:"archived"`
+} //@Name BundleClassroom
+
+// BundleRubric represents a manual grading rubric of the classroom.
+type BundleRubric struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	MaxScore    int       `json:"maxScore"`
+} //@Name BundleRubric
+
+// BundleJUnitTest represents a scored JUnit test of an assignment.
+type BundleJUnitTest struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+} //@Name BundleJUnitTest
+
+// BundleAssignment represents an assignment with its tests, rubric links and projects.
+type BundleAssignment struct {
+	ID                            uuid.UUID                     `json:"id"`
+	Name                          string                        `json:"name"`
+	Description                   string                        `json:"description"`
+	TemplateProjectID             int                           `json:"templateProjectId"`
+	DueDate                       *time.Time                    `json:"dueDate"`
+	Closed                        bool                          `json:"closed"`
+	GradingJUnitAutoGradingActive bool                          `json:"gradingJUnitAutoGradingActive"`
+	JUnitTests                    []BundleJUnitTest             `json:"junitTests"`
+	RubricIDs                     []uuid.UUID                   `json:"rubricIds"`
+	TemplateVariantIDs            []int                         `json:"templateVariantIds"`
+	TemplateDistribution          database.TemplateDistribution `json:"templateDistribution"`
+	Projects                      []BundleProject               `json:"projects"`
+} //@Name BundleAssignment
+
+// BundleManualResult represents the manual grading result of a project for a rubric.
+type BundleManualResult struct {
+	RubricID uuid.UUID `json:"rubricId"`
+	Score    int       `json:"score"`
+	Feedback *string   `json:"feedback"`
+} //@Name BundleManualResult
+
+// BundleProject represents the project of a team for an assignment, including its grading results.
+type BundleProject struct {
+	ID                     uuid.UUID                 `json:"id"`
+	TeamID                 uuid.UUID                 `json:"teamId"`
+	ProjectID              int                       `json:"projectId"`
+	ProjectStatus          string                    `json:"projectStatus"`
+	GradingJUnitTestResult *database.JUnitTestResult `json:"gradingJUnitTestResult"`
+	GradingManualResults   []BundleManualResult      `json:"gradingManualResults"`
+	TemplateProjectID      *int                      `json:"templateProjectId"`
+} //@Name BundleProject
+
+// BundleTeam represents a team of the classroom.
+type BundleTeam struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	GroupID int       `json:"groupId"`
+} //@Name BundleTeam
+
+// BundleMember represents a member of the classroom. The user ID is the ID of the user in GitLab.
+type BundleMember struct {
+	UserID         int           `json:"userId"`
+	GitlabUsername string        `json:"gitlabUsername"`
+	GitlabEmail    string        `json:"gitlabEmail"`
+	Name           string        `json:"name"`
+	Role           database.Role `json:"role"`
+	TeamID         *uuid.UUID    `json:"teamId"`
+} //@Name BundleMember
+
+// CreateClassroomBundle creates a bundle from the given classroom.
+// The assignments need their projects, tests, rubrics, template variants and manual results preloaded
+// and the members need their user preloaded.
+func CreateClassroomBundle(classroom *database.Classroom, rubrics []*database.ManualGradingRubric, assignments []*database.Assignment, teams []*database.Team, members []*database.UserClassrooms) *ClassroomBundle {
+	return &ClassroomBundle{
+		Version:    ClassroomBundleVersion,
+		ExportedAt: time.Now(),
+		Classroom: BundleClassroom{
+			ID:                      classroom.ID,
+			CreatedAt:               classroom.CreatedAt,
+			Name:                    classroom.Name,
+			Description:             classroom.Description,
+			OwnerID:                 classroom.OwnerID,
+			CreateTeams:             classroom.CreateTeams,
+			MaxTeamSize:             classroom.MaxTeamSize,
+			MaxTeams:                classroom.MaxTeams,
+			StudentsViewAllProjects: classroom.StudentsViewAllProjects,
+			GroupID:                 classroom.GroupID,
+			Archived:                classroom.Archived,
+		},
+		Rubrics: Map(rubrics, func(rubric *database.ManualGradingRubric) BundleRubric {
+			return BundleRubric{
+				ID:          rubric.ID,
+				Name:        rubric.Name,
+				Description: rubric.Description,
+				MaxScore:    rubric.MaxScore,
+			}
+		}),
+		Assignments: Map(assignments, func(assignment *database.Assignment) BundleAssignment {
+			return BundleAssignment{
+				ID:                            assignment.ID,
+				Name:                          assignment.Name,
+				Description:                   assignment.Description,
+				TemplateProjectID:             assignment.TemplateProjectID,
+				DueDate:                       assignment.DueDate,
+				Closed:                        assignment.Closed,
+				GradingJUnitAutoGradingActive: assignment.GradingJUnitAutoGradingActive,
+				JUnitTests: Map(assignment.JUnitTests, func(test *database.AssignmentJunitTest) BundleJUnitTest {
+					return BundleJUnitTest{Name: test.Name, Score: test.Score}
+				}),
+				RubricIDs: Map(assignment.GradingManualRubrics, func(rubric *database.ManualGradingRubric) uuid.UUID {
+					return rubric.ID
+				}),
+				TemplateVariantIDs: Map(assignment.TemplateVariants, func(variant *database.AssignmentTemplateVariant) int {
+					return variant.TemplateProjectID
+				}),
+				TemplateDistribution: assignment.TemplateDistribution,
+				Projects: Map(assignment.Projects, func(project *database.AssignmentProjects) BundleProject {
+					return BundleProject{
+						ID:                     project.ID,
+						TeamID:                 project.TeamID,
+						ProjectID:              project.ProjectID,
+						ProjectStatus:          string(project.ProjectStatus),
+						GradingJUnitTestResult: project.GradingJUnitTestResult,
+						GradingManualResults: Map(project.GradingManualResults, func(result *database.ManualGradingResult) BundleManualResult {
+							return BundleManualResult{RubricID: result.RubricID, Score: result.Score, Feedback: result.Feedback}
+						}),
+						TemplateProjectID: project.TemplateProjectID,
+					}
+				}),
+			}
+		}),
+		Teams: Map(teams, func(team *database.Team) BundleTeam {
+			return BundleTeam{ID: team.ID, Name: team.Name, GroupID: team.GroupID}
+		}),
+		Members: Map(members, func(member *database.UserClassrooms) BundleMember {
+			return BundleMember{
+				UserID:         member.UserID,
+				GitlabUsername: member.User.GitlabUsername,
+				GitlabEmail:    member.User.GitlabEmail,
+				Name:           member.User.Name,
+				Role:           member.Role,
+				TeamID:         member.TeamID,
+			}
+		}),
+	}
+}
+
+// WriteClassroomBundleZip writes the bundle as a ZIP archive containing a single JSON file.
+func WriteClassroomBundleZip(w io.Writer, bundle *ClassroomBundle) error {
+	zipWriter := zip.NewWriter(w)
+
+	file, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     classroomBundleFileName,
+		Method:   zip.Deflate,
+		Modified: bundle.ExportedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(bundle); err != nil {
+		return err
+	}
+
+	return zipWriter.Close()
+}
+
+// ReadClassroomBundle reads a bundle from either a ZIP archive or a plain JSON file.
+func ReadClassroomBundle(data []byte) (*ClassroomBundle, error) {
+	if bytes.HasPrefix(data, []byte("PK")) {
+		zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := zipReader.Open(classroomBundleFileName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid classroom bundle: %w", err)
+		}
+		defer file.Close()
+
+		data, err = io.ReadAll(io.LimitReader(file, maxClassroomBundleSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxClassroomBundleSize {
+			return nil, ErrClassroomBundleTooLarge
+		}
+	}
+
+	var bundle ClassroomBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid classroom bundle: %w", err)
+	}
+
+	if bundle.Version != ClassroomBundleVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedBundleVersion, bundle.Version)
+	}
+
+	return &bundle, nil
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+)
+
+func TestClassroomBundle(t *testing.T) {
+	dueDate := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	feedback := "Well done"
+	rubric := &database.ManualGradingRubric{ID: uuid.New(), Name: "Quality", MaxScore: 10}
+	team := &database.Team{ID: uuid.New(), Name: "Team A", GroupID: 12}
+	classroom := &database.Classroom{ID: uuid.New(), Name: "Classroom", OwnerID: 1, MaxTeamSize: 2}
+	assignments := []*database.Assignment{
+		{
+			ID:                   uuid.New(),
+			Name:                 "Assignment 1",
+			TemplateProjectID:    1234,
+			DueDate:              &dueDate,
+			JUnitTests:           []*database.AssignmentJunitTest{{Name: "TestAdd", Score: 5}},
+			GradingManualRubrics: []*database.ManualGradingRubric{rubric},
+			TemplateVariants: []*database.AssignmentTemplateVariant{
+				{TemplateProjectID: 1234, Position: 0},
+				{TemplateProjectID: 5678, Position: 1},
+			},
+			TemplateDistribution: database.RoundRobinDistribution,
+			Projects: []*database.AssignmentProjects{
+				{
+					ID:                     uuid.New(),
+					TeamID:                 team.ID,
+					ProjectID:              99,
+					ProjectStatus:          database.Accepted,
+					GradingJUnitTestResult: &gradingJUnitTestResult,
+					GradingManualResults: []*database.ManualGradingResult{
+						{RubricID: rubric.ID, Score: 8, Feedback: &feedback},
+					},
+				},
+			},
+		},
+	}
+	members := []*database.UserClassrooms{
+		{UserID: 2, User: database.User{ID: 2, GitlabUsername: "student", GitlabEmail: "student@example.com", Name: "Student"}, Role: database.Student, TeamID: &team.ID},
+	}
+
+	bundle := CreateClassroomBundle(classroom, []*database.ManualGradingRubric{rubric}, assignments, []*database.Team{team}, members)
+
+	t.Run("CreateClassroomBundle", func(t *testing.T) {
+		assert.Equal(t, ClassroomBundleVersion, bundle.Version)
+		assert.Equal(t, classroom.Name, bundle.Classroom.Name)
+		assert.Equal(t, []uuid.UUID{rubric.ID}, bundle.Assignments[0].RubricIDs)
+		assert.Equal(t, []int{1234, 5678}, bundle.Assignments[0].TemplateVariantIDs)
+		assert.Equal(t, database.RoundRobinDistribution, bundle.Assignments[0].TemplateDistribution)
+		assert.Equal(t, "accepted", bundle.Assignments[0].Projects[0].ProjectStatus)
+		assert.Equal(t, 8, bundle.Assignments[0].Projects[0].GradingManualResults[0].Score)
+		assert.Equal(t, "student", bundle.Members[0].GitlabUsername)
+		assert.Equal(t, team.ID, *bundle.Members[0].TeamID)
+	})
+
+	t.Run("ZIP roundtrip", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteClassroomBundleZip(&buf, bundle)
+		assert.NoError(t, err)
+
+		readBundle, err := ReadClassroomBundle(buf.Bytes())
+		assert.NoError(t, err)
+		assert.Equal(t, bundle.Classroom, readBundle.Classroom)
+		assert.Equal(t, bundle.Assignments[0].JUnitTests, readBundle.Assignments[0].JUnitTests)
+		assert.Equal(t, gradingJUnitTestResult.TotalCount, readBundle.Assignments[0].Projects[0].GradingJUnitTestResult.TotalCount)
+	})
+
+	t.Run("reads plain JSON", func(t *testing.T) {
+		readBundle, err := ReadClassroomBundle([]byte(`{"version": 1, "classroom": {"name": "Plain"}}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "Plain", readBundle.Classroom.Name)
+	})
+
+	t.Run("rejects unknown version", func(t *testing.T) {
+		_, err := ReadClassroomBundle([]byte(`{"version": 99}`))
+		assert.ErrorIs(t, err, ErrUnsupportedBundleVersion)
+	})
+
+	t.Run("rejects too large ZIP content", func(t *testing.T) {
+		var buf bytes.Buffer
+		zipWriter := zip.NewWriter(&buf)
+		file, err := zipWriter.Create(classroomBundleFileName)
+		assert.NoError(t, err)
+		_, err = file.Write(bytes.Repeat([]byte(" "), maxClassroomBundleSize+1))
+		assert.NoError(t, err)
+		assert.NoError(t, zipWriter.Close())
+
+		_, err = ReadClassroomBundle(buf.Bytes())
+		assert.ErrorIs(t, err, ErrClassroomBundleTooLarge)
+	})
+
+	t.Run("rejects invalid data", func(t *testing.T) {
+		_, err := ReadClassroomBundle([]byte(`not a bundle`))
+		assert.Error(t, err)
+	})
+}
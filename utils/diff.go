@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkHeaderPattern matches the header of a hunk in a unified diff, e.g. "@@ -1,4 +1,5 @@".
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// DiffOldLine returns the line number in the old file of a line of the new file in a unified diff.
+// It reports false if the line has been added, lines outside the hunks are unchanged.
+func DiffOldLine(diff string, newLine int) (int, bool) {
+	// offset is the difference between the line numbers of the new and the old file
+	offset := 0
+	oldLine, currentLine := 0, 0
+	inHunk := false
+
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		if match := hunkHeaderPattern.FindStringSubmatch(line); match != nil {
+			oldStart, _ := strconv.Atoi(match[1])
+			newStart, _ := strconv.Atoi(match[2])
+			if newLine < newStart {
+				break
+			}
+			oldLine, currentLine = oldStart, newStart
+			offset = newStart - oldStart
+			inHunk = true
+			continue
+		}
+		if !inHunk {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+"):
+			if currentLine == newLine {
+				return 0, false
+			}
+			currentLine++
+		case strings.HasPrefix(line, "-"):
+			oldLine++
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file" belongs to the previous line
+		default:
+			if currentLine == newLine {
+				return oldLine, true
+			}
+			oldLine++
+			currentLine++
+		}
+		offset = currentLine - oldLine
+	}
+
+	return newLine - offset, true
+}
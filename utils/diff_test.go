@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffOldLine(t *testing.T) {
+	diff := `@@ -2,4 +2,5 @@ package main
+ import "fmt"
+-const a = 1
++const a = 2
++const b = 3
+ 
+ func main() {
+@@ -20,3 +21,2 @@ func main() {
+ 	fmt.Println(a)
+-	fmt.Println(a)
+ }
+`
+
+	tests := []struct {
+		name    string
+		newLine int
+		oldLine int
+		ok      bool
+	}{
+		{name: "unchanged line before the first hunk", newLine: 1, oldLine: 1, ok: true},
+		{name: "context line", newLine: 2, oldLine: 2, ok: true},
+		{name: "changed line", newLine: 3, ok: false},
+		{name: "added line", newLine: 4, ok: false},
+		{name: "context line after the added lines", newLine: 6, oldLine: 5, ok: true},
+		{name: "unchanged line between the hunks", newLine: 10, oldLine: 9, ok: true},
+		{name: "context line after the removed line", newLine: 22, oldLine: 22, ok: true},
+		{name: "unchanged line after the last hunk", newLine: 30, oldLine: 30, ok: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldLine, ok := DiffOldLine(diff, test.newLine)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.oldLine, oldLine)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// gitlabCIKeywords are the global keywords of a .gitlab-ci.yml, every other top-level key is a job.
+var gitlabCIKeywords = []string{"default", "include", "stages", "variables", "workflow", "image", "services", "cache", "before_script", "after_script"}
+
+// GitlabCIJobTags returns the runner tags of every job in a .gitlab-ci.yml, keyed by the job name.
+// Jobs without tags inherit the tags of the default section. Hidden jobs and extends are not resolved.
+func GitlabCIJobTags(content []byte) (map[string][]string, error) {
+	var config map[string]any
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, err
+	}
+
+	var defaultTags []string
+	if defaults, ok := config["default"].(map[string]any); ok {
+		defaultTags = toStrings(defaults["tags"])
+	}
+
+	jobs := map[string][]string{}
+	for name, value := range config {
+		job, ok := value.(map[string]any)
+		if !ok || strings.HasPrefix(name, ".") || slices.Contains(gitlabCIKeywords, name) {
+			continue
+		}
+
+		if tags, ok := job["tags"]; ok {
+			jobs[name] = toStrings(tags)
+		} else {
+			jobs[name] = defaultTags
+		}
+	}
+	return jobs, nil
+}
+
+func toStrings(value any) []string {
+	values, _ := value.([]any)
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitlabCIJobTags(t *testing.T) {
+	content := []byte(`
+stages: [build, test]
+
+default:
+  tags: [docker]
+
+.template:
+  tags: [hidden]
+
+build:
+  stage: build
+  script: make
+
+test:
+  stage: test
+  tags: [docker, java]
+  script: make test
+
+untagged:
+  tags: []
+  script: echo
+
+pages:
+  tags: [pages]
+  script: make site
+`)
+
+	jobs, err := GitlabCIJobTags(content)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"build":    {"docker"},
+		"test":     {"docker", "java"},
+		"untagged": {},
+		"pages":    {"pages"},
+	}, jobs)
+
+	_, err = GitlabCIJobTags([]byte(`"": {script: echo}`))
+	assert.NoError(t, err)
+
+	_, err = GitlabCIJobTags([]byte("jobs: ["))
+	assert.Error(t, err)
+}
@@ -21,6 +21,14 @@ type ManualResult struct {
 	MaxScore   int       `json:"maxScore"`
 }
 
+// JUnitResult represents the result of a single autograding test.
+type JUnitResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Score    int    `json:"score"`
+	MaxScore int    `json:"maxScore"`
+}
+
 // ReportDataItem represents a single item in a report.
 type ReportDataItem struct {
 	ProjectID           uuid.UUID               `json:"projectId"`
@@ -30,11 +38,13 @@ type ReportDataItem struct {
 	Username            string                  `json:"username"`
 	Email               string                  `json:"email"`
 	RubricResults       map[string]ManualResult `json:"rubricResults"`
+	JUnitResults        []JUnitResult           `json:"junitResults"`
 	AutogradingScore    int                     `json:"autogradingScore"`
 	AutogradingMaxScore int                     `json:"autogradingMaxScore"`
 	MaxScore            int                     `json:"maxScore"`
 	Score               int                     `json:"score"`
 	Percentage          float64                 `json:"percentage"`
+	TemplateProjectID   int                     `json:"templateProjectId"`
 }
 
 // GenerateReports generates reports for the given assignments and rubrics.
@@ -111,7 +121,7 @@ func GenerateCSVReport(w io.Writer, assignment *database.Assignment, rubrics []*
 			row = append(row, strconv.Itoa(result.Score), result.Feedback, strconv.Itoa(result.MaxScore))
 		}
 
-		row = append(row, strconv.Itoa(item.AutogradingScore), strconv.Itoa(item.AutogradingMaxScore), strconv.Itoa(item.MaxScore), strconv.Itoa(item.Score), fmt.Sprintf("%.2f", item.Percentage))
+		row = append(row, strconv.Itoa(item.AutogradingScore), strconv.Itoa(item.AutogradingMaxScore), strconv.Itoa(item.MaxScore), strconv.Itoa(item.Score), fmt.Sprintf("%.2f", item.Percentage), strconv.Itoa(item.TemplateProjectID))
 		if err := writer.Write(row); err != nil {
 			return err
 		}
@@ -134,6 +144,7 @@ func createReportDataItems(assignment *database.Assignment, teamID *uuid.UUID) [
 		}
 
 		manualRubricResults := createManualRubricResults(project, assignment.GradingManualRubrics)
+		junitResults := createJUnitResults(project, assignment.JUnitTests)
 
 		autogradingScore := calculateAutogradingScore(project, assignment.JUnitTests)
 		autogradingMaxScore := calculateAutogradingMaxScore(project, assignment.JUnitTests)
@@ -148,6 +159,12 @@ func createReportDataItems(assignment *database.Assignment, teamID *uuid.UUID) [
 			percentage = float64(score) / float64(maxScore) * 100
 		}
 
+		// Projects accepted before template variants were introduced use the template of the assignment
+		templateProjectID := assignment.TemplateProjectID
+		if project.TemplateProjectID != nil {
+			templateProjectID = *project.TemplateProjectID
+		}
+
 		for _, member := range project.Team.Member {
 			reportData = append(reportData, &ReportDataItem{
 				ProjectID:           project.ID,
@@ -157,11 +174,13 @@ func createReportDataItems(assignment *database.Assignment, teamID *uuid.UUID) [
 				Username:            member.User.GitlabUsername,
 				Email:               member.User.GitlabEmail,
 				RubricResults:       manualRubricResults,
+				JUnitResults:        junitResults,
 				AutogradingScore:    autogradingScore,
 				AutogradingMaxScore: autogradingMaxScore,
 				MaxScore:            maxScore,
 				Score:               score,
 				Percentage:          percentage,
+				TemplateProjectID:   templateProjectID,
 			})
 		}
 	}
@@ -211,6 +230,44 @@ func createManualRubricResults(project *database.AssignmentProjects, _ []*databa
 	return results
 }
 
+// createJUnitResults creates the per-test breakdown of the autograding for a project.
+// Without configured tests, every reported test case counts one point like in calculateAutogradingScore.
+// Configured tests missing from the test report are listed with the status "missing".
+func createJUnitResults(project *database.AssignmentProjects, tests []*database.AssignmentJunitTest) []JUnitResult {
+	statuses := make(map[string]string)
+	results := make([]JUnitResult, 0)
+	if project.GradingJUnitTestResult != nil {
+		for _, ts := range project.GradingJUnitTestResult.TestSuites {
+			for _, tc := range ts.TestCases {
+				name := fmt.Sprintf("%s/%s", ts.Name, tc.Name)
+				statuses[name] = tc.Status
+
+				if len(tests) == 0 {
+					score := 0
+					if tc.Status == "success" {
+						score = 1
+					}
+					results = append(results, JUnitResult{Name: name, Status: tc.Status, Score: score, MaxScore: 1})
+				}
+			}
+		}
+	}
+
+	for _, test := range tests {
+		status, ok := statuses[test.Name]
+		if !ok {
+			status = "missing"
+		}
+		score := 0
+		if status == "success" {
+			score = test.Score
+		}
+		results = append(results, JUnitResult{Name: test.Name, Status: status, Score: score, MaxScore: test.Score})
+	}
+
+	return results
+}
+
 // calculateMaxScore calculates the maximum score for a project.
 func calculateMaxScore(project *database.AssignmentProjects, tests []*database.AssignmentJunitTest, rubrics []*database.ManualGradingRubric) int {
 	maxScore := 0
@@ -282,7 +339,7 @@ func writeHeader(writer *csv.Writer, rubrics []*database.ManualGradingRubric) er
 		header = append(header, rubric.Name+"Score", rubric.Name+"Feedback", rubric.Name+"MaxScore")
 	}
 
-	header = append(header, "AutogradingScore", "AutogradingMaxScore", "MaxScore", "Score", "Percentage")
+	header = append(header, "AutogradingScore", "AutogradingMaxScore", "MaxScore", "Score", "Percentage", "TemplateProjectID")
 
 	return writer.Write(header)
 }
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+)
+
+// reportSummary is the result of a team in one assignment, as shown in the report documents.
+type reportSummary struct {
+	AssignmentName string
+	Score          int
+	MaxScore       int
+	Percentage     float64
+	RubricResults  []ManualResult
+	JUnitResults   []JUnitResult
+}
+
+// summarizeReports reduces the reports to one summary per assignment.
+// All items of an assignment belong to the same project, as the reports are generated for a single team.
+func summarizeReports(reports [][]*ReportDataItem) []reportSummary {
+	summaries := make([]reportSummary, 0, len(reports))
+	for _, report := range reports {
+		if len(report) == 0 {
+			continue
+		}
+		item := report[0]
+
+		rubricResults := make([]ManualResult, 0, len(item.RubricResults))
+		for _, result := range item.RubricResults {
+			rubricResults = append(rubricResults, result)
+		}
+		slices.SortFunc(rubricResults, func(a, b ManualResult) int {
+			return strings.Compare(a.RubricName, b.RubricName)
+		})
+
+		summaries = append(summaries, reportSummary{
+			AssignmentName: item.AssignmentName,
+			Score:          item.Score,
+			MaxScore:       item.MaxScore,
+			Percentage:     item.Percentage,
+			RubricResults:  rubricResults,
+			JUnitResults:   item.JUnitResults,
+		})
+	}
+	return summaries
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Summaries}}
+<h2>{{.AssignmentName}}</h2>
+<p>Score: {{.Score}} / {{.MaxScore}} ({{printf "%.2f" .Percentage}} %)</p>
+{{if .RubricResults}}
+<table>
+<tr><th>Rubric</th><th>Score</th><th>Feedback</th></tr>
+{{range .RubricResults}}<tr><td>{{.RubricName}}</td><td>{{.Score}} / {{.MaxScore}}</td><td>{{.Feedback}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .JUnitResults}}
+<table>
+<tr><th>Test</th><th>Status</th><th>Score</th></tr>
+{{range .JUnitResults}}<tr><td>{{.Name}}</td><td>{{.Status}}</td><td>{{.Score}} / {{.MaxScore}}</td></tr>
+{{end}}</table>
+{{end}}
+{{else}}
+<p>No assignments found.</p>
+{{end}}
+</body>
+</html>
+`))
+
+// GenerateHTMLReport writes the reports of a single team as HTML document.
+func GenerateHTMLReport(w io.Writer, title string, reports [][]*ReportDataItem) error {
+	return htmlReportTemplate.Execute(w, struct {
+		Title     string
+		Summaries []reportSummary
+	}{
+		Title:     title,
+		Summaries: summarizeReports(reports),
+	})
+}
+
+// GeneratePDFReport writes the reports of a single team as PDF document.
+func GeneratePDFReport(w io.Writer, title string, reports [][]*ReportDataItem) error {
+	lines := []PDFLine{{Text: title, Bold: true}, {}}
+
+	summaries := summarizeReports(reports)
+	if len(summaries) == 0 {
+		lines = append(lines, PDFLine{Text: "No assignments found."})
+	}
+
+	for _, summary := range summaries {
+		lines = append(lines,
+			PDFLine{Text: summary.AssignmentName, Bold: true},
+			PDFLine{Text: fmt.Sprintf("Score: %d / %d (%.2f %%)", summary.Score, summary.MaxScore, summary.Percentage), Indent: 2},
+		)
+
+		if len(summary.RubricResults) > 0 {
+			lines = append(lines, PDFLine{Text: "Rubrics", Bold: true, Indent: 2})
+			for _, result := range summary.RubricResults {
+				lines = append(lines, PDFLine{Text: fmt.Sprintf("%s: %d / %d", result.RubricName, result.Score, result.MaxScore), Indent: 4})
+				if result.Feedback != "" {
+					lines = append(lines, PDFLine{Text: result.Feedback, Indent: 6})
+				}
+			}
+		}
+
+		if len(summary.JUnitResults) > 0 {
+			lines = append(lines, PDFLine{Text: "Tests", Bold: true, Indent: 2})
+			for _, result := range summary.JUnitResults {
+				lines = append(lines, PDFLine{Text: fmt.Sprintf("%s: %s (%d / %d)", result.Name, result.Status, result.Score, result.MaxScore), Indent: 4})
+			}
+		}
+
+		lines = append(lines, PDFLine{})
+	}
+
+	return WritePDF(w, lines)
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportDocuments(t *testing.T) {
+	reports := [][]*ReportDataItem{
+		{
+			{
+				AssignmentName: "Assignment <1>",
+				TeamName:       "Team A",
+				RubricResults: map[string]ManualResult{
+					"Quality": {RubricName: "Quality", Score: 8, MaxScore: 10, Feedback: "Schöner Code (mostly)"},
+				},
+				JUnitResults: []JUnitResult{{Name: "golang/test", Status: "success", Score: 1, MaxScore: 1}},
+				Score:        9,
+				MaxScore:     11,
+				Percentage:   81.82,
+			},
+		},
+		{},
+	}
+
+	t.Run("html", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, GenerateHTMLReport(&buf, "Classroom - Team A", reports))
+
+		html := buf.String()
+		assert.Contains(t, html, "<h2>Assignment &lt;1&gt;</h2>")
+		assert.Contains(t, html, "9 / 11 (81.82 %)")
+		assert.Contains(t, html, "Schöner Code (mostly)")
+		assert.Contains(t, html, "golang/test")
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, GeneratePDFReport(&buf, "Classroom - Team A", reports))
+
+		pdf := buf.String()
+		assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4"))
+		assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+		assert.Contains(t, pdf, "(Assignment <1>)")
+		assert.Contains(t, pdf, `(Sch\366ner Code \(mostly\))`)
+		assert.Contains(t, pdf, "(golang/test: success \\(1 / 1\\))")
+	})
+
+	t.Run("pdf pages", func(t *testing.T) {
+		lines := make([]PDFLine, 2*pdfLinesPerPage+1)
+		var buf bytes.Buffer
+		assert.NoError(t, WritePDF(&buf, lines))
+		assert.Contains(t, buf.String(), "/Count 3")
+	})
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{"aaa bbb", "ccc"}, wrapText("aaa bbb ccc", 8))
+	assert.Equal(t, []string{"aaaa", "aa b"}, wrapText("aaaaaa b", 4))
+	assert.Equal(t, []string{"a", "b"}, wrapText("a\nb", 10))
+	assert.Equal(t, []string{""}, wrapText("", 10))
+}
@@ -28,6 +28,7 @@ func TestGenerateReports(t *testing.T) {
 	assignments := []*database.Assignment{
 		{
 			Name:                 "Assignment 1",
+			TemplateProjectID:    1234,
 			GradingManualRubrics: rubrics,
 			Projects: []*database.AssignmentProjects{
 				{
@@ -55,6 +56,28 @@ func TestGenerateReports(t *testing.T) {
 	assert.Equal(t, 1, len(reports))
 	assert.Equal(t, "Team A", reports[0][0].TeamName)
 	assert.Equal(t, "Assignment 1", reports[0][0].AssignmentName)
+	assert.Equal(t, 1234, reports[0][0].TemplateProjectID)
+	assert.Len(t, reports[0][0].JUnitResults, 4)
+	assert.Equal(t, JUnitResult{Name: "golang/test", Status: "success", Score: 1, MaxScore: 1}, reports[0][0].JUnitResults[0])
+	assert.Equal(t, JUnitResult{Name: "golang/test2", Status: "failed", Score: 0, MaxScore: 1}, reports[0][0].JUnitResults[1])
+}
+
+func TestCreateJUnitResults(t *testing.T) {
+	project := &database.AssignmentProjects{GradingJUnitTestResult: &gradingJUnitTestResult}
+	tests := []*database.AssignmentJunitTest{
+		{Name: "golang/test", Score: 5},
+		{Name: "golang/test2", Score: 3},
+		{Name: "golang/unknown", Score: 2},
+	}
+
+	results := createJUnitResults(project, tests)
+	assert.Equal(t, []JUnitResult{
+		{Name: "golang/test", Status: "success", Score: 5, MaxScore: 5},
+		{Name: "golang/test2", Status: "failed", Score: 0, MaxScore: 3},
+		{Name: "golang/unknown", Status: "missing", Score: 0, MaxScore: 2},
+	}, results)
+
+	assert.Empty(t, createJUnitResults(&database.AssignmentProjects{}, nil))
 }
 
 func TestGenerateCSVReports(t *testing.T) {
@@ -153,6 +176,7 @@ func TestGenerateCSVReports(t *testing.T) {
 	assert.Equal(t, "Score", records[0][14])
 	assert.Equal(t, "12", records[1][14])
 	assert.Equal(t, "Percentage", records[0][15])
+	assert.Equal(t, "TemplateProjectID", records[0][16])
 
 	// --------------------------------------
 	assert.Equal(t, "Assignment 2", records[2][0])
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"path"
+	"slices"
+)
+
+//go:embed all:starter_kits
+var starterKits embed.FS
+
+const starterKitsDir = "starter_kits"
+
+var ErrUnknownStarterKit = errors.New("unknown starter kit")
+
+// StarterKits returns the names of the built-in starter kits for template projects.
+func StarterKits() []string {
+	entries, err := starterKits.ReadDir(starterKitsDir)
+	if err != nil {
+		return nil
+	}
+
+	kits := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			kits = append(kits, entry.Name())
+		}
+	}
+	return kits
+}
+
+// StarterKitFiles returns the content of the files of a starter kit, keyed by their path in the repository.
+func StarterKitFiles(kit string) (map[string]string, error) {
+	if !slices.Contains(StarterKits(), kit) {
+		return nil, ErrUnknownStarterKit
+	}
+
+	root := path.Join(starterKitsDir, kit)
+	files := map[string]string{}
+	err := fs.WalkDir(starterKits, root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := starterKits.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		files[filePath[len(root)+1:]] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
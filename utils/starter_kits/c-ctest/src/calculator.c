@@ -0,0 +1,5 @@
+#include "calculator.h"
+
+int add(int a, int b) {
+    return a + b;
+}
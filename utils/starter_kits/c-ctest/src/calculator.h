@@ -0,0 +1,6 @@
+#ifndef CALCULATOR_H
+#define CALCULATOR_H
+
+int add(int a, int b);
+
+#endif
@@ -0,0 +1,11 @@
+#include <stdio.h>
+
+#include "calculator.h"
+
+int main(void) {
+    if (add(2, 3) != 5) {
+        fprintf(stderr, "add(2, 3) should be 5\n");
+        return 1;
+    }
+    return 0;
+}
@@ -0,0 +1,7 @@
+package assignment;
+
+public class Calculator {
+    public int add(int a, int b) {
+        return a + b;
+    }
+}
@@ -0,0 +1,12 @@
+package assignment;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+
+import org.junit.jupiter.api.Test;
+
+class CalculatorTest {
+    @Test
+    void addsNumbers() {
+        assertEquals(5, new Calculator().add(2, 3));
+    }
+}
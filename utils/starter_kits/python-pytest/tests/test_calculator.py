@@ -0,0 +1,5 @@
+from calculator import add
+
+
+def test_adds_numbers():
+    assert add(2, 3) == 5
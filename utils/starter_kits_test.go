@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestStarterKits(t *testing.T) {
+	assert.ElementsMatch(t, []string{"c-ctest", "java-maven", "python-pytest"}, StarterKits())
+
+	for _, kit := range StarterKits() {
+		t.Run(kit, func(t *testing.T) {
+			files, err := StarterKitFiles(kit)
+			assert.NoError(t, err)
+			assert.Contains(t, files, "README.md")
+			assert.Contains(t, files, ".gitignore")
+
+			// Every job of the pipeline has to publish a JUnit report for the autograding
+			var config map[string]struct {
+				Artifacts struct {
+					Reports struct {
+						JUnit string `yaml:"junit"`
+					} `yaml:"reports"`
+				} `yaml:"artifacts"`
+			}
+			assert.NoError(t, yaml.Unmarshal([]byte(files[".gitlab-ci.yml"]), &config))
+			assert.NotEmpty(t, config)
+			for job, value := range config {
+				assert.NotEmpty(t, value.Artifacts.Reports.JUnit, job)
+			}
+		})
+	}
+
+	t.Run("unknown kit", func(t *testing.T) {
+		_, err := StarterKitFiles("rust-cargo")
+		assert.ErrorIs(t, err, ErrUnknownStarterKit)
+	})
+}
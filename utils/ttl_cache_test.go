@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewTTLCache[int, string](time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.Get(1)
+	assert.False(t, ok)
+
+	cache.Set(1, "a")
+	value, ok := cache.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get(1)
+	assert.False(t, ok)
+
+	cache.Set(2, "b")
+	cache.Delete(2)
+	_, ok = cache.Get(2)
+	assert.False(t, ok)
+}
@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	reminderConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/reminder"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	"gorm.io/gen/field"
+)
+
+// AssignmentReminderWork sends reminder mails to team members before the due date of an assignment.
+// Members of accepted projects are reminded of the due date, members of teams that have not accepted
+// the assignment yet are asked to accept it. Every reminder is recorded together with its mail in the outbox,
+// so that it is sent only once.
+type AssignmentReminderWork struct {
+	deadlineOffsets   []time.Duration
+	acceptanceOffsets []time.Duration
+}
+
+// NewAssignmentReminderWork creates a new instance of AssignmentReminderWork.
+func NewAssignmentReminderWork(config reminderConfig.Config) *AssignmentReminderWork {
+	return &AssignmentReminderWork{
+		deadlineOffsets:   config.GetDeadlineOffsets(),
+		acceptanceOffsets: config.GetAcceptanceOffsets(),
+	}
+}
+
+// Do sends the reminders which are due for all open assignments.
+func (w *AssignmentReminderWork) Do(ctx context.Context) {
+	now := time.Now()
+	assignments := w.getAssignments2Remind(ctx, now)
+	for _, assignment := range assignments {
+		if assignment.Classroom.Archived {
+			continue
+		}
+
+		remaining := assignment.DueDate.Sub(now)
+		for _, project := range assignment.Projects {
+			switch project.ProjectStatus {
+			case database.Accepted:
+				w.remindProject(ctx, assignment, project, database.DeadlineReminder, w.deadlineOffsets, remaining)
+			case database.Pending:
+				w.remindProject(ctx, assignment, project, database.AcceptanceReminder, w.acceptanceOffsets, remaining)
+			}
+		}
+	}
+}
+
+// getAssignments2Remind retrieves open assignments whose due date is within the largest configured offset.
+func (w *AssignmentReminderWork) getAssignments2Remind(ctx context.Context, now time.Time) []*database.Assignment {
+	var maxOffset time.Duration
+	for _, offset := range slices.Concat(w.deadlineOffsets, w.acceptanceOffsets) {
+		maxOffset = max(maxOffset, offset)
+	}
+	if maxOffset <= 0 {
+		return []*database.Assignment{}
+	}
+
+	assignments, err := query.Assignment.
+		WithContext(ctx).
+		Preload(query.Assignment.Classroom).
+		Preload(query.Assignment.Projects).
+		Preload(query.Assignment.Projects.Team).
+		Preload(query.Assignment.Projects.Team.Member).
+		Preload(field.NewRelation("Projects.Team.Member.User", "")).
+		Preload(query.Assignment.Projects.Reminders).
+		Where(query.Assignment.DueDate.Gt(now)).
+		Where(query.Assignment.DueDate.Lte(now.Add(maxOffset))).
+		Where(query.Assignment.Closed.Is(false)).
+		Find()
+	if err != nil {
+		log.Default().Printf("Error occurred while fetching assignments to remind: %s", err.Error())
+		return []*database.Assignment{}
+	}
+
+	return assignments
+}
+
+// remindProject sends the current reminder to every member of the project's team, who has not received it yet.
+func (w *AssignmentReminderWork) remindProject(ctx context.Context, assignment *database.Assignment, project *database.AssignmentProjects, kind database.ReminderKind, offsets []time.Duration, remaining time.Duration) {
+	offset, ok := currentReminderOffset(offsets, remaining)
+	if !ok {
+		return
+	}
+
+	for _, member := range project.Team.Member {
+		if reminderSent(project.Reminders, member.UserID, kind, offset) {
+			continue
+		}
+
+		err := query.Q.Transaction(func(tx *query.Query) error {
+			reminder := &database.AssignmentReminder{
+				AssignmentProjectID: project.ID,
+				UserID:              member.UserID,
+				Kind:                kind,
+				RemindBefore:        offset,
+			}
+			if err := tx.AssignmentReminder.WithContext(ctx).Create(reminder); err != nil {
+				return err
+			}
+
+			return sendReminder(mail.NewOutboxRepository(ctx, tx, &assignment.ClassroomID), assignment, project, member, kind)
+		})
+		if err != nil {
+			log.Default().Printf("AssignmentReminderWorker: Error occurred while reminding %s of assignment %s: %s", member.User.GitlabEmail, assignment.Name, err.Error())
+		}
+	}
+}
+
+func sendReminder(mailRepo mail.Repository, assignment *database.Assignment, project *database.AssignmentProjects, member *database.UserClassrooms, kind database.ReminderKind) error {
+	data := mail.AssignmentReminderData{
+		ClassroomName:  assignment.Classroom.Name,
+		RecipientName:  member.User.Name,
+		AssignmentName: assignment.Name,
+		DueDate:        *assignment.DueDate,
+	}
+
+	if kind == database.AcceptanceReminder {
+		data.Path = fmt.Sprintf("/classrooms/%s/projects/%s/accept", assignment.ClassroomID.String(), project.ID.String())
+		return mailRepo.SendAcceptanceReminder(member.User.GitlabEmail,
+			fmt.Sprintf(`Please accept the Assignment "%s"`, assignment.Name),
+			data)
+	}
+
+	data.Path = fmt.Sprintf("/classrooms/%s", assignment.ClassroomID.String())
+	return mailRepo.SendDeadlineReminder(member.User.GitlabEmail,
+		fmt.Sprintf(`The Assignment "%s" is due soon`, assignment.Name),
+		data)
+}
+
+// currentReminderOffset returns the smallest offset which has already been reached.
+// Earlier offsets are superseded by it, so only one reminder is sent when several offsets were reached at once.
+func currentReminderOffset(offsets []time.Duration, remaining time.Duration) (time.Duration, bool) {
+	var current time.Duration
+	found := false
+	for _, offset := range offsets {
+		if offset >= remaining && (!found || offset < current) {
+			current = offset
+			found = true
+		}
+	}
+	return current, found
+}
+
+func reminderSent(reminders []*database.AssignmentReminder, userID int, kind database.ReminderKind, offset time.Duration) bool {
+	return slices.ContainsFunc(reminders, func(reminder *database.AssignmentReminder) bool {
+		return reminder.UserID == userID && reminder.Kind == kind && reminder.RemindBefore == offset
+	})
+}
@@ -0,0 +1,139 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/config/reminder"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+	db_tests "gitlab.hs-flensburg.de/gitlab-classroom/utils/tests"
+)
+
+func TestAssignmentReminderWorker(t *testing.T) {
+	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+
+	pg, err := db_tests.StartPostgres()
+	if err != nil {
+		t.Fatalf("Failed to start postgres container: %s", err.Error())
+	}
+
+	dbURL, err := pg.ConnectionString(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to obtain connection string: %s", err.Error())
+	}
+
+	db, err := gorm.Open(postgres.Open(dbURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("could not get database connection: %s", err.Error())
+	}
+
+	err = database.MigrateDatabase(sqlDB)
+	if err != nil {
+		t.Fatalf("could not migrate database: %s", err.Error())
+	}
+
+	query.SetDefault(db)
+
+	owner := factory.User()
+	student1 := factory.User()
+	student2 := factory.User()
+	classroom := factory.Classroom(owner.ID)
+
+	dueDate := time.Now().Add(48 * time.Hour)
+	assignment := factory.Assignment(classroom.ID, &dueDate, false)
+
+	team1 := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(student1.ID, classroom.ID, database.Student),
+	})
+	team2 := factory.Team(classroom.ID, []*database.UserClassrooms{
+		factory.UserClassroom(student2.ID, classroom.ID, database.Student),
+	})
+
+	acceptedProject := factory.AssignmentProject(assignment.ID, team1.ID)
+	pendingProject := factory.AssignmentProject(assignment.ID, team2.ID)
+	pendingProject.ProjectStatus = database.Pending
+	SaveAssignmentProjects(t, pendingProject)
+
+	work := NewAssignmentReminderWork(&reminder.ReminderConfig{
+		DeadlineOffsets:   []time.Duration{72 * time.Hour, 24 * time.Hour},
+		AcceptanceOffsets: []time.Duration{72 * time.Hour},
+	})
+
+	t.Run("sends deadline and acceptance reminders", func(t *testing.T) {
+		work.Do(context.Background())
+
+		reminders, err := query.AssignmentReminder.WithContext(context.Background()).Find()
+		assert.NoError(t, err)
+		assert.Len(t, reminders, 2)
+
+		assertOutgoingMails(t, student1.GitlabEmail, database.DeadlineReminderMail, 1)
+		assertOutgoingMails(t, student2.GitlabEmail, database.AcceptanceReminderMail, 1)
+	})
+
+	t.Run("does not send reminders twice", func(t *testing.T) {
+		work.Do(context.Background())
+
+		assertOutgoingMails(t, student1.GitlabEmail, database.DeadlineReminderMail, 1)
+		assertOutgoingMails(t, student2.GitlabEmail, database.AcceptanceReminderMail, 1)
+	})
+
+	t.Run("sends next deadline reminder", func(t *testing.T) {
+		dueDate := time.Now().Add(12 * time.Hour)
+		assignment.DueDate = &dueDate
+		SaveAssignment(t, assignment)
+
+		work.Do(context.Background())
+
+		reminders, err := query.AssignmentReminder.
+			WithContext(context.Background()).
+			Where(query.AssignmentReminder.AssignmentProjectID.Eq(acceptedProject.ID)).
+			Find()
+		assert.NoError(t, err)
+		assert.Len(t, reminders, 2)
+
+		assertOutgoingMails(t, student1.GitlabEmail, database.DeadlineReminderMail, 2)
+	})
+}
+
+func assertOutgoingMails(t *testing.T, recipient string, kind database.MailKind, expected int64) {
+	count, err := query.OutgoingMail.
+		WithContext(context.Background()).
+		Where(query.OutgoingMail.Recipient.Eq(recipient)).
+		Where(query.OutgoingMail.Kind.Eq(string(kind))).
+		Count()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, count)
+}
+
+func TestCurrentReminderOffset(t *testing.T) {
+	offsets := []time.Duration{72 * time.Hour, 24 * time.Hour}
+
+	t.Run("no offset reached", func(t *testing.T) {
+		_, ok := currentReminderOffset(offsets, 100*time.Hour)
+		assert.False(t, ok)
+	})
+
+	t.Run("first offset reached", func(t *testing.T) {
+		offset, ok := currentReminderOffset(offsets, 48*time.Hour)
+		assert.True(t, ok)
+		assert.Equal(t, 72*time.Hour, offset)
+	})
+
+	t.Run("all offsets reached", func(t *testing.T) {
+		offset, ok := currentReminderOffset(offsets, 1*time.Hour)
+		assert.True(t, ok)
+		assert.Equal(t, 24*time.Hour, offset)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
 )
 
@@ -114,7 +115,17 @@ func (w *DueAssignmentWork) closeAssignment(ctx context.Context, assignment *dat
 	}
 
 	assignment.Closed = true
-	_, err = query.Assignment.WithContext(ctx).Updates(assignment)
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if _, err := tx.Assignment.WithContext(ctx).Updates(assignment); err != nil {
+			return err
+		}
+
+		return webhook.NewOutbox(ctx, tx, assignment.ClassroomID).Emit(database.AssignmentClosedEvent, webhook.AssignmentData{
+			AssignmentID: assignment.ID,
+			Name:         assignment.Name,
+			DueDate:      assignment.DueDate,
+		})
+	})
 	if err != nil {
 		return err
 	}
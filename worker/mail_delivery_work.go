@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+)
+
+const (
+	// maxDeliveryAttempts is the number of attempts after which a mail is marked as failed.
+	maxDeliveryAttempts = 8
+	// deliveryBackoff is the delay before the second attempt, which doubles with every further attempt.
+	deliveryBackoff = 1 * time.Minute
+	// deliveryBatchSize limits the number of mails sent per run.
+	deliveryBatchSize = 100
+)
+
+// MailDeliveryWork sends the mails of the outbox.
+// Failed deliveries are retried with exponential backoff until maxDeliveryAttempts is reached.
+// Mails rejected permanently by the mail server are marked as bounced and not retried.
+// Mails the recipient has turned off are marked as suppressed.
+type MailDeliveryWork struct {
+	mailRepo mail.Repository
+}
+
+// NewMailDeliveryWork creates a new instance of MailDeliveryWork sending the mails with the given repository.
+func NewMailDeliveryWork(mailRepo mail.Repository) *MailDeliveryWork {
+	return &MailDeliveryWork{mailRepo: mailRepo}
+}
+
+// Do sends all pending mails whose next attempt is due.
+func (w *MailDeliveryWork) Do(ctx context.Context) {
+	mails := w.getMails2Deliver(ctx)
+	for _, outgoingMail := range mails {
+		w.deliverMail(ctx, outgoingMail)
+	}
+}
+
+// getMails2Deliver retrieves the pending mails whose next attempt is due, oldest first.
+func (w *MailDeliveryWork) getMails2Deliver(ctx context.Context) []*database.OutgoingMail {
+	queryOutgoingMail := query.OutgoingMail
+	mails, err := queryOutgoingMail.
+		WithContext(ctx).
+		Where(queryOutgoingMail.Status.Eq(string(database.MailPending))).
+		Where(queryOutgoingMail.NextAttemptAt.Lte(time.Now())).
+		Order(queryOutgoingMail.NextAttemptAt).
+		Limit(deliveryBatchSize).
+		Find()
+	if err != nil {
+		log.Default().Printf("Error occurred while fetching mails to deliver: %s", err.Error())
+		return []*database.OutgoingMail{}
+	}
+
+	return mails
+}
+
+// deliverMail sends the mail and records the result of the attempt.
+func (w *MailDeliveryWork) deliverMail(ctx context.Context, outgoingMail *database.OutgoingMail) {
+	outgoingMail.Attempts++
+
+	err := mail.DeliverOutgoingMail(w.mailRepo, outgoingMail)
+	switch {
+	case err == nil:
+		now := time.Now()
+		outgoingMail.Status = database.MailSent
+		outgoingMail.SentAt = &now
+		outgoingMail.LastError = nil
+	case errors.Is(err, mail.ErrNotificationSuppressed):
+		outgoingMail.Status = database.MailSuppressed
+		outgoingMail.LastError = nil
+	case mail.IsPermanentError(err):
+		log.Default().Printf("MailDeliveryWorker: Mail %s to %s bounced: %s", outgoingMail.ID, outgoingMail.Recipient, err.Error())
+		outgoingMail.Status = database.MailBounced
+		outgoingMail.LastError = utils.Ptr(err.Error())
+	case outgoingMail.Attempts >= maxDeliveryAttempts:
+		log.Default().Printf("MailDeliveryWorker: Giving up mail %s to %s: %s", outgoingMail.ID, outgoingMail.Recipient, err.Error())
+		outgoingMail.Status = database.MailFailed
+		outgoingMail.LastError = utils.Ptr(err.Error())
+	default:
+		outgoingMail.NextAttemptAt = time.Now().Add(deliveryBackoff << (outgoingMail.Attempts - 1))
+		outgoingMail.LastError = utils.Ptr(err.Error())
+	}
+
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.OutgoingMail.WithContext(ctx).Save(outgoingMail); err != nil {
+			return err
+		}
+
+		// The invitation can't be accepted without its mail, so the owner has to invite again or resend it
+		if outgoingMail.ClassroomInvitationID != nil && (outgoingMail.Status == database.MailFailed || outgoingMail.Status == database.MailBounced) {
+			_, err := tx.ClassroomInvitation.
+				WithContext(ctx).
+				Where(tx.ClassroomInvitation.ID.Eq(*outgoingMail.ClassroomInvitationID)).
+				Where(tx.ClassroomInvitation.Status.Eq(uint8(database.ClassroomInvitationPending))).
+				Update(tx.ClassroomInvitation.Status, database.ClassroomInvitationFailed)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		log.Default().Printf("MailDeliveryWorker: Error occurred while saving mail %s: %s", outgoingMail.ID, err.Error())
+	}
+}
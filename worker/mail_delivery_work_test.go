@@ -0,0 +1,190 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	mailRepoMock "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail/_mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+	db_tests "gitlab.hs-flensburg.de/gitlab-classroom/utils/tests"
+)
+
+func TestMailDeliveryWorker(t *testing.T) {
+	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+
+	pg, err := db_tests.StartPostgres()
+	if err != nil {
+		t.Fatalf("Failed to start postgres container: %s", err.Error())
+	}
+
+	dbURL, err := pg.ConnectionString(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to obtain connection string: %s", err.Error())
+	}
+
+	db, err := gorm.Open(postgres.Open(dbURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("could not get database connection: %s", err.Error())
+	}
+
+	err = database.MigrateDatabase(sqlDB)
+	if err != nil {
+		t.Fatalf("could not migrate database: %s", err.Error())
+	}
+
+	query.SetDefault(db)
+	mailRepo := mailRepoMock.NewMockRepository(t)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+
+	work := NewMailDeliveryWork(mailRepo)
+
+	enqueue := func(t *testing.T, to string) *database.OutgoingMail {
+		outbox := mail.NewOutboxRepository(context.Background(), query.Q, &classroom.ID)
+		err := outbox.SendClassroomRemovalNotification(to, "Removed", mail.ClassroomRemovalData{ClassroomName: classroom.Name})
+		assert.NoError(t, err)
+
+		outgoingMail, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.Recipient.Eq(to)).
+			First()
+		assert.NoError(t, err)
+		return outgoingMail
+	}
+
+	reload := func(t *testing.T, outgoingMail *database.OutgoingMail) *database.OutgoingMail {
+		reloaded, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.ID.Eq(outgoingMail.ID)).
+			First()
+		assert.NoError(t, err)
+		return reloaded
+	}
+
+	t.Run("sends pending mails", func(t *testing.T) {
+		outgoingMail := enqueue(t, "sent@example.com")
+
+		mailRepo.EXPECT().
+			SendClassroomRemovalNotification("sent@example.com", "Removed", mail.ClassroomRemovalData{ClassroomName: classroom.Name}).
+			Return(nil).
+			Times(1)
+
+		work.Do(context.Background())
+
+		mailRepo.AssertExpectations(t)
+
+		outgoingMail = reload(t, outgoingMail)
+		assert.Equal(t, database.MailSent, outgoingMail.Status)
+		assert.Equal(t, 1, outgoingMail.Attempts)
+		assert.NotNil(t, outgoingMail.SentAt)
+	})
+
+	t.Run("retries failed mails with backoff", func(t *testing.T) {
+		outgoingMail := enqueue(t, "retry@example.com")
+
+		mailRepo.EXPECT().
+			SendClassroomRemovalNotification("retry@example.com", mock.Anything, mock.Anything).
+			Return(errors.New("connection refused")).
+			Times(1)
+
+		work.Do(context.Background())
+
+		mailRepo.AssertExpectations(t)
+
+		outgoingMail = reload(t, outgoingMail)
+		assert.Equal(t, database.MailPending, outgoingMail.Status)
+		assert.Equal(t, 1, outgoingMail.Attempts)
+		assert.Equal(t, "connection refused", *outgoingMail.LastError)
+		assert.True(t, outgoingMail.NextAttemptAt.After(time.Now()))
+
+		// The next attempt is not due yet
+		work.Do(context.Background())
+	})
+
+	t.Run("marks mails as failed after the last attempt", func(t *testing.T) {
+		invitation := factory.Invitation(classroom.ID)
+		outbox := mail.NewOutboxRepository(context.Background(), query.Q, &classroom.ID)
+		err := outbox.SendClassroomInvitation("failed@example.com", "Invitation", mail.ClassroomInvitationData{InvitationID: invitation.ID})
+		assert.NoError(t, err)
+
+		_, err = query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.Recipient.Eq("failed@example.com")).
+			Update(query.OutgoingMail.Attempts, maxDeliveryAttempts-1)
+		assert.NoError(t, err)
+
+		mailRepo.EXPECT().
+			SendClassroomInvitation("failed@example.com", mock.Anything, mock.Anything).
+			Return(errors.New("connection refused")).
+			Times(1)
+
+		work.Do(context.Background())
+
+		mailRepo.AssertExpectations(t)
+
+		outgoingMail, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.Recipient.Eq("failed@example.com")).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.MailFailed, outgoingMail.Status)
+
+		invitation, err = query.ClassroomInvitation.
+			WithContext(context.Background()).
+			Where(query.ClassroomInvitation.ID.Eq(invitation.ID)).
+			First()
+		assert.NoError(t, err)
+		assert.Equal(t, database.ClassroomInvitationFailed, invitation.Status)
+	})
+
+	t.Run("marks rejected mails as bounced", func(t *testing.T) {
+		outgoingMail := enqueue(t, "bounced@example.com")
+
+		mailRepo.EXPECT().
+			SendClassroomRemovalNotification("bounced@example.com", mock.Anything, mock.Anything).
+			Return(errors.New("gomail: could not send email 1: 550 5.1.1 User unknown")).
+			Times(1)
+
+		work.Do(context.Background())
+
+		mailRepo.AssertExpectations(t)
+
+		outgoingMail = reload(t, outgoingMail)
+		assert.Equal(t, database.MailBounced, outgoingMail.Status)
+		assert.Equal(t, 1, outgoingMail.Attempts)
+	})
+
+	t.Run("marks turned off notifications as suppressed", func(t *testing.T) {
+		outgoingMail := enqueue(t, "suppressed@example.com")
+
+		mailRepo.EXPECT().
+			SendClassroomRemovalNotification("suppressed@example.com", mock.Anything, mock.Anything).
+			Return(mail.ErrNotificationSuppressed).
+			Times(1)
+
+		work.Do(context.Background())
+
+		mailRepo.AssertExpectations(t)
+
+		outgoingMail = reload(t, outgoingMail)
+		assert.Equal(t, database.MailSuppressed, outgoingMail.Status)
+		assert.Nil(t, outgoingMail.SentAt)
+		assert.Nil(t, outgoingMail.LastError)
+	})
+}
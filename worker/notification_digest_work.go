@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+)
+
+// digestPeriod is the time pending notifications are collected before they are sent in a digest.
+const digestPeriod = 24 * time.Hour
+
+// NotificationDigestWork sends the pending notifications of users who chose the digest mode in a single mail.
+// A digest is sent as soon as the oldest pending notification of a user is older than one day,
+// so every user receives at most one digest a day.
+type NotificationDigestWork struct{}
+
+// NewNotificationDigestWork creates a new instance of NotificationDigestWork.
+func NewNotificationDigestWork() *NotificationDigestWork {
+	return &NotificationDigestWork{}
+}
+
+// Do sends the digests of all users with notifications pending for longer than the digest period.
+func (w *NotificationDigestWork) Do(ctx context.Context) {
+	users := w.getUsers2Notify(ctx, time.Now().Add(-digestPeriod))
+	for _, user := range users {
+		if err := w.sendDigest(ctx, user); err != nil {
+			log.Default().Printf("NotificationDigestWorker: Error occurred while sending digest to %s: %s", user.GitlabEmail, err.Error())
+		}
+	}
+}
+
+// getUsers2Notify retrieves the users with pending notifications created before the given time.
+func (w *NotificationDigestWork) getUsers2Notify(ctx context.Context, before time.Time) []*database.User {
+	queryPendingNotification := query.PendingNotification
+	var userIDs []int
+	err := queryPendingNotification.
+		WithContext(ctx).
+		Distinct(queryPendingNotification.UserID).
+		Where(queryPendingNotification.CreatedAt.Lte(before)).
+		Pluck(queryPendingNotification.UserID, &userIDs)
+	if err != nil {
+		log.Default().Printf("Error occurred while fetching users to notify: %s", err.Error())
+		return []*database.User{}
+	}
+
+	if len(userIDs) == 0 {
+		return []*database.User{}
+	}
+
+	users, err := query.User.
+		WithContext(ctx).
+		Where(query.User.ID.In(userIDs...)).
+		Find()
+	if err != nil {
+		log.Default().Printf("Error occurred while fetching users to notify: %s", err.Error())
+		return []*database.User{}
+	}
+
+	return users
+}
+
+// sendDigest writes all pending notifications of the user in one mail to the outbox and deletes them.
+func (w *NotificationDigestWork) sendDigest(ctx context.Context, user *database.User) error {
+	queryPendingNotification := query.PendingNotification
+	notifications, err := queryPendingNotification.
+		WithContext(ctx).
+		Where(queryPendingNotification.UserID.Eq(user.ID)).
+		Order(queryPendingNotification.CreatedAt).
+		Find()
+	if err != nil {
+		return err
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return query.Q.Transaction(func(tx *query.Query) error {
+		err := mail.NewOutboxRepository(ctx, tx, nil).SendNotificationDigest(user.GitlabEmail,
+			"Your daily notification digest",
+			mail.NotificationDigestData{
+				RecipientName: user.Name,
+				Notifications: utils.Map(notifications, func(notification *database.PendingNotification) mail.DigestNotification {
+					return mail.DigestNotification{
+						Subject:   notification.Subject,
+						Path:      notification.Path,
+						CreatedAt: notification.CreatedAt,
+					}
+				}),
+			})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.PendingNotification.WithContext(ctx).Delete(notifications...)
+		return err
+	})
+}
@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/mail"
+	mailRepoMock "gitlab.hs-flensburg.de/gitlab-classroom/repository/mail/_mock"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+	db_tests "gitlab.hs-flensburg.de/gitlab-classroom/utils/tests"
+)
+
+func TestNotificationDigestWorker(t *testing.T) {
+	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+
+	pg, err := db_tests.StartPostgres()
+	if err != nil {
+		t.Fatalf("Failed to start postgres container: %s", err.Error())
+	}
+
+	dbURL, err := pg.ConnectionString(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to obtain connection string: %s", err.Error())
+	}
+
+	db, err := gorm.Open(postgres.Open(dbURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("could not get database connection: %s", err.Error())
+	}
+
+	err = database.MigrateDatabase(sqlDB)
+	if err != nil {
+		t.Fatalf("could not migrate database: %s", err.Error())
+	}
+
+	query.SetDefault(db)
+	mailRepo := mailRepoMock.NewMockRepository(t)
+	preferenceRepo := mail.NewPreferenceRepository(mailRepo)
+
+	user := factory.User()
+	err = query.NotificationPreference.WithContext(context.Background()).Create(
+		&database.NotificationPreference{UserID: user.ID, Category: database.NewAssignmentsCategory, Mode: database.DigestMode},
+		&database.NotificationPreference{UserID: user.ID, Category: database.TeamChangesCategory, Mode: database.OffMode},
+	)
+	if err != nil {
+		t.Fatalf("could not insert notification preferences: %s", err.Error())
+	}
+
+	work := NewNotificationDigestWork()
+
+	t.Run("sends immediate notifications", func(t *testing.T) {
+		mailRepo.EXPECT().
+			SendDeadlineReminder(user.GitlabEmail, "Reminder", mock.Anything).
+			Return(nil).
+			Times(1)
+
+		err := preferenceRepo.SendDeadlineReminder(user.GitlabEmail, "Reminder", mail.AssignmentReminderData{})
+		assert.NoError(t, err)
+
+		mailRepo.AssertExpectations(t)
+	})
+
+	t.Run("drops disabled notifications", func(t *testing.T) {
+		err := preferenceRepo.SendClassroomRemovalNotification(user.GitlabEmail, "Removed", mail.ClassroomRemovalData{})
+		assert.ErrorIs(t, err, mail.ErrNotificationSuppressed)
+	})
+
+	t.Run("collects digest notifications", func(t *testing.T) {
+		err := preferenceRepo.SendAssignmentNotification(user.GitlabEmail, "New assignment", mail.AssignmentNotificationData{JoinPath: "/join"})
+		assert.NoError(t, err)
+
+		notifications, err := query.PendingNotification.WithContext(context.Background()).Find()
+		assert.NoError(t, err)
+		assert.Len(t, notifications, 1)
+		assert.Equal(t, "/join", notifications[0].Path)
+	})
+
+	t.Run("does not send digest before the digest period", func(t *testing.T) {
+		work.Do(context.Background())
+
+		count, err := query.PendingNotification.WithContext(context.Background()).Count()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("sends digest", func(t *testing.T) {
+		_, err := query.PendingNotification.
+			WithContext(context.Background()).
+			Where(query.PendingNotification.UserID.Eq(user.ID)).
+			Update(query.PendingNotification.CreatedAt, time.Now().Add(-25*time.Hour))
+		assert.NoError(t, err)
+
+		work.Do(context.Background())
+
+		count, err := query.PendingNotification.WithContext(context.Background()).Count()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		outgoingMail, err := query.OutgoingMail.
+			WithContext(context.Background()).
+			Where(query.OutgoingMail.Recipient.Eq(user.GitlabEmail)).
+			Where(query.OutgoingMail.Kind.Eq(string(database.NotificationDigestMail))).
+			First()
+		assert.NoError(t, err)
+
+		mailRepo.EXPECT().
+			SendNotificationDigest(user.GitlabEmail, mock.Anything, mock.MatchedBy(func(data mail.NotificationDigestData) bool {
+				return len(data.Notifications) == 1 && data.Notifications[0].Subject == "New assignment"
+			})).
+			Return(nil).
+			Times(1)
+
+		assert.NoError(t, mail.DeliverOutgoingMail(mailRepo, outgoingMail))
+
+		mailRepo.AssertExpectations(t)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	gitlabConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/gitlab"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
@@ -22,11 +23,12 @@ import (
 type SyncGitlabDbWork struct {
 	gitlabConfig gitlabConfig.Config
 	publicURL    *url.URL
+	broker       *events.Broker
 }
 
-// NewSyncGitlabDbWork creates a new instance of SyncGitlabDbWork.
-func NewSyncGitlabDbWork(config gitlabConfig.Config, publicUrl *url.URL) *SyncGitlabDbWork {
-	return &SyncGitlabDbWork{gitlabConfig: config, publicURL: publicUrl}
+// NewSyncGitlabDbWork creates a new instance of SyncGitlabDbWork, which streams the changes of the sync to the broker.
+func NewSyncGitlabDbWork(config gitlabConfig.Config, publicUrl *url.URL, broker *events.Broker) *SyncGitlabDbWork {
+	return &SyncGitlabDbWork{gitlabConfig: config, publicURL: publicUrl, broker: broker}
 }
 
 // Do synchronizes classrooms, teams, and projects between GitLab and the local database.
@@ -58,7 +60,7 @@ func (w *SyncGitlabDbWork) Do(ctx context.Context) {
 		for _, assignment := range classroom.Assignments {
 			projects := w.getAssignmentProjects(ctx, assignment.ID)
 			for _, project := range projects {
-				w.syncProject(ctx, *project, repo)
+				w.syncProject(ctx, classroom, *project, repo)
 			}
 		}
 	}
@@ -99,6 +101,7 @@ func (w *SyncGitlabDbWork) syncClassroom(ctx context.Context, dbClassroom databa
 				_, err := query.Classroom.WithContext(ctx).Delete(&dbClassroom)
 				if err == nil {
 					log.Default().Printf("Classroom %s (ID=%d) deleted due to group deletion or member classroom_bot removal via GitLab.", dbClassroom.Name, dbClassroom.GroupID)
+					w.publish(dbClassroom.ID, memberIDs(dbClassroom.Member), events.SyncData{Change: events.ClassroomDeletedChange})
 				}
 			} else if gitLabError.Response.StatusCode == 401 {
 				if strings.Contains(gitLabError.Message, "error: invalid_token") {
@@ -106,12 +109,14 @@ func (w *SyncGitlabDbWork) syncClassroom(ctx context.Context, dbClassroom databa
 					err := query.Classroom.WithContext(ctx).Save(&dbClassroom)
 					if err == nil {
 						log.Default().Printf("Classroom %s (ID=%d) archived due to revoked access token", dbClassroom.Name, dbClassroom.GroupID)
+						w.publish(dbClassroom.ID, memberIDs(dbClassroom.Member), events.SyncData{Change: events.ClassroomArchivedChange})
 					}
 				} else if strings.Contains(gitLabError.Message, "message: 401 Unauthorized") {
 					dbClassroom.PotentiallyDeleted = true
 					err := query.Classroom.WithContext(ctx).Save(&dbClassroom)
 					if err == nil {
 						log.Default().Printf("Classroom %s (ID=%d) marked as potentially deleted due to 401 Unauthorized. Group access token could be revoked or group could be deleted via GitLab. Clarify on next user access of classroom.", dbClassroom.Name, dbClassroom.GroupID) // Clarify in classroom middleware
+						w.publish(dbClassroom.ID, memberIDs(dbClassroom.Member), events.SyncData{Change: events.ClassroomPotentiallyDeletedChange})
 					}
 				}
 			}
@@ -146,6 +151,7 @@ func (w *SyncGitlabDbWork) syncClassroomMember(ctx context.Context, groupId int,
 			log.Default().Printf("Error could not remove member [%d] from classroom %d: %s", member.UserID, groupId, err.Error())
 		} else {
 			log.Default().Printf("Removed member %d from classroom %d", member.UserID, groupId)
+			w.publish(member.ClassroomID, []int{member.UserID}, events.SyncData{Change: events.MemberRemovedChange, UserID: &member.UserID})
 		}
 	}
 
@@ -165,6 +171,7 @@ func (w *SyncGitlabDbWork) syncClassroomMember(ctx context.Context, groupId int,
 func (w *SyncGitlabDbWork) syncTeamMember(ctx context.Context, groupId int, dbMember []*database.UserClassrooms, repo gitlab.Repository) {
 	// TODO delete Team if teamsize is 1
 	handleLeftMembers := func(context context.Context, member *database.UserClassrooms, groupId int, repo gitlab.Repository) {
+		teamID := member.TeamID
 		member.TeamID = nil
 		member.Team = nil
 		err := query.UserClassrooms.WithContext(ctx).Save(member)
@@ -172,6 +179,7 @@ func (w *SyncGitlabDbWork) syncTeamMember(ctx context.Context, groupId int, dbMe
 			log.Default().Printf("Error could not remove member [%d] from team %d: %s", member.UserID, groupId, err.Error())
 			return
 		}
+		w.publish(member.ClassroomID, []int{member.UserID}, events.SyncData{Change: events.TeamMemberRemovedChange, UserID: &member.UserID, TeamID: teamID})
 	}
 
 	handleAddedMembers := func(context context.Context, member *model.User, groupId int, repo gitlab.Repository) {
@@ -270,6 +278,7 @@ func (w *SyncGitlabDbWork) syncTeam(ctx context.Context, classroom *database.Cla
 			_, err := query.Team.WithContext(ctx).Delete(&dbTeam)
 			if err == nil {
 				log.Default().Printf("Team %s marked as deleted via gitlab", dbTeam.Name)
+				w.publish(dbTeam.ClassroomID, memberIDs(dbTeam.Member), events.SyncData{Change: events.TeamDeletedChange, TeamID: &dbTeam.ID})
 			}
 		} else {
 			log.Default().Printf("Error while fetching group with id %d. ErrorMsg: %s", dbTeam.GroupID, err.Error())
@@ -310,7 +319,7 @@ func (w *SyncGitlabDbWork) getAssignmentProjects(ctx context.Context, assignment
 }
 
 // syncProject synchronizes the project data between GitLab and the local database.
-func (w *SyncGitlabDbWork) syncProject(ctx context.Context, dbProject database.AssignmentProjects, repo gitlab.Repository) {
+func (w *SyncGitlabDbWork) syncProject(ctx context.Context, classroom *database.Classroom, dbProject database.AssignmentProjects, repo gitlab.Repository) {
 	_, err := repo.GetProjectById(dbProject.ProjectID)
 	if err == nil || !strings.Contains(err.Error(), "404 {message: 404 Project Not Found}") {
 		return
@@ -322,4 +331,28 @@ func (w *SyncGitlabDbWork) syncProject(ctx context.Context, dbProject database.A
 	}
 
 	log.Default().Printf("Project with id %d deleted via gitlab", dbProject.ProjectID)
+
+	var teamMemberIDs []int
+	for _, team := range classroom.Teams {
+		if team.ID == dbProject.TeamID {
+			teamMemberIDs = memberIDs(team.Member)
+		}
+	}
+	w.publish(classroom.ID, teamMemberIDs, events.SyncData{Change: events.ProjectDeletedChange, TeamID: &dbProject.TeamID, ProjectID: &dbProject.ID})
+}
+
+// publish streams a change of the sync to the classroom and the affected users.
+func (w *SyncGitlabDbWork) publish(classroomID uuid.UUID, userIDs []int, data events.SyncData) {
+	w.broker.Publish(events.Event{
+		Type:        events.SyncEvent,
+		ClassroomID: classroomID,
+		UserIDs:     userIDs,
+		Data:        data,
+	})
+}
+
+func memberIDs(members []*database.UserClassrooms) []int {
+	return utils.Map(members, func(member *database.UserClassrooms) int {
+		return member.UserID
+	})
 }
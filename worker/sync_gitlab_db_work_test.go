@@ -11,6 +11,7 @@ import (
 	gitlabConfig "gitlab.hs-flensburg.de/gitlab-classroom/config/gitlab"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
 	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/events"
 	gitlabRepoMock "gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/_mock"
 	"gitlab.hs-flensburg.de/gitlab-classroom/repository/gitlab/model"
 	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
@@ -73,7 +74,8 @@ func TestSyncClassroomsWork(t *testing.T) {
 
 	publicUrl := &url.URL{Scheme: "http", Host: "localhost"}
 
-	w := NewSyncGitlabDbWork(&gitlabConfig.GitlabConfig{}, publicUrl)
+	broker := events.NewBroker()
+	w := NewSyncGitlabDbWork(&gitlabConfig.GitlabConfig{}, publicUrl, broker)
 
 	// Test the getUnarchivedClassrooms method.
 	t.Run("getUnarchivedClassrooms", func(t *testing.T) {
@@ -358,10 +360,22 @@ func TestSyncClassroomsWork(t *testing.T) {
 			Return(nil, fiber.NewError(404, "404 {message: 404 Project Not Found}")).
 			Times(1)
 
-		w.syncProject(context.Background(), *assignment1Project, repo)
+		subscription := broker.Subscribe(events.ForClassroom(classroom1.ID))
+		defer broker.Unsubscribe(subscription)
+
+		w.syncProject(context.Background(), classroom1, *assignment1Project, repo)
 
 		repo.AssertExpectations(t)
 
+		var event events.Event
+		select {
+		case event = <-subscription.Events():
+		case <-time.After(5 * time.Second):
+			t.Fatal("no sync event published")
+		}
+		assert.Equal(t, events.SyncEvent, event.Type)
+		assert.Equal(t, events.ProjectDeletedChange, event.Data.(events.SyncData).Change)
+
 		deletedProject, err := query.AssignmentProjects.WithContext(context.Background()).
 			Where(query.AssignmentProjects.ID.Eq(assignment1Project.ID)).
 			First()
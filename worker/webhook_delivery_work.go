@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils"
+)
+
+const (
+	// maxWebhookAttempts is the number of attempts after which a delivery is marked as failed.
+	maxWebhookAttempts = 6
+	// webhookBackoff is the delay before the second attempt, which doubles with every further attempt.
+	webhookBackoff = 1 * time.Minute
+	// webhookBatchSize limits the number of deliveries posted per run.
+	webhookBatchSize = 100
+)
+
+// WebhookDeliveryWork posts the pending deliveries to the webhooks of the classrooms.
+// Failed deliveries are retried with exponential backoff until maxWebhookAttempts is reached.
+type WebhookDeliveryWork struct {
+	sender *webhook.Sender
+}
+
+// NewWebhookDeliveryWork creates a new instance of WebhookDeliveryWork posting the deliveries with the given sender.
+func NewWebhookDeliveryWork(sender *webhook.Sender) *WebhookDeliveryWork {
+	return &WebhookDeliveryWork{sender: sender}
+}
+
+// Do posts all pending deliveries whose next attempt is due.
+func (w *WebhookDeliveryWork) Do(ctx context.Context) {
+	deliveries := w.getDeliveries2Post(ctx)
+	for _, delivery := range deliveries {
+		w.postDelivery(ctx, delivery)
+	}
+}
+
+// getDeliveries2Post retrieves the pending deliveries whose next attempt is due, oldest first.
+func (w *WebhookDeliveryWork) getDeliveries2Post(ctx context.Context) []*database.WebhookDelivery {
+	queryDelivery := query.WebhookDelivery
+	deliveries, err := queryDelivery.
+		WithContext(ctx).
+		Preload(queryDelivery.Webhook).
+		Where(queryDelivery.Status.Eq(string(database.WebhookPending))).
+		Where(queryDelivery.NextAttemptAt.Lte(time.Now())).
+		Order(queryDelivery.NextAttemptAt).
+		Limit(webhookBatchSize).
+		Find()
+	if err != nil {
+		log.Default().Printf("Error occurred while fetching webhook deliveries: %s", err.Error())
+		return []*database.WebhookDelivery{}
+	}
+
+	return deliveries
+}
+
+// postDelivery posts the delivery and records the result of the attempt.
+func (w *WebhookDeliveryWork) postDelivery(ctx context.Context, delivery *database.WebhookDelivery) {
+	delivery.Attempts++
+
+	if !delivery.Webhook.Active {
+		delivery.Status = database.WebhookFailed
+		delivery.LastError = utils.Ptr("The webhook is disabled")
+		w.saveDelivery(ctx, delivery)
+		return
+	}
+
+	status, err := w.sender.Deliver(&delivery.Webhook, delivery)
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case err == nil:
+		now := time.Now()
+		delivery.Status = database.WebhookDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+	case delivery.Attempts >= maxWebhookAttempts:
+		log.Default().Printf("WebhookDeliveryWorker: Giving up delivery %s to %s: %s", delivery.ID, delivery.Webhook.URL, err.Error())
+		delivery.Status = database.WebhookFailed
+		delivery.LastError = utils.Ptr(err.Error())
+	default:
+		delivery.NextAttemptAt = time.Now().Add(webhookBackoff << (delivery.Attempts - 1))
+		delivery.LastError = utils.Ptr(err.Error())
+	}
+
+	w.saveDelivery(ctx, delivery)
+}
+
+func (w *WebhookDeliveryWork) saveDelivery(ctx context.Context, delivery *database.WebhookDelivery) {
+	queryDelivery := query.WebhookDelivery
+	_, err := queryDelivery.
+		WithContext(ctx).
+		Where(queryDelivery.ID.Eq(delivery.ID)).
+		Select(queryDelivery.Status, queryDelivery.Attempts, queryDelivery.NextAttemptAt, queryDelivery.ResponseStatus, queryDelivery.LastError, queryDelivery.DeliveredAt).
+		Updates(delivery)
+	if err != nil {
+		log.Default().Printf("WebhookDeliveryWorker: Error occurred while saving delivery %s: %s", delivery.ID, err.Error())
+	}
+}
@@ -0,0 +1,153 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database"
+	"gitlab.hs-flensburg.de/gitlab-classroom/model/database/query"
+	"gitlab.hs-flensburg.de/gitlab-classroom/repository/webhook"
+	"gitlab.hs-flensburg.de/gitlab-classroom/utils/factory"
+	db_tests "gitlab.hs-flensburg.de/gitlab-classroom/utils/tests"
+)
+
+func TestWebhookDeliveryWorker(t *testing.T) {
+	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+
+	pg, err := db_tests.StartPostgres()
+	if err != nil {
+		t.Fatalf("Failed to start postgres container: %s", err.Error())
+	}
+
+	dbURL, err := pg.ConnectionString(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to obtain connection string: %s", err.Error())
+	}
+
+	db, err := gorm.Open(postgres.Open(dbURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("could not get database connection: %s", err.Error())
+	}
+
+	err = database.MigrateDatabase(sqlDB)
+	if err != nil {
+		t.Fatalf("could not migrate database: %s", err.Error())
+	}
+
+	query.SetDefault(db)
+
+	owner := factory.User()
+	classroom := factory.Classroom(owner.ID)
+
+	status := http.StatusOK
+	var received []webhook.Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhook.Payload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		received = append(received, payload)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	hook := &database.Webhook{
+		ClassroomID: classroom.ID,
+		URL:         server.URL,
+		Secret:      "secret",
+		Events:      []database.WebhookEvent{database.TeamCreatedEvent},
+		Active:      true,
+	}
+	err = query.Webhook.WithContext(context.Background()).Create(hook)
+	assert.NoError(t, err)
+
+	// The test server listens on the loopback address, to which the default sender does not connect
+	work := NewWebhookDeliveryWork(webhook.NewSenderWithClient(server.Client()))
+
+	emit := func(t *testing.T, event database.WebhookEvent) *database.WebhookDelivery {
+		err := webhook.NewOutbox(context.Background(), query.Q, classroom.ID).Emit(event, webhook.TeamData{Name: "Team"})
+		assert.NoError(t, err)
+
+		delivery, err := query.WebhookDelivery.
+			WithContext(context.Background()).
+			Where(query.WebhookDelivery.Event.Eq(string(event))).
+			Order(query.WebhookDelivery.CreatedAt.Desc()).
+			First()
+		if err != nil {
+			return nil
+		}
+		return delivery
+	}
+
+	reload := func(t *testing.T, delivery *database.WebhookDelivery) *database.WebhookDelivery {
+		reloaded, err := query.WebhookDelivery.
+			WithContext(context.Background()).
+			Where(query.WebhookDelivery.ID.Eq(delivery.ID)).
+			First()
+		assert.NoError(t, err)
+		return reloaded
+	}
+
+	t.Run("posts subscribed events", func(t *testing.T) {
+		received = nil
+		delivery := emit(t, database.TeamCreatedEvent)
+		assert.NotNil(t, delivery)
+
+		work.Do(context.Background())
+
+		assert.Len(t, received, 1)
+		assert.Equal(t, database.TeamCreatedEvent, received[0].Event)
+		assert.Equal(t, classroom.ID, received[0].ClassroomID)
+
+		delivery = reload(t, delivery)
+		assert.Equal(t, database.WebhookDelivered, delivery.Status)
+		assert.Equal(t, http.StatusOK, *delivery.ResponseStatus)
+		assert.NotNil(t, delivery.DeliveredAt)
+	})
+
+	t.Run("ignores other events", func(t *testing.T) {
+		delivery := emit(t, database.MemberJoinedEvent)
+		assert.Nil(t, delivery)
+	})
+
+	t.Run("retries failed deliveries with backoff", func(t *testing.T) {
+		received = nil
+		status = http.StatusServiceUnavailable
+		delivery := emit(t, database.TeamCreatedEvent)
+
+		work.Do(context.Background())
+
+		delivery = reload(t, delivery)
+		assert.Equal(t, database.WebhookPending, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusServiceUnavailable, *delivery.ResponseStatus)
+		assert.True(t, delivery.NextAttemptAt.After(time.Now()))
+
+		// The next attempt is not due yet
+		work.Do(context.Background())
+		assert.Len(t, received, 1)
+
+		_, err := query.WebhookDelivery.
+			WithContext(context.Background()).
+			Where(query.WebhookDelivery.ID.Eq(delivery.ID)).
+			Updates(&database.WebhookDelivery{Attempts: maxWebhookAttempts - 1, NextAttemptAt: time.Now()})
+		assert.NoError(t, err)
+
+		work.Do(context.Background())
+
+		delivery = reload(t, delivery)
+		assert.Equal(t, database.WebhookFailed, delivery.Status)
+		assert.Equal(t, maxWebhookAttempts, delivery.Attempts)
+	})
+}
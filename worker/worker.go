@@ -3,6 +3,10 @@
 // The main components of the package include:
 // - DueAssignmentWork: Handles the closure of assignments that have passed their due date.
 // - SyncGitlabDbWork: Synchronizes classrooms, teams, and projects between the local database and GitLab.
+// - AssignmentReminderWork: Sends reminder mails to team members before the due date of an assignment.
+// - NotificationDigestWork: Sends the collected notifications of users who chose the daily digest.
+// - MailDeliveryWork: Sends the mails of the outbox and retries failed deliveries.
+// - WebhookDeliveryWork: Posts the pending webhook deliveries of the classrooms and retries failed deliveries.
 // - Worker: Provides a mechanism to run tasks periodically at specified intervals.
 package worker
 
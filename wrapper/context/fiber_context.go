@@ -29,6 +29,7 @@ const (
 	assignmentProjectKey contextKey = "assignment-project"
 	classroomMember      contextKey = "classroom-member"
 	teamKey              contextKey = "team"
+	apiTokenKey          contextKey = "api-token"
 )
 
 // FiberContext wraps the fiber.Ctx to provide additional methods.
@@ -144,3 +145,14 @@ func (c *FiberContext) GetTeam() *database.Team {
 func (c *FiberContext) SetTeam(team *database.Team) {
 	c.Locals(teamKey, team)
 }
+
+// GetAPIToken returns the API token of the request or nil if the request is authenticated by the session.
+func (c *FiberContext) GetAPIToken() *database.APIToken {
+	token, _ := c.Locals(apiTokenKey).(*database.APIToken)
+	return token
+}
+
+// SetAPIToken sets the API token of the request in the context.
+func (c *FiberContext) SetAPIToken(token *database.APIToken) {
+	c.Locals(apiTokenKey, token)
+}
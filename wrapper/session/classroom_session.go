@@ -101,5 +101,9 @@ func (s *ClassroomSession) GetGitlabOauth2Token() (*oauth2.Token, error) {
 	if err := s.checkLogin(); err != nil {
 		return nil, err
 	}
-	return s.Get(gitLabOauth2Token).(*oauth2.Token), nil
+	token, ok := s.Get(gitLabOauth2Token).(*oauth2.Token)
+	if !ok {
+		return nil, errors.New("no GitLab token in session")
+	}
+	return token, nil
 }
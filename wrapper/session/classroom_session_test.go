@@ -201,3 +201,39 @@ func TestClassroomSession_checkLogin(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestBearerToken(t *testing.T) {
+	// Mock a fiber context
+	app := fiber.New()
+	req := new(fasthttp.RequestCtx)
+	ctx := app.AcquireCtx(req)
+	defer app.ReleaseCtx(ctx)
+
+	//Tests
+	t.Run("No Authorization Header", func(t *testing.T) {
+		_, ok := BearerToken(ctx)
+		assert.False(t, ok)
+	})
+
+	t.Run("Bearer Token", func(t *testing.T) {
+		ctx.Request().Header.Set(fiber.HeaderAuthorization, "Bearer gcr_token")
+
+		token, ok := BearerToken(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "gcr_token", token)
+	})
+
+	t.Run("Other Scheme", func(t *testing.T) {
+		ctx.Request().Header.Set(fiber.HeaderAuthorization, "Basic dXNlcjpwYXNz")
+
+		_, ok := BearerToken(ctx)
+		assert.False(t, ok)
+	})
+
+	t.Run("Empty Bearer Token", func(t *testing.T) {
+		ctx.Request().Header.Set(fiber.HeaderAuthorization, "Bearer ")
+
+		_, ok := BearerToken(ctx)
+		assert.False(t, ok)
+	})
+}
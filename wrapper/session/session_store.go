@@ -2,6 +2,7 @@ package session
 
 import (
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,6 +26,15 @@ var (
 	headerExtractor = csrf.CsrfFromHeader(HeaderName)
 )
 
+// BearerToken returns the API token of the Authorization header. These requests authenticate without a session.
+func BearerToken(c *fiber.Ctx) (string, bool) {
+	scheme, token, found := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
 var store *session.Store
 var CsrfConfig csrf.Config
 var once sync.Once
@@ -69,8 +79,10 @@ func InitSessionStore(dsn *string, publicURL *url.URL) {
 		store.RegisterType(&oauth2.Token{})
 
 		CsrfConfig = csrf.Config{
+			// Requests with an API token are not sent by a browser, so they need no csrf protection
 			Next: func(c *fiber.Ctx) bool {
-				return false
+				_, ok := BearerToken(c)
+				return ok
 			},
 			CookieName:        csrfCookieName,
 			CookieSameSite:    "Lax",